@@ -3,15 +3,23 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/go-logr/logr/funcr"
+	"github.com/projectsyn/lieutenant-api/pkg/inventory"
 	"github.com/projectsyn/lieutenant-api/pkg/service"
 	crlog "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
+// shutdownTimeout is how long running requests may take after the API received a signal to stop
+const shutdownTimeout = 10 * time.Second
+
 // Version is the lieutenant-api version (set during build)
 var (
 	Version   = "unreleased"
@@ -20,6 +28,35 @@ var (
 
 func main() {
 	crlog.SetLogger(newStdoutLogger())
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	inventoryRetention, err := inventory.ParseRetention(os.Getenv("INVENTORY_RETENTION"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err.Error())
+		os.Exit(1)
+	}
+	inventoryStore, err := inventory.NewStore(inventory.Config{
+		Backend:  os.Getenv("INVENTORY_BACKEND"),
+		Path:     os.Getenv("INVENTORY_PATH"),
+		URL:      os.Getenv("INVENTORY_INFLUXDB_URL"),
+		Database: os.Getenv("INVENTORY_INFLUXDB_DATABASE"),
+		Token:    os.Getenv("INVENTORY_INFLUXDB_TOKEN"),
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err.Error())
+		os.Exit(1)
+	}
+	if inventoryStore != nil {
+		defer inventoryStore.Close()
+		go inventory.RunRetention(ctx, inventoryStore, inventoryRetention)
+	}
+
+	readCache, err := service.NewReadCache(ctx, os.Getenv("READ_CACHE"), os.Getenv("NAMESPACE"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err.Error())
+		os.Exit(1)
+	}
 
 	conf := service.APIConfig{
 		APIVersion:       Version,
@@ -28,6 +65,8 @@ func main() {
 		OidcCLientID:     os.Getenv("OIDC_CLIENT_ID"),
 		VaultAddr:        os.Getenv("VAULT_ADDR"),
 		VaultLoginMethod: os.Getenv("VAULT_LOGIN_METHOD"),
+		Inventory:        inventoryStore,
+		ReadCache:        readCache,
 	}
 
 	e, err := service.NewAPIServer(conf)
@@ -35,10 +74,30 @@ func main() {
 		fmt.Fprintf(os.Stderr, "%v\n", err.Error())
 		os.Exit(1)
 	}
+
+	if err := service.RunTokenRevocation(ctx, conf.Namespace); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err.Error())
+		os.Exit(1)
+	}
 	fmt.Println("Version: " + Version)
 	fmt.Println("Build Date: " + BuildDate)
 
-	e.Logger.Fatal(e.Start(":8080"))
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- e.Start(":8080")
+	}()
+
+	select {
+	case err := <-serverErr:
+		fmt.Fprintf(os.Stderr, "%v\n", err.Error())
+		os.Exit(1)
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := e.Shutdown(shutdownCtx); err != nil {
+		e.Logger.Error(err)
+	}
 }
 
 func newStdoutLogger() logr.Logger {
@@ -0,0 +1,174 @@
+package api
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"regexp"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// IDGeneratorHaikunator generates IDs like `c-morning-dust-1234`
+	IDGeneratorHaikunator = "haikunator"
+	// IDGeneratorAlphanumeric generates IDs from random lowercase letters and digits
+	IDGeneratorAlphanumeric = "alphanumeric"
+	// IDGeneratorSlug generates IDs from the display name
+	IDGeneratorSlug = "slug"
+	// IDGeneratorPattern generates IDs from a custom pattern
+	IDGeneratorPattern = "pattern"
+
+	alphanumericIDLength = 8
+	maxSlugLength        = 40
+	idRetries            = 10
+
+	lowercase = "abcdefghijklmnopqrstuvwxyz"
+	digits    = "0123456789"
+)
+
+var slugInvalidChars = regexp.MustCompile("[^a-z0-9]+")
+
+// IDGenerator generates the part of an ID following the prefix.
+// The attempt is increased every time the ID generated for an object is already taken.
+type IDGenerator interface {
+	Generate(displayName string, attempt int) (string, error)
+}
+
+// HaikunatorIDGenerator generates a random combination of an adjective, a noun and a number
+type HaikunatorIDGenerator struct{}
+
+// Generate implements IDGenerator
+func (HaikunatorIDGenerator) Generate(string, int) (string, error) {
+	return h.Haikunate(), nil
+}
+
+// AlphanumericIDGenerator generates random lowercase letters and digits
+type AlphanumericIDGenerator struct {
+	Length int
+}
+
+// Generate implements IDGenerator
+func (g AlphanumericIDGenerator) Generate(string, int) (string, error) {
+	length := g.Length
+	if length <= 0 {
+		length = alphanumericIDLength
+	}
+	return randomString(length, lowercase+digits), nil
+}
+
+// SlugIDGenerator converts the display name into a slug.
+// A random suffix is added if the slug is already taken.
+// The fallback is used for objects without display name.
+type SlugIDGenerator struct {
+	Fallback IDGenerator
+}
+
+// Generate implements IDGenerator
+func (g SlugIDGenerator) Generate(displayName string, attempt int) (string, error) {
+	slug := Slug(displayName)
+	if len(slug) > maxSlugLength {
+		slug = strings.TrimRight(slug[:maxSlugLength], "-")
+	}
+	if slug == "" {
+		if g.Fallback == nil {
+			return "", fmt.Errorf("can't generate an ID from an empty display name")
+		}
+		return g.Fallback.Generate(displayName, attempt)
+	}
+	if attempt > 0 {
+		slug += "-" + randomString(4, lowercase+digits)
+	}
+	return slug, nil
+}
+
+// PatternIDGenerator generates IDs from a pattern.
+// In the pattern `?` is replaced by a random lowercase letter, `#` by a random digit and `*` by either, all other characters are kept.
+type PatternIDGenerator struct {
+	Pattern string
+}
+
+// Generate implements IDGenerator
+func (g PatternIDGenerator) Generate(string, int) (string, error) {
+	if g.Pattern == "" {
+		return "", fmt.Errorf("the ID pattern is empty")
+	}
+	id := strings.Builder{}
+	for _, c := range g.Pattern {
+		switch c {
+		case '?':
+			id.WriteString(randomString(1, lowercase))
+		case '#':
+			id.WriteString(randomString(1, digits))
+		case '*':
+			id.WriteString(randomString(1, lowercase+digits))
+		default:
+			id.WriteRune(c)
+		}
+	}
+	return id.String(), nil
+}
+
+// Slug converts the name to lowercase and replaces all characters except letters and digits with dashes
+func Slug(name string) string {
+	return strings.Trim(slugInvalidChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+// NewIDGenerator returns the generator with the given name
+func NewIDGenerator(name, pattern string) (IDGenerator, error) {
+	switch name {
+	case "", IDGeneratorHaikunator:
+		return HaikunatorIDGenerator{}, nil
+	case IDGeneratorAlphanumeric:
+		return AlphanumericIDGenerator{}, nil
+	case IDGeneratorSlug:
+		return SlugIDGenerator{Fallback: HaikunatorIDGenerator{}}, nil
+	case IDGeneratorPattern:
+		if pattern == "" {
+			return nil, fmt.Errorf("the %s ID generator requires a pattern", IDGeneratorPattern)
+		}
+		return PatternIDGenerator{Pattern: pattern}, nil
+	}
+	return nil, fmt.Errorf("unknown ID generator '%s'", name)
+}
+
+// GenerateID generates an ID with the given prefix which is a valid Kubernetes name.
+// IDs containing one of the reserved words separated by dashes are discarded.
+func GenerateID(generator IDGenerator, prefix, displayName string, attempt int, reserved []string) (Id, error) {
+	var lastErr error
+	for i := 0; i < idRetries; i++ {
+		suffix, err := generator.Generate(displayName, attempt+i)
+		if err != nil {
+			return "", err
+		}
+		id := prefix + suffix
+		if errs := validation.IsDNS1123Label(id); len(errs) > 0 {
+			lastErr = fmt.Errorf("generated ID '%s' is invalid: %s", id, strings.Join(errs, ", "))
+			continue
+		}
+		if word, found := containsReserved(suffix, reserved); found {
+			lastErr = fmt.Errorf("generated ID '%s' contains the reserved word '%s'", id, word)
+			continue
+		}
+		return Id(id), nil
+	}
+	return "", fmt.Errorf("could not generate a valid ID: %w", lastErr)
+}
+
+func containsReserved(id string, reserved []string) (string, bool) {
+	for _, part := range strings.Split(id, "-") {
+		if slices.Contains(reserved, part) {
+			return part, true
+		}
+	}
+	return "", false
+}
+
+func randomString(length int, chars string) string {
+	b := make([]byte, length)
+	for i := range b {
+		b[i] = chars[rand.IntN(len(chars))]
+	}
+	return string(b)
+}
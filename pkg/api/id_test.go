@@ -0,0 +1,103 @@
+package api
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sequenceIDGenerator []string
+
+func (g sequenceIDGenerator) Generate(_ string, attempt int) (string, error) {
+	return g[attempt%len(g)], nil
+}
+
+func TestIDGenerators(t *testing.T) {
+	tcs := map[string]struct {
+		generator   IDGenerator
+		displayName string
+		attempt     int
+		expected    string
+	}{
+		"alphanumeric": {
+			generator: AlphanumericIDGenerator{},
+			expected:  "^c-[a-z0-9]{8}$",
+		},
+		"alphanumeric with length": {
+			generator: AlphanumericIDGenerator{Length: 12},
+			expected:  "^c-[a-z0-9]{12}$",
+		},
+		"slug": {
+			generator:   SlugIDGenerator{},
+			displayName: "  My Cluster (Production) ",
+			expected:    "^c-my-cluster-production$",
+		},
+		"slug with collision": {
+			generator:   SlugIDGenerator{},
+			displayName: "My Cluster",
+			attempt:     1,
+			expected:    "^c-my-cluster-[a-z0-9]{4}$",
+		},
+		"long slug": {
+			generator:   SlugIDGenerator{},
+			displayName: "A very long display name of a cluster which doesn't fit into an ID",
+			expected:    "^c-a-very-long-display-name-of-a-cluster-wh$",
+		},
+		"slug fallback": {
+			generator: SlugIDGenerator{Fallback: AlphanumericIDGenerator{}},
+			expected:  "^c-[a-z0-9]{8}$",
+		},
+		"pattern": {
+			generator: PatternIDGenerator{Pattern: "prod-??##-**"},
+			expected:  "^c-prod-[a-z]{2}[0-9]{2}-[a-z0-9]{2}$",
+		},
+	}
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			id, err := GenerateID(tc.generator, ClusterIDPrefix, tc.displayName, tc.attempt, nil)
+			require.NoError(t, err)
+			assert.Regexp(t, regexp.MustCompile(tc.expected), id)
+		})
+	}
+}
+
+func TestGenerateIDReservedWords(t *testing.T) {
+	id, err := GenerateID(sequenceIDGenerator{"admin-cluster", "badminton", "cluster"}, TenantIDPrefix, "", 0, []string{"admin"})
+	require.NoError(t, err)
+	assert.Equal(t, Id("t-badminton"), id)
+
+	_, err = GenerateID(sequenceIDGenerator{"admin"}, TenantIDPrefix, "", 0, []string{"admin"})
+	assert.ErrorContains(t, err, "reserved word 'admin'")
+}
+
+func TestGenerateIDInvalid(t *testing.T) {
+	_, err := GenerateID(PatternIDGenerator{Pattern: "Prod_#"}, ClusterIDPrefix, "", 0, nil)
+	assert.ErrorContains(t, err, "is invalid")
+
+	_, err = GenerateID(SlugIDGenerator{}, ClusterIDPrefix, "!!!", 0, nil)
+	assert.Error(t, err)
+}
+
+func TestNewIDGenerator(t *testing.T) {
+	for name, expected := range map[string]IDGenerator{
+		"":                      HaikunatorIDGenerator{},
+		IDGeneratorHaikunator:   HaikunatorIDGenerator{},
+		IDGeneratorAlphanumeric: AlphanumericIDGenerator{},
+		IDGeneratorSlug:         SlugIDGenerator{Fallback: HaikunatorIDGenerator{}},
+	} {
+		generator, err := NewIDGenerator(name, "")
+		require.NoError(t, err)
+		assert.Equal(t, expected, generator)
+	}
+
+	generator, err := NewIDGenerator(IDGeneratorPattern, "##")
+	require.NoError(t, err)
+	assert.Equal(t, PatternIDGenerator{Pattern: "##"}, generator)
+
+	_, err = NewIDGenerator(IDGeneratorPattern, "")
+	assert.Error(t, err)
+	_, err = NewIDGenerator("uuid", "")
+	assert.ErrorContains(t, err, "unknown ID generator 'uuid'")
+}
@@ -26,6 +26,51 @@ const (
 	BearerAuthScopes = "BearerAuth.Scopes"
 )
 
+// Defines values for ClusterConnectivityStatus.
+const (
+	ClusterConnectivityStatusNeverSeen ClusterConnectivityStatus = "neverSeen"
+	ClusterConnectivityStatusOnline    ClusterConnectivityStatus = "online"
+	ClusterConnectivityStatusStale     ClusterConnectivityStatus = "stale"
+)
+
+// Valid indicates whether the value is a known member of the ClusterConnectivityStatus enum.
+func (e ClusterConnectivityStatus) Valid() bool {
+	switch e {
+	case ClusterConnectivityStatusNeverSeen:
+		return true
+	case ClusterConnectivityStatusOnline:
+		return true
+	case ClusterConnectivityStatusStale:
+		return true
+	default:
+		return false
+	}
+}
+
+// Defines values for InventoryQueryAggregation.
+const (
+	InventoryQueryAggregationCount    InventoryQueryAggregation = "count"
+	InventoryQueryAggregationDistinct InventoryQueryAggregation = "distinct"
+	InventoryQueryAggregationLast     InventoryQueryAggregation = "last"
+	InventoryQueryAggregationNone     InventoryQueryAggregation = "none"
+)
+
+// Valid indicates whether the value is a known member of the InventoryQueryAggregation enum.
+func (e InventoryQueryAggregation) Valid() bool {
+	switch e {
+	case InventoryQueryAggregationCount:
+		return true
+	case InventoryQueryAggregationDistinct:
+		return true
+	case InventoryQueryAggregationLast:
+		return true
+	case InventoryQueryAggregationNone:
+		return true
+	default:
+		return false
+	}
+}
+
 // Defines values for ListClustersParamsSortBy.
 const (
 	ListClustersParamsSortByDisplayName ListClustersParamsSortBy = "displayName"
@@ -47,7 +92,29 @@ func (e ListClustersParamsSortBy) Valid() bool {
 	}
 }
 
-// Annotations Unstructured key value map containing arbitrary metadata
+// Defines values for ListClustersParamsConnectivity.
+const (
+	ListClustersParamsConnectivityNeverSeen ListClustersParamsConnectivity = "neverSeen"
+	ListClustersParamsConnectivityOnline    ListClustersParamsConnectivity = "online"
+	ListClustersParamsConnectivityStale     ListClustersParamsConnectivity = "stale"
+)
+
+// Valid indicates whether the value is a known member of the ListClustersParamsConnectivity enum.
+func (e ListClustersParamsConnectivity) Valid() bool {
+	switch e {
+	case ListClustersParamsConnectivityNeverSeen:
+		return true
+	case ListClustersParamsConnectivityOnline:
+		return true
+	case ListClustersParamsConnectivityStale:
+		return true
+	default:
+		return false
+	}
+}
+
+// Annotations Unstructured key value map containing arbitrary metadata.
+// Values must be strings, other values are rejected with 400 naming the offending keys.
 type Annotations map[string]interface{}
 
 // Cluster defines model for Cluster.
@@ -137,25 +204,79 @@ type ClusterCompileMeta struct {
 	} `json:"tenant,omitempty"`
 }
 
+// ClusterConnectivity Liveness of the cluster's Steward agent, computed from its heartbeats.
+type ClusterConnectivity struct {
+	// LastSeen Time of the last heartbeat
+	LastSeen *time.Time `json:"lastSeen,omitempty"`
+
+	// State Agent state reported with the last heartbeat
+	State *map[string]interface{} `json:"state,omitempty"`
+
+	// Status `online` if the last heartbeat is recent, `stale` if it's older than the configured threshold
+	// and `neverSeen` if Steward never sent a heartbeat.
+	Status ClusterConnectivityStatus `json:"status"`
+
+	// Version Steward version reported with the last heartbeat
+	Version *string `json:"version,omitempty"`
+}
+
+// ClusterConnectivityStatus `online` if the last heartbeat is recent, `stale` if it's older than the configured threshold
+// and `neverSeen` if Steward never sent a heartbeat.
+type ClusterConnectivityStatus string
+
+// ClusterDrift A cluster whose last compilation doesn't match the desired revisions or is too old
+type ClusterDrift struct {
+	// Global The desired revision of a configuration repository differs from the compiled version
+	Global *RevisionDrift `json:"global,omitempty"`
+	Id     string         `json:"id"`
+
+	// LastCompile Time of the last compilation, unset if the cluster was never compiled
+	LastCompile *time.Time `json:"lastCompile,omitempty"`
+
+	// Stale True if the cluster wasn't compiled within the configured age
+	Stale  bool   `json:"stale"`
+	Tenant string `json:"tenant"`
+
+	// TenantRevision The desired revision of a configuration repository differs from the compiled version
+	TenantRevision *RevisionDrift `json:"tenantRevision,omitempty"`
+}
+
 // ClusterFacts Facts about a cluster object. Statically configured key/value pairs.
+// Values keep their JSON type, e.g. `3` is returned as `3`.
+// Facts can't be `null`, the request is rejected with 400 naming the offending keys.
 type ClusterFacts map[string]interface{}
 
 // ClusterId defines model for ClusterId.
 type ClusterId struct {
-	// Id A unique object identifier string. Automatically generated by the API on creation (in the form "<letter>-<adjective>-<noun>-<digits>" where all letters are lowercase, max 63 characters in total).
+	// Id A unique object identifier string. Automatically generated by the API on creation (by default in the form "<letter>-<adjective>-<noun>-<digits>" where all letters are lowercase, max 63 characters in total).
 	Id *Id `json:"id,omitempty"`
 }
 
 // ClusterProperties A cluster defition object.
 // The Git repository is usually managed by the API and autogenerated.
 // The sshDeployKey will be managed by Steward
+// The dynamicFacts and compileMeta are stored in the status of the cluster and can only be changed through their dedicated endpoints.
+// They're ignored when creating or replacing a cluster and rejected when updating it.
+// Only for backwards compatibility with deployed Steward agents, updating a cluster with its own service account still merges the dynamicFacts into the existing ones.
+// This is deprecated, use `PUT /clusters/{clusterId}/dynamicFacts` instead.
 type ClusterProperties struct {
-	// Annotations Unstructured key value map containing arbitrary metadata
+	// Aliases Alternative names of the cluster which can be used instead of its ID.
+	// Aliases must be lowercase DNS labels and unique in the namespace.
+	// An alias can't be the ID of another cluster, with or without the `c-` prefix, and new clusters can't use an existing alias as ID.
+	// Uniqueness is checked before the cluster is stored, concurrent requests can still assign the same alias to different clusters.
+	// Such an alias can't be resolved until it's removed from one of them.
+	Aliases *[]string `json:"aliases,omitempty"`
+
+	// Annotations Unstructured key value map containing arbitrary metadata.
+	// Values must be strings, other values are rejected with 400 naming the offending keys.
 	Annotations *Annotations `json:"annotations,omitempty"`
 
 	// CompileMeta CompileMeta contains information about the last compilation with Commodore.
 	CompileMeta *ClusterCompileMeta `json:"compileMeta,omitempty"`
 
+	// Connectivity Liveness of the cluster's Steward agent, computed from its heartbeats.
+	Connectivity *ClusterConnectivity `json:"connectivity,omitempty"`
+
 	// DisplayName Display Name of the cluster
 	DisplayName *string `json:"displayName,omitempty"`
 
@@ -163,6 +284,8 @@ type ClusterProperties struct {
 	DynamicFacts *DynamicClusterFacts `json:"dynamicFacts,omitempty"`
 
 	// Facts Facts about a cluster object. Statically configured key/value pairs.
+	// Values keep their JSON type, e.g. `3` is returned as `3`.
+	// Facts can't be `null`, the request is rejected with 400 naming the offending keys.
 	Facts *ClusterFacts `json:"facts,omitempty"`
 
 	// GitRepo Configuration Git repository, usually generated by the API
@@ -175,6 +298,9 @@ type ClusterProperties struct {
 	// InstallURL URL to fetch install manifests for Steward cluster agent. This will only be set if the cluster's token is still valid.
 	InstallURL *string `json:"installURL,omitempty"`
 
+	// StewardInstallation Audit information about the last time the Steward install manifests of the cluster were fetched.
+	StewardInstallation *StewardInstallation `json:"stewardInstallation,omitempty"`
+
 	// TenantGitRepoRevision Git revision to use with the tenant configruation git repository.
 	// This takes precedence over the revision configured on the Tenant.
 	TenantGitRepoRevision *string `json:"tenantGitRepoRevision,omitempty"`
@@ -186,9 +312,45 @@ type ClusterTenant struct {
 	Tenant string `json:"tenant"`
 }
 
+// ComponentReport Versions of the components and packages used by the clusters
+type ComponentReport struct {
+	// Components Components ordered by name
+	Components []VersionReport `json:"components"`
+
+	// Packages Packages ordered by name
+	Packages []VersionReport `json:"packages"`
+}
+
 // DynamicClusterFacts Dynamic facts about a cluster object. Are periodically udpated by Project Syn and should not be set manually.
 type DynamicClusterFacts map[string]interface{}
 
+// FactChange Change of a dynamic fact of a cluster
+type FactChange struct {
+	// Key Name of the fact
+	Key string `json:"key"`
+
+	// NewValue Value after the change, absent if the fact was removed
+	NewValue interface{} `json:"newValue,omitempty"`
+
+	// OldValue Value before the change, absent if the fact was added
+	OldValue interface{} `json:"oldValue,omitempty"`
+
+	// Timestamp Time of the change
+	Timestamp time.Time `json:"timestamp"`
+
+	// Writer User who submitted the change, empty if unknown
+	Writer string `json:"writer"`
+}
+
+// FactsSchema JSON schemas of the static facts of clusters, in the OpenAPI schema dialect
+type FactsSchema struct {
+	// Instance Schema configured for all clusters of the Lieutenant instance, unset if not configured
+	Instance *map[string]interface{} `json:"instance,omitempty"`
+
+	// Tenant Schema configured for the clusters of the tenant, unset if not configured
+	Tenant *map[string]interface{} `json:"tenant,omitempty"`
+}
+
 // GitRepo Configuration Git repository, usually generated by the API
 type GitRepo struct {
 	// DeployKey SSH public key / deploy key for clusterconfiguration catalog Git repository. This property is managed by Steward.
@@ -204,13 +366,79 @@ type GitRepo struct {
 	Url *string `json:"url,omitempty"`
 }
 
-// Id A unique object identifier string. Automatically generated by the API on creation (in the form "<letter>-<adjective>-<noun>-<digits>" where all letters are lowercase, max 63 characters in total).
+// Heartbeat Heartbeat of the Steward agent
+type Heartbeat struct {
+	// State Freeform state of the agent
+	State *map[string]interface{} `json:"state,omitempty"`
+
+	// Version Version of Steward
+	Version string `json:"version"`
+}
+
+// Id A unique object identifier string. Automatically generated by the API on creation (by default in the form "<letter>-<adjective>-<noun>-<digits>" where all letters are lowercase, max 63 characters in total).
 type Id string
 
 // Inventory Inventory data of a cluster
 type Inventory struct {
 	Cluster   string                  `json:"cluster"`
 	Inventory *map[string]interface{} `json:"inventory,omitempty"`
+
+	// Timestamp Time the inventory data was stored
+	Timestamp *time.Time `json:"timestamp,omitempty"`
+}
+
+// InventoryQuery Backend independent query of inventory data.
+//
+// Fields and groups are selected with dot separated paths.
+// Paths start with `cluster`, `tenant`, `timestamp` or `inventory`.
+// Paths starting with `inventory` continue into the inventory document.
+type InventoryQuery struct {
+	// Aggregation Combines the values of each field within a group.
+	// `none` returns a row per inventory entry.
+	// `last` returns the most recent value.
+	// `count` returns the number of entries containing the field.
+	// `distinct` returns the list of distinct values.
+	Aggregation *InventoryQueryAggregation `json:"aggregation,omitempty"`
+
+	// Clusters Only consider inventory data of these clusters
+	Clusters *[]string `json:"clusters,omitempty"`
+
+	// Fields Paths of the values to return
+	Fields *[]string `json:"fields,omitempty"`
+
+	// From Only consider inventory data stored at or after this time
+	From *time.Time `json:"from,omitempty"`
+
+	// GroupBy Paths whose values form the groups
+	GroupBy *[]string `json:"groupBy,omitempty"`
+
+	// Latest Only consider the most recent inventory data of each cluster within the time range
+	Latest *bool `json:"latest,omitempty"`
+
+	// Tenant Only consider inventory data of clusters of this tenant
+	Tenant *string `json:"tenant,omitempty"`
+
+	// To Only consider inventory data stored at or before this time
+	To *time.Time `json:"to,omitempty"`
+}
+
+// InventoryQueryAggregation Combines the values of each field within a group.
+// `none` returns a row per inventory entry.
+// `last` returns the most recent value.
+// `count` returns the number of entries containing the field.
+// `distinct` returns the list of distinct values.
+type InventoryQueryAggregation string
+
+// InventoryQueryRow A row of an inventory query result
+type InventoryQueryRow struct {
+	// Entries Number of inventory entries the row was computed from
+	Entries int `json:"entries"`
+
+	// Group Values of the `groupBy` paths
+	Group map[string]interface{} `json:"group"`
+
+	// Values Values of the fields, aggregated within the group
+	Values map[string]interface{} `json:"values"`
 }
 
 // Metadata defines model for Metadata.
@@ -238,6 +466,15 @@ type Revision struct {
 	Revision *string `json:"revision,omitempty"`
 }
 
+// RevisionDrift The desired revision of a configuration repository differs from the compiled version
+type RevisionDrift struct {
+	// Compiled Version used by the last compilation
+	Compiled string `json:"compiled"`
+
+	// Desired Effective revision configured on the cluster or inherited from the tenant
+	Desired string `json:"desired"`
+}
+
 // RevisionedGitRepo defines model for RevisionedGitRepo.
 type RevisionedGitRepo struct {
 	// Embedded struct due to allOf(#/components/schemas/GitRepo)
@@ -246,6 +483,30 @@ type RevisionedGitRepo struct {
 	Revision `yaml:",inline"`
 }
 
+// StewardInstallation Audit information about the last time the Steward install manifests of the cluster were fetched.
+type StewardInstallation struct {
+	// ClientIP IP address of the client which fetched the install manifests
+	ClientIP *string `json:"clientIP,omitempty"`
+
+	// Image Steward image used in the install manifests
+	Image *string `json:"image,omitempty"`
+
+	// InstalledAt Time the install manifests were fetched
+	InstalledAt *time.Time `json:"installedAt,omitempty"`
+
+	// UserAgent User agent of the client which fetched the install manifests
+	UserAgent *string `json:"userAgent,omitempty"`
+}
+
+// StewardRotation Result of a Steward credential rotation
+type StewardRotation struct {
+	// InstallURL One-time URL to fetch install manifests containing the new credentials for Steward
+	InstallURL string `json:"installURL"`
+
+	// RevokeAfter Time after which the previous credentials of Steward are revoked
+	RevokeAfter *time.Time `json:"revokeAfter,omitempty"`
+}
+
 // Tenant defines model for Tenant.
 type Tenant struct {
 	// Embedded struct due to allOf(#/components/schemas/TenantId)
@@ -256,7 +517,7 @@ type Tenant struct {
 
 // TenantId defines model for TenantId.
 type TenantId struct {
-	// Id A unique object identifier string. Automatically generated by the API on creation (in the form "<letter>-<adjective>-<noun>-<digits>" where all letters are lowercase, max 63 characters in total).
+	// Id A unique object identifier string. Automatically generated by the API on creation (by default in the form "<letter>-<adjective>-<noun>-<digits>" where all letters are lowercase, max 63 characters in total).
 	Id *Id `json:"id,omitempty"`
 }
 
@@ -264,7 +525,8 @@ type TenantId struct {
 // The Git repository is usually managed by the API and autogenerated.
 // All properties except name are optional on creation.
 type TenantProperties struct {
-	// Annotations Unstructured key value map containing arbitrary metadata
+	// Annotations Unstructured key value map containing arbitrary metadata.
+	// Values must be strings, other values are rejected with 400 naming the offending keys.
 	Annotations *Annotations `json:"annotations,omitempty"`
 
 	// DisplayName Display name of the tenant
@@ -284,10 +546,43 @@ type VaultConfig struct {
 	LoginMethod *string `json:"loginMethod,omitempty"`
 }
 
-// ClusterIdParameter A unique object identifier string. Automatically generated by the API on creation (in the form "<letter>-<adjective>-<noun>-<digits>" where all letters are lowercase, max 63 characters in total).
+// VersionReport Distinct versions of a component or package
+type VersionReport struct {
+	// Name Name of the component or package
+	Name string `json:"name"`
+
+	// Versions Distinct versions ordered by version, git SHA and URL
+	Versions []VersionUsage `json:"versions"`
+}
+
+// VersionUsage A version of a component or package and the clusters using it
+type VersionUsage struct {
+	// Clusters Clusters using the version, ordered by ID
+	Clusters []VersionUsageCluster `json:"clusters"`
+
+	// GitSha Git commit SHA of the used commit
+	GitSha *string `json:"gitSha,omitempty"`
+
+	// Url URL of the git repository
+	Url *string `json:"url,omitempty"`
+
+	// Version Version of the component or package, a tag, branch or any other git reference
+	Version string `json:"version"`
+}
+
+// VersionUsageCluster defines model for VersionUsageCluster.
+type VersionUsageCluster struct {
+	Id string `json:"id"`
+
+	// Instances Names of the component instances using the version, only set for components
+	Instances *[]string `json:"instances,omitempty"`
+	Tenant    string    `json:"tenant"`
+}
+
+// ClusterIdParameter A unique object identifier string. Automatically generated by the API on creation (by default in the form "<letter>-<adjective>-<noun>-<digits>" where all letters are lowercase, max 63 characters in total).
 type ClusterIdParameter Id
 
-// TenantIdParameter A unique object identifier string. Automatically generated by the API on creation (in the form "<letter>-<adjective>-<noun>-<digits>" where all letters are lowercase, max 63 characters in total).
+// TenantIdParameter A unique object identifier string. Automatically generated by the API on creation (by default in the form "<letter>-<adjective>-<noun>-<digits>" where all letters are lowercase, max 63 characters in total).
 type TenantIdParameter Id
 
 // Default A reason for responses
@@ -300,11 +595,38 @@ type ListClustersParams struct {
 
 	// SortBy Sort list by field
 	SortBy *ListClustersParamsSortBy `form:"sort_by,omitempty" json:"sort_by,omitempty"`
+
+	// Connectivity Filter clusters by connectivity of their Steward agent
+	Connectivity *ListClustersParamsConnectivity `form:"connectivity,omitempty" json:"connectivity,omitempty"`
+
+	// GitRepoUrl Filter clusters by the URL of their catalog Git repository
+	GitRepoUrl *string `form:"gitRepoUrl,omitempty" json:"gitRepoUrl,omitempty"`
 }
 
 // ListClustersParamsSortBy defines parameters for ListClusters.
 type ListClustersParamsSortBy string
 
+// ListClustersParamsConnectivity defines parameters for ListClusters.
+type ListClustersParamsConnectivity string
+
+// GetClusterFactsHistoryParams defines parameters for GetClusterFactsHistory.
+type GetClusterFactsHistoryParams struct {
+	// Key Only return changes of this fact
+	Key *string `form:"key,omitempty" json:"key,omitempty"`
+
+	// From Only return changes at or after this time
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Only return changes at or before this time
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// RotateStewardTokenParams defines parameters for RotateStewardToken.
+type RotateStewardTokenParams struct {
+	// GracePeriod Duration after which the previous credentials are revoked. Defaults to 1h.
+	GracePeriod *string `form:"gracePeriod,omitempty" json:"gracePeriod,omitempty"`
+}
+
 // InstallStewardParams defines parameters for InstallSteward.
 type InstallStewardParams struct {
 	// Token Initial bootstrap token
@@ -313,8 +635,44 @@ type InstallStewardParams struct {
 
 // QueryInventoryParams defines parameters for QueryInventory.
 type QueryInventoryParams struct {
-	// Q InfluxQL query string
-	Q *string `form:"q,omitempty" json:"q,omitempty"`
+	// Cluster Only return inventory data of this cluster
+	Cluster *string `form:"cluster,omitempty" json:"cluster,omitempty"`
+
+	// From Only return inventory data stored at or after this time
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Only return inventory data stored at or before this time
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// Latest Only return the most recent inventory data of each cluster within the time range
+	Latest *bool `form:"latest,omitempty" json:"latest,omitempty"`
+}
+
+// GetComponentReportParams defines parameters for GetComponentReport.
+type GetComponentReportParams struct {
+	// Tenant Only include clusters of this tenant
+	Tenant *string `form:"tenant,omitempty" json:"tenant,omitempty"`
+
+	// Fact Only include clusters with the given facts, in the form `key=value`
+	Fact *[]string `form:"fact,omitempty" json:"fact,omitempty"`
+
+	// Component Only return these components
+	Component *[]string `form:"component,omitempty" json:"component,omitempty"`
+
+	// Package Only return these packages
+	Package *[]string `form:"package,omitempty" json:"package,omitempty"`
+}
+
+// GetDriftReportParams defines parameters for GetDriftReport.
+type GetDriftReportParams struct {
+	// Tenant Only include clusters of this tenant
+	Tenant *string `form:"tenant,omitempty" json:"tenant,omitempty"`
+
+	// Fact Only include clusters with the given facts, in the form `key=value`
+	Fact *[]string `form:"fact,omitempty" json:"fact,omitempty"`
+
+	// StaleAfter Age after which a compilation is considered stale, as Go duration. Defaults to the configured age.
+	StaleAfter *string `form:"staleAfter,omitempty" json:"staleAfter,omitempty"`
 }
 
 // CreateClusterJSONRequestBody defines body for CreateCluster for application/json ContentType.
@@ -329,9 +687,18 @@ type PutClusterJSONRequestBody Cluster
 // PostClusterCompileMetaJSONRequestBody defines body for PostClusterCompileMeta for application/json ContentType.
 type PostClusterCompileMetaJSONRequestBody ClusterCompileMeta
 
+// PutClusterDynamicFactsJSONRequestBody defines body for PutClusterDynamicFacts for application/json ContentType.
+type PutClusterDynamicFactsJSONRequestBody DynamicClusterFacts
+
+// PostClusterHeartbeatJSONRequestBody defines body for PostClusterHeartbeat for application/json ContentType.
+type PostClusterHeartbeatJSONRequestBody Heartbeat
+
 // UpdateInventoryJSONRequestBody defines body for UpdateInventory for application/json ContentType.
 type UpdateInventoryJSONRequestBody Inventory
 
+// SearchInventoryJSONRequestBody defines body for SearchInventory for application/json ContentType.
+type SearchInventoryJSONRequestBody InventoryQuery
+
 // CreateTenantJSONRequestBody defines body for CreateTenant for application/json ContentType.
 type CreateTenantJSONRequestBody Tenant
 
@@ -417,6 +784,9 @@ type ClientInterface interface {
 	// Discovery request
 	Discovery(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// FlushClientCache request
+	FlushClientCache(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// ListClusters request
 	ListClusters(ctx context.Context, params *ListClustersParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -446,6 +816,25 @@ type ClientInterface interface {
 
 	PostClusterCompileMeta(ctx context.Context, clusterId ClusterIdParameter, body PostClusterCompileMetaJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// GetClusterCompileMetaHistory request
+	GetClusterCompileMetaHistory(ctx context.Context, clusterId ClusterIdParameter, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PutClusterDynamicFactsWithBody request with any body
+	PutClusterDynamicFactsWithBody(ctx context.Context, clusterId ClusterIdParameter, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PutClusterDynamicFacts(ctx context.Context, clusterId ClusterIdParameter, body PutClusterDynamicFactsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetClusterFactsHistory request
+	GetClusterFactsHistory(ctx context.Context, clusterId ClusterIdParameter, params *GetClusterFactsHistoryParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostClusterHeartbeatWithBody request with any body
+	PostClusterHeartbeatWithBody(ctx context.Context, clusterId ClusterIdParameter, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostClusterHeartbeat(ctx context.Context, clusterId ClusterIdParameter, body PostClusterHeartbeatJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RotateStewardToken request
+	RotateStewardToken(ctx context.Context, clusterId ClusterIdParameter, params *RotateStewardTokenParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// Docs request
 	Docs(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -463,9 +852,23 @@ type ClientInterface interface {
 
 	UpdateInventory(ctx context.Context, body UpdateInventoryJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// SearchInventoryWithBody request with any body
+	SearchInventoryWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SearchInventory(ctx context.Context, body SearchInventoryJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// Metrics request
+	Metrics(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// Openapi request
 	Openapi(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// GetComponentReport request
+	GetComponentReport(ctx context.Context, params *GetComponentReportParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetDriftReport request
+	GetDriftReport(ctx context.Context, params *GetDriftReportParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// ListTenants request
 	ListTenants(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -489,6 +892,9 @@ type ClientInterface interface {
 	PutTenantWithBody(ctx context.Context, tenantId TenantIdParameter, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	PutTenant(ctx context.Context, tenantId TenantIdParameter, body PutTenantJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetTenantFactsSchema request
+	GetTenantFactsSchema(ctx context.Context, tenantId TenantIdParameter, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
 func (c *Client) Discovery(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -503,6 +909,18 @@ func (c *Client) Discovery(ctx context.Context, reqEditors ...RequestEditorFn) (
 	return c.Client.Do(req)
 }
 
+func (c *Client) FlushClientCache(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewFlushClientCacheRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) ListClusters(ctx context.Context, params *ListClustersParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewListClustersRequest(c.Server, params)
 	if err != nil {
@@ -635,8 +1053,8 @@ func (c *Client) PostClusterCompileMeta(ctx context.Context, clusterId ClusterId
 	return c.Client.Do(req)
 }
 
-func (c *Client) Docs(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewDocsRequest(c.Server)
+func (c *Client) GetClusterCompileMetaHistory(ctx context.Context, clusterId ClusterIdParameter, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetClusterCompileMetaHistoryRequest(c.Server, clusterId)
 	if err != nil {
 		return nil, err
 	}
@@ -647,8 +1065,8 @@ func (c *Client) Docs(ctx context.Context, reqEditors ...RequestEditorFn) (*http
 	return c.Client.Do(req)
 }
 
-func (c *Client) Healthz(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewHealthzRequest(c.Server)
+func (c *Client) PutClusterDynamicFactsWithBody(ctx context.Context, clusterId ClusterIdParameter, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutClusterDynamicFactsRequestWithBody(c.Server, clusterId, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -659,8 +1077,8 @@ func (c *Client) Healthz(ctx context.Context, reqEditors ...RequestEditorFn) (*h
 	return c.Client.Do(req)
 }
 
-func (c *Client) InstallSteward(ctx context.Context, params *InstallStewardParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewInstallStewardRequest(c.Server, params)
+func (c *Client) PutClusterDynamicFacts(ctx context.Context, clusterId ClusterIdParameter, body PutClusterDynamicFactsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutClusterDynamicFactsRequest(c.Server, clusterId, body)
 	if err != nil {
 		return nil, err
 	}
@@ -671,8 +1089,8 @@ func (c *Client) InstallSteward(ctx context.Context, params *InstallStewardParam
 	return c.Client.Do(req)
 }
 
-func (c *Client) QueryInventory(ctx context.Context, params *QueryInventoryParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewQueryInventoryRequest(c.Server, params)
+func (c *Client) GetClusterFactsHistory(ctx context.Context, clusterId ClusterIdParameter, params *GetClusterFactsHistoryParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetClusterFactsHistoryRequest(c.Server, clusterId, params)
 	if err != nil {
 		return nil, err
 	}
@@ -683,8 +1101,8 @@ func (c *Client) QueryInventory(ctx context.Context, params *QueryInventoryParam
 	return c.Client.Do(req)
 }
 
-func (c *Client) UpdateInventoryWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewUpdateInventoryRequestWithBody(c.Server, contentType, body)
+func (c *Client) PostClusterHeartbeatWithBody(ctx context.Context, clusterId ClusterIdParameter, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostClusterHeartbeatRequestWithBody(c.Server, clusterId, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -695,8 +1113,8 @@ func (c *Client) UpdateInventoryWithBody(ctx context.Context, contentType string
 	return c.Client.Do(req)
 }
 
-func (c *Client) UpdateInventory(ctx context.Context, body UpdateInventoryJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewUpdateInventoryRequest(c.Server, body)
+func (c *Client) PostClusterHeartbeat(ctx context.Context, clusterId ClusterIdParameter, body PostClusterHeartbeatJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostClusterHeartbeatRequest(c.Server, clusterId, body)
 	if err != nil {
 		return nil, err
 	}
@@ -707,8 +1125,8 @@ func (c *Client) UpdateInventory(ctx context.Context, body UpdateInventoryJSONRe
 	return c.Client.Do(req)
 }
 
-func (c *Client) Openapi(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewOpenapiRequest(c.Server)
+func (c *Client) RotateStewardToken(ctx context.Context, clusterId ClusterIdParameter, params *RotateStewardTokenParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRotateStewardTokenRequest(c.Server, clusterId, params)
 	if err != nil {
 		return nil, err
 	}
@@ -719,8 +1137,8 @@ func (c *Client) Openapi(ctx context.Context, reqEditors ...RequestEditorFn) (*h
 	return c.Client.Do(req)
 }
 
-func (c *Client) ListTenants(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewListTenantsRequest(c.Server)
+func (c *Client) Docs(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDocsRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -731,8 +1149,8 @@ func (c *Client) ListTenants(ctx context.Context, reqEditors ...RequestEditorFn)
 	return c.Client.Do(req)
 }
 
-func (c *Client) CreateTenantWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewCreateTenantRequestWithBody(c.Server, contentType, body)
+func (c *Client) Healthz(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewHealthzRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -743,8 +1161,8 @@ func (c *Client) CreateTenantWithBody(ctx context.Context, contentType string, b
 	return c.Client.Do(req)
 }
 
-func (c *Client) CreateTenant(ctx context.Context, body CreateTenantJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewCreateTenantRequest(c.Server, body)
+func (c *Client) InstallSteward(ctx context.Context, params *InstallStewardParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewInstallStewardRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -755,8 +1173,8 @@ func (c *Client) CreateTenant(ctx context.Context, body CreateTenantJSONRequestB
 	return c.Client.Do(req)
 }
 
-func (c *Client) DeleteTenant(ctx context.Context, tenantId TenantIdParameter, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewDeleteTenantRequest(c.Server, tenantId)
+func (c *Client) QueryInventory(ctx context.Context, params *QueryInventoryParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewQueryInventoryRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -767,8 +1185,8 @@ func (c *Client) DeleteTenant(ctx context.Context, tenantId TenantIdParameter, r
 	return c.Client.Do(req)
 }
 
-func (c *Client) GetTenant(ctx context.Context, tenantId TenantIdParameter, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewGetTenantRequest(c.Server, tenantId)
+func (c *Client) UpdateInventoryWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateInventoryRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -779,8 +1197,8 @@ func (c *Client) GetTenant(ctx context.Context, tenantId TenantIdParameter, reqE
 	return c.Client.Do(req)
 }
 
-func (c *Client) UpdateTenantWithBody(ctx context.Context, tenantId TenantIdParameter, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewUpdateTenantRequestWithBody(c.Server, tenantId, contentType, body)
+func (c *Client) UpdateInventory(ctx context.Context, body UpdateInventoryJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateInventoryRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
@@ -791,8 +1209,8 @@ func (c *Client) UpdateTenantWithBody(ctx context.Context, tenantId TenantIdPara
 	return c.Client.Do(req)
 }
 
-func (c *Client) UpdateTenantWithApplicationMergePatchPlusJSONBody(ctx context.Context, tenantId TenantIdParameter, body UpdateTenantApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewUpdateTenantRequestWithApplicationMergePatchPlusJSONBody(c.Server, tenantId, body)
+func (c *Client) SearchInventoryWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSearchInventoryRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -803,8 +1221,8 @@ func (c *Client) UpdateTenantWithApplicationMergePatchPlusJSONBody(ctx context.C
 	return c.Client.Do(req)
 }
 
-func (c *Client) PutTenantWithBody(ctx context.Context, tenantId TenantIdParameter, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPutTenantRequestWithBody(c.Server, tenantId, contentType, body)
+func (c *Client) SearchInventory(ctx context.Context, body SearchInventoryJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSearchInventoryRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
@@ -815,8 +1233,8 @@ func (c *Client) PutTenantWithBody(ctx context.Context, tenantId TenantIdParamet
 	return c.Client.Do(req)
 }
 
-func (c *Client) PutTenant(ctx context.Context, tenantId TenantIdParameter, body PutTenantJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewPutTenantRequest(c.Server, tenantId, body)
+func (c *Client) Metrics(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewMetricsRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -827,48 +1245,231 @@ func (c *Client) PutTenant(ctx context.Context, tenantId TenantIdParameter, body
 	return c.Client.Do(req)
 }
 
-// NewDiscoveryRequest generates requests for Discovery
-func NewDiscoveryRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) Openapi(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewOpenapiRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) GetComponentReport(ctx context.Context, params *GetComponentReportParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetComponentReportRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+func (c *Client) GetDriftReport(ctx context.Context, params *GetDriftReportParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetDriftReportRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewListClustersRequest generates requests for ListClusters
-func NewListClustersRequest(server string, params *ListClustersParams) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) ListTenants(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListTenantsRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/clusters")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) CreateTenantWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateTenantRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateTenant(ctx context.Context, body CreateTenantJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateTenantRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteTenant(ctx context.Context, tenantId TenantIdParameter, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteTenantRequest(c.Server, tenantId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetTenant(ctx context.Context, tenantId TenantIdParameter, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetTenantRequest(c.Server, tenantId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateTenantWithBody(ctx context.Context, tenantId TenantIdParameter, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateTenantRequestWithBody(c.Server, tenantId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateTenantWithApplicationMergePatchPlusJSONBody(ctx context.Context, tenantId TenantIdParameter, body UpdateTenantApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateTenantRequestWithApplicationMergePatchPlusJSONBody(c.Server, tenantId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutTenantWithBody(ctx context.Context, tenantId TenantIdParameter, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutTenantRequestWithBody(c.Server, tenantId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PutTenant(ctx context.Context, tenantId TenantIdParameter, body PutTenantJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPutTenantRequest(c.Server, tenantId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetTenantFactsSchema(ctx context.Context, tenantId TenantIdParameter, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetTenantFactsSchemaRequest(c.Server, tenantId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewDiscoveryRequest generates requests for Discovery
+func NewDiscoveryRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewFlushClientCacheRequest generates requests for FlushClientCache
+func NewFlushClientCacheRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/clientCache")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListClustersRequest generates requests for ListClusters
+func NewListClustersRequest(server string, params *ListClustersParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/clusters")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
 	if err != nil {
 		return nil, err
 	}
@@ -908,6 +1509,38 @@ func NewListClustersRequest(server string, params *ListClustersParams) (*http.Re
 
 		}
 
+		if params.Connectivity != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "connectivity", *params.Connectivity, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.GitRepoUrl != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "gitRepoUrl", *params.GitRepoUrl, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
 		queryURL.RawQuery = queryValues.Encode()
 	}
 
@@ -1168,16 +1801,23 @@ func NewPostClusterCompileMetaRequestWithBody(server string, clusterId ClusterId
 	return req, nil
 }
 
-// NewDocsRequest generates requests for Docs
-func NewDocsRequest(server string) (*http.Request, error) {
+// NewGetClusterCompileMetaHistoryRequest generates requests for GetClusterCompileMetaHistory
+func NewGetClusterCompileMetaHistoryRequest(server string, clusterId ClusterIdParameter) (*http.Request, error) {
 	var err error
 
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "clusterId", clusterId, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/docs")
+	operationPath := fmt.Sprintf("/clusters/%s/compileMeta/history", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1195,16 +1835,34 @@ func NewDocsRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
-// NewHealthzRequest generates requests for Healthz
-func NewHealthzRequest(server string) (*http.Request, error) {
+// NewPutClusterDynamicFactsRequest calls the generic PutClusterDynamicFacts builder with application/json body
+func NewPutClusterDynamicFactsRequest(server string, clusterId ClusterIdParameter, body PutClusterDynamicFactsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutClusterDynamicFactsRequestWithBody(server, clusterId, "application/json", bodyReader)
+}
+
+// NewPutClusterDynamicFactsRequestWithBody generates requests for PutClusterDynamicFacts with any type of body
+func NewPutClusterDynamicFactsRequestWithBody(server string, clusterId ClusterIdParameter, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "clusterId", clusterId, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/healthz")
+	operationPath := fmt.Sprintf("/clusters/%s/dynamicFacts", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1214,24 +1872,33 @@ func NewHealthzRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
 
+	req.Header.Add("Content-Type", contentType)
+
 	return req, nil
 }
 
-// NewInstallStewardRequest generates requests for InstallSteward
-func NewInstallStewardRequest(server string, params *InstallStewardParams) (*http.Request, error) {
+// NewGetClusterFactsHistoryRequest generates requests for GetClusterFactsHistory
+func NewGetClusterFactsHistoryRequest(server string, clusterId ClusterIdParameter, params *GetClusterFactsHistoryParams) (*http.Request, error) {
 	var err error
 
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "clusterId", clusterId, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/install/steward.json")
+	operationPath := fmt.Sprintf("/clusters/%s/facts/history", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1244,9 +1911,9 @@ func NewInstallStewardRequest(server string, params *InstallStewardParams) (*htt
 	if params != nil {
 		queryValues := queryURL.Query()
 
-		if params.Token != nil {
+		if params.Key != nil {
 
-			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "token", *params.Token, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "key", *params.Key, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
 				return nil, err
 			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
 				return nil, err
@@ -1260,42 +1927,25 @@ func NewInstallStewardRequest(server string, params *InstallStewardParams) (*htt
 
 		}
 
-		queryURL.RawQuery = queryValues.Encode()
-	}
+		if params.From != nil {
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
-		return nil, err
-	}
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "from", *params.From, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: "date-time"}); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
 
-	return req, nil
-}
+		}
 
-// NewQueryInventoryRequest generates requests for QueryInventory
-func NewQueryInventoryRequest(server string, params *QueryInventoryParams) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
-	if err != nil {
-		return nil, err
-	}
-
-	operationPath := fmt.Sprintf("/inventory")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
-	}
-
-	queryURL, err := serverURL.Parse(operationPath)
-	if err != nil {
-		return nil, err
-	}
-
-	if params != nil {
-		queryValues := queryURL.Query()
+		if params.To != nil {
 
-		if params.Q != nil {
-
-			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "q", *params.Q, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "to", *params.To, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: "date-time"}); err != nil {
 				return nil, err
 			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
 				return nil, err
@@ -1320,27 +1970,34 @@ func NewQueryInventoryRequest(server string, params *QueryInventoryParams) (*htt
 	return req, nil
 }
 
-// NewUpdateInventoryRequest calls the generic UpdateInventory builder with application/json body
-func NewUpdateInventoryRequest(server string, body UpdateInventoryJSONRequestBody) (*http.Request, error) {
+// NewPostClusterHeartbeatRequest calls the generic PostClusterHeartbeat builder with application/json body
+func NewPostClusterHeartbeatRequest(server string, clusterId ClusterIdParameter, body PostClusterHeartbeatJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewUpdateInventoryRequestWithBody(server, "application/json", bodyReader)
+	return NewPostClusterHeartbeatRequestWithBody(server, clusterId, "application/json", bodyReader)
 }
 
-// NewUpdateInventoryRequestWithBody generates requests for UpdateInventory with any type of body
-func NewUpdateInventoryRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+// NewPostClusterHeartbeatRequestWithBody generates requests for PostClusterHeartbeat with any type of body
+func NewPostClusterHeartbeatRequestWithBody(server string, clusterId ClusterIdParameter, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "clusterId", clusterId, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/inventory")
+	operationPath := fmt.Sprintf("/clusters/%s/heartbeat", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1360,16 +2017,23 @@ func NewUpdateInventoryRequestWithBody(server string, contentType string, body i
 	return req, nil
 }
 
-// NewOpenapiRequest generates requests for Openapi
-func NewOpenapiRequest(server string) (*http.Request, error) {
+// NewRotateStewardTokenRequest generates requests for RotateStewardToken
+func NewRotateStewardTokenRequest(server string, clusterId ClusterIdParameter, params *RotateStewardTokenParams) (*http.Request, error) {
 	var err error
 
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "clusterId", clusterId, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/openapi.json")
+	operationPath := fmt.Sprintf("/clusters/%s/steward/rotate", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1379,7 +2043,29 @@ func NewOpenapiRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.GracePeriod != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "gracePeriod", *params.GracePeriod, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1387,8 +2073,8 @@ func NewOpenapiRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
-// NewListTenantsRequest generates requests for ListTenants
-func NewListTenantsRequest(server string) (*http.Request, error) {
+// NewDocsRequest generates requests for Docs
+func NewDocsRequest(server string) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1396,7 +2082,7 @@ func NewListTenantsRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/tenants")
+	operationPath := fmt.Sprintf("/docs")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1414,19 +2100,8 @@ func NewListTenantsRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
-// NewCreateTenantRequest calls the generic CreateTenant builder with application/json body
-func NewCreateTenantRequest(server string, body CreateTenantJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
-	if err != nil {
-		return nil, err
-	}
-	bodyReader = bytes.NewReader(buf)
-	return NewCreateTenantRequestWithBody(server, "application/json", bodyReader)
-}
-
-// NewCreateTenantRequestWithBody generates requests for CreateTenant with any type of body
-func NewCreateTenantRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+// NewHealthzRequest generates requests for Healthz
+func NewHealthzRequest(server string) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -1434,7 +2109,7 @@ func NewCreateTenantRequestWithBody(server string, contentType string, body io.R
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/tenants")
+	operationPath := fmt.Sprintf("/healthz")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1444,33 +2119,24 @@ func NewCreateTenantRequestWithBody(server string, contentType string, body io.R
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", queryURL.String(), body)
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Add("Content-Type", contentType)
-
 	return req, nil
 }
 
-// NewDeleteTenantRequest generates requests for DeleteTenant
-func NewDeleteTenantRequest(server string, tenantId TenantIdParameter) (*http.Request, error) {
+// NewInstallStewardRequest generates requests for InstallSteward
+func NewInstallStewardRequest(server string, params *InstallStewardParams) (*http.Request, error) {
 	var err error
 
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "tenantId", tenantId, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
-	if err != nil {
-		return nil, err
-	}
-
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/tenants/%s", pathParam0)
+	operationPath := fmt.Sprintf("/install/steward.json")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1480,7 +2146,29 @@ func NewDeleteTenantRequest(server string, tenantId TenantIdParameter) (*http.Re
 		return nil, err
 	}
 
-	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Token != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "token", *params.Token, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1488,23 +2176,16 @@ func NewDeleteTenantRequest(server string, tenantId TenantIdParameter) (*http.Re
 	return req, nil
 }
 
-// NewGetTenantRequest generates requests for GetTenant
-func NewGetTenantRequest(server string, tenantId TenantIdParameter) (*http.Request, error) {
+// NewQueryInventoryRequest generates requests for QueryInventory
+func NewQueryInventoryRequest(server string, params *QueryInventoryParams) (*http.Request, error) {
 	var err error
 
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "tenantId", tenantId, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
-	if err != nil {
-		return nil, err
-	}
-
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/tenants/%s", pathParam0)
+	operationPath := fmt.Sprintf("/inventory")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1514,6 +2195,76 @@ func NewGetTenantRequest(server string, tenantId TenantIdParameter) (*http.Reque
 		return nil, err
 	}
 
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Cluster != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "cluster", *params.Cluster, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "from", *params.From, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: "date-time"}); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.To != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "to", *params.To, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: "date-time"}); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Latest != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "latest", *params.Latest, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "boolean", Format: ""}); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
 	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
@@ -1522,34 +2273,27 @@ func NewGetTenantRequest(server string, tenantId TenantIdParameter) (*http.Reque
 	return req, nil
 }
 
-// NewUpdateTenantRequestWithApplicationMergePatchPlusJSONBody calls the generic UpdateTenant builder with application/merge-patch+json body
-func NewUpdateTenantRequestWithApplicationMergePatchPlusJSONBody(server string, tenantId TenantIdParameter, body UpdateTenantApplicationMergePatchPlusJSONRequestBody) (*http.Request, error) {
+// NewUpdateInventoryRequest calls the generic UpdateInventory builder with application/json body
+func NewUpdateInventoryRequest(server string, body UpdateInventoryJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewUpdateTenantRequestWithBody(server, tenantId, "application/merge-patch+json", bodyReader)
+	return NewUpdateInventoryRequestWithBody(server, "application/json", bodyReader)
 }
 
-// NewUpdateTenantRequestWithBody generates requests for UpdateTenant with any type of body
-func NewUpdateTenantRequestWithBody(server string, tenantId TenantIdParameter, contentType string, body io.Reader) (*http.Request, error) {
+// NewUpdateInventoryRequestWithBody generates requests for UpdateInventory with any type of body
+func NewUpdateInventoryRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "tenantId", tenantId, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
-	if err != nil {
-		return nil, err
-	}
-
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/tenants/%s", pathParam0)
+	operationPath := fmt.Sprintf("/inventory")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1559,7 +2303,7 @@ func NewUpdateTenantRequestWithBody(server string, tenantId TenantIdParameter, c
 		return nil, err
 	}
 
-	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	req, err := http.NewRequest("POST", queryURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
@@ -1569,34 +2313,27 @@ func NewUpdateTenantRequestWithBody(server string, tenantId TenantIdParameter, c
 	return req, nil
 }
 
-// NewPutTenantRequest calls the generic PutTenant builder with application/json body
-func NewPutTenantRequest(server string, tenantId TenantIdParameter, body PutTenantJSONRequestBody) (*http.Request, error) {
+// NewSearchInventoryRequest calls the generic SearchInventory builder with application/json body
+func NewSearchInventoryRequest(server string, body SearchInventoryJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
 	buf, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
-	return NewPutTenantRequestWithBody(server, tenantId, "application/json", bodyReader)
+	return NewSearchInventoryRequestWithBody(server, "application/json", bodyReader)
 }
 
-// NewPutTenantRequestWithBody generates requests for PutTenant with any type of body
-func NewPutTenantRequestWithBody(server string, tenantId TenantIdParameter, contentType string, body io.Reader) (*http.Request, error) {
+// NewSearchInventoryRequestWithBody generates requests for SearchInventory with any type of body
+func NewSearchInventoryRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "tenantId", tenantId, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
-	if err != nil {
-		return nil, err
-	}
-
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/tenants/%s", pathParam0)
+	operationPath := fmt.Sprintf("/inventory/query")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1606,7 +2343,7 @@ func NewPutTenantRequestWithBody(server string, tenantId TenantIdParameter, cont
 		return nil, err
 	}
 
-	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	req, err := http.NewRequest("POST", queryURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
@@ -1616,22 +2353,517 @@ func NewPutTenantRequestWithBody(server string, tenantId TenantIdParameter, cont
 	return req, nil
 }
 
-func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
-	for _, r := range c.RequestEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
-	}
-	for _, r := range additionalEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
-	}
-	return nil
-}
+// NewMetricsRequest generates requests for Metrics
+func NewMetricsRequest(server string) (*http.Request, error) {
+	var err error
 
-// ClientWithResponses builds on ClientInterface to offer response payloads
-type ClientWithResponses struct {
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/metrics")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewOpenapiRequest generates requests for Openapi
+func NewOpenapiRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/openapi.json")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetComponentReportRequest generates requests for GetComponentReport
+func NewGetComponentReportRequest(server string, params *GetComponentReportParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/reports/components")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Tenant != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "tenant", *params.Tenant, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Fact != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "fact", *params.Fact, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "array", Format: ""}); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Component != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "component", *params.Component, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "array", Format: ""}); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Package != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "package", *params.Package, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "array", Format: ""}); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetDriftReportRequest generates requests for GetDriftReport
+func NewGetDriftReportRequest(server string, params *GetDriftReportParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/reports/drift")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Tenant != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "tenant", *params.Tenant, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Fact != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "fact", *params.Fact, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "array", Format: ""}); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.StaleAfter != nil {
+
+			if queryFrag, err := runtime.StyleParamWithOptions("form", true, "staleAfter", *params.StaleAfter, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationQuery, Type: "string", Format: ""}); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListTenantsRequest generates requests for ListTenants
+func NewListTenantsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tenants")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateTenantRequest calls the generic CreateTenant builder with application/json body
+func NewCreateTenantRequest(server string, body CreateTenantJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateTenantRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateTenantRequestWithBody generates requests for CreateTenant with any type of body
+func NewCreateTenantRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tenants")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteTenantRequest generates requests for DeleteTenant
+func NewDeleteTenantRequest(server string, tenantId TenantIdParameter) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "tenantId", tenantId, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tenants/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetTenantRequest generates requests for GetTenant
+func NewGetTenantRequest(server string, tenantId TenantIdParameter) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "tenantId", tenantId, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tenants/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewUpdateTenantRequestWithApplicationMergePatchPlusJSONBody calls the generic UpdateTenant builder with application/merge-patch+json body
+func NewUpdateTenantRequestWithApplicationMergePatchPlusJSONBody(server string, tenantId TenantIdParameter, body UpdateTenantApplicationMergePatchPlusJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUpdateTenantRequestWithBody(server, tenantId, "application/merge-patch+json", bodyReader)
+}
+
+// NewUpdateTenantRequestWithBody generates requests for UpdateTenant with any type of body
+func NewUpdateTenantRequestWithBody(server string, tenantId TenantIdParameter, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "tenantId", tenantId, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tenants/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPutTenantRequest calls the generic PutTenant builder with application/json body
+func NewPutTenantRequest(server string, tenantId TenantIdParameter, body PutTenantJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPutTenantRequestWithBody(server, tenantId, "application/json", bodyReader)
+}
+
+// NewPutTenantRequestWithBody generates requests for PutTenant with any type of body
+func NewPutTenantRequestWithBody(server string, tenantId TenantIdParameter, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "tenantId", tenantId, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tenants/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetTenantFactsSchemaRequest generates requests for GetTenantFactsSchema
+func NewGetTenantFactsSchemaRequest(server string, tenantId TenantIdParameter) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithOptions("simple", false, "tenantId", tenantId, runtime.StyleParamOptions{ParamLocation: runtime.ParamLocationPath, Type: "string", Format: ""})
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tenants/%s/factsSchema", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
 	ClientInterface
 }
 
@@ -1662,6 +2894,9 @@ type ClientWithResponsesInterface interface {
 	// DiscoveryWithResponse request
 	DiscoveryWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*DiscoveryResponse, error)
 
+	// FlushClientCacheWithResponse request
+	FlushClientCacheWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*FlushClientCacheResponse, error)
+
 	// ListClustersWithResponse request
 	ListClustersWithResponse(ctx context.Context, params *ListClustersParams, reqEditors ...RequestEditorFn) (*ListClustersResponse, error)
 
@@ -1691,6 +2926,25 @@ type ClientWithResponsesInterface interface {
 
 	PostClusterCompileMetaWithResponse(ctx context.Context, clusterId ClusterIdParameter, body PostClusterCompileMetaJSONRequestBody, reqEditors ...RequestEditorFn) (*PostClusterCompileMetaResponse, error)
 
+	// GetClusterCompileMetaHistoryWithResponse request
+	GetClusterCompileMetaHistoryWithResponse(ctx context.Context, clusterId ClusterIdParameter, reqEditors ...RequestEditorFn) (*GetClusterCompileMetaHistoryResponse, error)
+
+	// PutClusterDynamicFactsWithBodyWithResponse request with any body
+	PutClusterDynamicFactsWithBodyWithResponse(ctx context.Context, clusterId ClusterIdParameter, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutClusterDynamicFactsResponse, error)
+
+	PutClusterDynamicFactsWithResponse(ctx context.Context, clusterId ClusterIdParameter, body PutClusterDynamicFactsJSONRequestBody, reqEditors ...RequestEditorFn) (*PutClusterDynamicFactsResponse, error)
+
+	// GetClusterFactsHistoryWithResponse request
+	GetClusterFactsHistoryWithResponse(ctx context.Context, clusterId ClusterIdParameter, params *GetClusterFactsHistoryParams, reqEditors ...RequestEditorFn) (*GetClusterFactsHistoryResponse, error)
+
+	// PostClusterHeartbeatWithBodyWithResponse request with any body
+	PostClusterHeartbeatWithBodyWithResponse(ctx context.Context, clusterId ClusterIdParameter, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostClusterHeartbeatResponse, error)
+
+	PostClusterHeartbeatWithResponse(ctx context.Context, clusterId ClusterIdParameter, body PostClusterHeartbeatJSONRequestBody, reqEditors ...RequestEditorFn) (*PostClusterHeartbeatResponse, error)
+
+	// RotateStewardTokenWithResponse request
+	RotateStewardTokenWithResponse(ctx context.Context, clusterId ClusterIdParameter, params *RotateStewardTokenParams, reqEditors ...RequestEditorFn) (*RotateStewardTokenResponse, error)
+
 	// DocsWithResponse request
 	DocsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*DocsResponse, error)
 
@@ -1708,9 +2962,23 @@ type ClientWithResponsesInterface interface {
 
 	UpdateInventoryWithResponse(ctx context.Context, body UpdateInventoryJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateInventoryResponse, error)
 
+	// SearchInventoryWithBodyWithResponse request with any body
+	SearchInventoryWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SearchInventoryResponse, error)
+
+	SearchInventoryWithResponse(ctx context.Context, body SearchInventoryJSONRequestBody, reqEditors ...RequestEditorFn) (*SearchInventoryResponse, error)
+
+	// MetricsWithResponse request
+	MetricsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*MetricsResponse, error)
+
 	// OpenapiWithResponse request
 	OpenapiWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*OpenapiResponse, error)
 
+	// GetComponentReportWithResponse request
+	GetComponentReportWithResponse(ctx context.Context, params *GetComponentReportParams, reqEditors ...RequestEditorFn) (*GetComponentReportResponse, error)
+
+	// GetDriftReportWithResponse request
+	GetDriftReportWithResponse(ctx context.Context, params *GetDriftReportParams, reqEditors ...RequestEditorFn) (*GetDriftReportResponse, error)
+
 	// ListTenantsWithResponse request
 	ListTenantsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListTenantsResponse, error)
 
@@ -1730,21 +2998,142 @@ type ClientWithResponsesInterface interface {
 
 	UpdateTenantWithApplicationMergePatchPlusJSONBodyWithResponse(ctx context.Context, tenantId TenantIdParameter, body UpdateTenantApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateTenantResponse, error)
 
-	// PutTenantWithBodyWithResponse request with any body
-	PutTenantWithBodyWithResponse(ctx context.Context, tenantId TenantIdParameter, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutTenantResponse, error)
+	// PutTenantWithBodyWithResponse request with any body
+	PutTenantWithBodyWithResponse(ctx context.Context, tenantId TenantIdParameter, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutTenantResponse, error)
+
+	PutTenantWithResponse(ctx context.Context, tenantId TenantIdParameter, body PutTenantJSONRequestBody, reqEditors ...RequestEditorFn) (*PutTenantResponse, error)
+
+	// GetTenantFactsSchemaWithResponse request
+	GetTenantFactsSchemaWithResponse(ctx context.Context, tenantId TenantIdParameter, reqEditors ...RequestEditorFn) (*GetTenantFactsSchemaResponse, error)
+}
+
+type DiscoveryResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Metadata
+	JSONDefault  *Default
+}
+
+// Status returns HTTPResponse.Status
+func (r DiscoveryResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DiscoveryResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type FlushClientCacheResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON403      *Reason
+	JSONDefault  *Default
+}
+
+// Status returns HTTPResponse.Status
+func (r FlushClientCacheResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r FlushClientCacheResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListClustersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Cluster
+	JSONDefault  *Default
+}
+
+// Status returns HTTPResponse.Status
+func (r ListClustersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListClustersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateClusterResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *Cluster
+	JSON400      *Reason
+	JSON403      *Reason
+	JSON422      *Reason
+	JSONDefault  *Default
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateClusterResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateClusterResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteClusterResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON403      *Reason
+	JSONDefault  *Default
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteClusterResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
 
-	PutTenantWithResponse(ctx context.Context, tenantId TenantIdParameter, body PutTenantJSONRequestBody, reqEditors ...RequestEditorFn) (*PutTenantResponse, error)
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteClusterResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
 }
 
-type DiscoveryResponse struct {
+type GetClusterResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *Metadata
+	JSON200      *Cluster
 	JSONDefault  *Default
 }
 
 // Status returns HTTPResponse.Status
-func (r DiscoveryResponse) Status() string {
+func (r GetClusterResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1752,22 +3141,25 @@ func (r DiscoveryResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r DiscoveryResponse) StatusCode() int {
+func (r GetClusterResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type ListClustersResponse struct {
+type UpdateClusterResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *[]Cluster
+	JSON200      *Cluster
+	JSON400      *Reason
+	JSON403      *Reason
+	JSON422      *Reason
 	JSONDefault  *Default
 }
 
 // Status returns HTTPResponse.Status
-func (r ListClustersResponse) Status() string {
+func (r UpdateClusterResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1775,23 +3167,26 @@ func (r ListClustersResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r ListClustersResponse) StatusCode() int {
+func (r UpdateClusterResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type CreateClusterResponse struct {
+type PutClusterResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
+	JSON200      *Cluster
 	JSON201      *Cluster
 	JSON400      *Reason
+	JSON403      *Reason
+	JSON422      *Reason
 	JSONDefault  *Default
 }
 
 // Status returns HTTPResponse.Status
-func (r CreateClusterResponse) Status() string {
+func (r PutClusterResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1799,22 +3194,23 @@ func (r CreateClusterResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r CreateClusterResponse) StatusCode() int {
+func (r PutClusterResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type DeleteClusterResponse struct {
+type PostClusterCompileMetaResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
 	JSON403      *Reason
+	JSON409      *Reason
 	JSONDefault  *Default
 }
 
 // Status returns HTTPResponse.Status
-func (r DeleteClusterResponse) Status() string {
+func (r PostClusterCompileMetaResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1822,22 +3218,22 @@ func (r DeleteClusterResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r DeleteClusterResponse) StatusCode() int {
+func (r PostClusterCompileMetaResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type GetClusterResponse struct {
+type GetClusterCompileMetaHistoryResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *Cluster
+	JSON200      *[]ClusterCompileMeta
 	JSONDefault  *Default
 }
 
 // Status returns HTTPResponse.Status
-func (r GetClusterResponse) Status() string {
+func (r GetClusterCompileMetaHistoryResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1845,23 +3241,23 @@ func (r GetClusterResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r GetClusterResponse) StatusCode() int {
+func (r GetClusterCompileMetaHistoryResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type UpdateClusterResponse struct {
+type PutClusterDynamicFactsResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *Cluster
 	JSON403      *Reason
+	JSON422      *Reason
 	JSONDefault  *Default
 }
 
 // Status returns HTTPResponse.Status
-func (r UpdateClusterResponse) Status() string {
+func (r PutClusterDynamicFactsResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1869,24 +3265,23 @@ func (r UpdateClusterResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r UpdateClusterResponse) StatusCode() int {
+func (r PutClusterDynamicFactsResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type PutClusterResponse struct {
+type GetClusterFactsHistoryResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *Cluster
-	JSON201      *Cluster
-	JSON403      *Reason
+	JSON200      *[]FactChange
+	JSON400      *Reason
 	JSONDefault  *Default
 }
 
 // Status returns HTTPResponse.Status
-func (r PutClusterResponse) Status() string {
+func (r GetClusterFactsHistoryResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1894,14 +3289,14 @@ func (r PutClusterResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r PutClusterResponse) StatusCode() int {
+func (r GetClusterFactsHistoryResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type PostClusterCompileMetaResponse struct {
+type PostClusterHeartbeatResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
 	JSON403      *Reason
@@ -1909,7 +3304,7 @@ type PostClusterCompileMetaResponse struct {
 }
 
 // Status returns HTTPResponse.Status
-func (r PostClusterCompileMetaResponse) Status() string {
+func (r PostClusterHeartbeatResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1917,7 +3312,31 @@ func (r PostClusterCompileMetaResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r PostClusterCompileMetaResponse) StatusCode() int {
+func (r PostClusterHeartbeatResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RotateStewardTokenResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *StewardRotation
+	JSON400      *Reason
+	JSONDefault  *Default
+}
+
+// Status returns HTTPResponse.Status
+func (r RotateStewardTokenResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RotateStewardTokenResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
@@ -1993,7 +3412,8 @@ func (r InstallStewardResponse) StatusCode() int {
 type QueryInventoryResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *Inventory
+	JSON200      *[]Inventory
+	JSON400      *Reason
 	JSONDefault  *Default
 }
 
@@ -2016,6 +3436,8 @@ func (r QueryInventoryResponse) StatusCode() int {
 type UpdateInventoryResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
+	JSON403      *Reason
+	JSON404      *Reason
 	JSONDefault  *Default
 }
 
@@ -2035,6 +3457,52 @@ func (r UpdateInventoryResponse) StatusCode() int {
 	return 0
 }
 
+type SearchInventoryResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]InventoryQueryRow
+	JSON400      *Reason
+	JSONDefault  *Default
+}
+
+// Status returns HTTPResponse.Status
+func (r SearchInventoryResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SearchInventoryResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type MetricsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSONDefault  *Default
+}
+
+// Status returns HTTPResponse.Status
+func (r MetricsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r MetricsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type OpenapiResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -2057,6 +3525,54 @@ func (r OpenapiResponse) StatusCode() int {
 	return 0
 }
 
+type GetComponentReportResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ComponentReport
+	JSON400      *Reason
+	JSONDefault  *Default
+}
+
+// Status returns HTTPResponse.Status
+func (r GetComponentReportResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetComponentReportResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetDriftReportResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]ClusterDrift
+	JSON400      *Reason
+	JSONDefault  *Default
+}
+
+// Status returns HTTPResponse.Status
+func (r GetDriftReportResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetDriftReportResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type ListTenantsResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -2085,6 +3601,7 @@ type CreateTenantResponse struct {
 	HTTPResponse *http.Response
 	JSON201      *Tenant
 	JSON400      *Reason
+	JSON403      *Reason
 	JSONDefault  *Default
 }
 
@@ -2154,6 +3671,7 @@ type UpdateTenantResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
 	JSON200      *Tenant
+	JSON400      *Reason
 	JSON403      *Reason
 	JSONDefault  *Default
 }
@@ -2179,6 +3697,7 @@ type PutTenantResponse struct {
 	HTTPResponse *http.Response
 	JSON200      *Tenant
 	JSON201      *Tenant
+	JSON400      *Reason
 	JSON403      *Reason
 	JSONDefault  *Default
 }
@@ -2199,6 +3718,29 @@ func (r PutTenantResponse) StatusCode() int {
 	return 0
 }
 
+type GetTenantFactsSchemaResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *FactsSchema
+	JSONDefault  *Default
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTenantFactsSchemaResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTenantFactsSchemaResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 // DiscoveryWithResponse request returning *DiscoveryResponse
 func (c *ClientWithResponses) DiscoveryWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*DiscoveryResponse, error) {
 	rsp, err := c.Discovery(ctx, reqEditors...)
@@ -2208,6 +3750,15 @@ func (c *ClientWithResponses) DiscoveryWithResponse(ctx context.Context, reqEdit
 	return ParseDiscoveryResponse(rsp)
 }
 
+// FlushClientCacheWithResponse request returning *FlushClientCacheResponse
+func (c *ClientWithResponses) FlushClientCacheWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*FlushClientCacheResponse, error) {
+	rsp, err := c.FlushClientCache(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseFlushClientCacheResponse(rsp)
+}
+
 // ListClustersWithResponse request returning *ListClustersResponse
 func (c *ClientWithResponses) ListClustersWithResponse(ctx context.Context, params *ListClustersParams, reqEditors ...RequestEditorFn) (*ListClustersResponse, error) {
 	rsp, err := c.ListClusters(ctx, params, reqEditors...)
@@ -2303,6 +3854,67 @@ func (c *ClientWithResponses) PostClusterCompileMetaWithResponse(ctx context.Con
 	return ParsePostClusterCompileMetaResponse(rsp)
 }
 
+// GetClusterCompileMetaHistoryWithResponse request returning *GetClusterCompileMetaHistoryResponse
+func (c *ClientWithResponses) GetClusterCompileMetaHistoryWithResponse(ctx context.Context, clusterId ClusterIdParameter, reqEditors ...RequestEditorFn) (*GetClusterCompileMetaHistoryResponse, error) {
+	rsp, err := c.GetClusterCompileMetaHistory(ctx, clusterId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetClusterCompileMetaHistoryResponse(rsp)
+}
+
+// PutClusterDynamicFactsWithBodyWithResponse request with arbitrary body returning *PutClusterDynamicFactsResponse
+func (c *ClientWithResponses) PutClusterDynamicFactsWithBodyWithResponse(ctx context.Context, clusterId ClusterIdParameter, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutClusterDynamicFactsResponse, error) {
+	rsp, err := c.PutClusterDynamicFactsWithBody(ctx, clusterId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutClusterDynamicFactsResponse(rsp)
+}
+
+func (c *ClientWithResponses) PutClusterDynamicFactsWithResponse(ctx context.Context, clusterId ClusterIdParameter, body PutClusterDynamicFactsJSONRequestBody, reqEditors ...RequestEditorFn) (*PutClusterDynamicFactsResponse, error) {
+	rsp, err := c.PutClusterDynamicFacts(ctx, clusterId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePutClusterDynamicFactsResponse(rsp)
+}
+
+// GetClusterFactsHistoryWithResponse request returning *GetClusterFactsHistoryResponse
+func (c *ClientWithResponses) GetClusterFactsHistoryWithResponse(ctx context.Context, clusterId ClusterIdParameter, params *GetClusterFactsHistoryParams, reqEditors ...RequestEditorFn) (*GetClusterFactsHistoryResponse, error) {
+	rsp, err := c.GetClusterFactsHistory(ctx, clusterId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetClusterFactsHistoryResponse(rsp)
+}
+
+// PostClusterHeartbeatWithBodyWithResponse request with arbitrary body returning *PostClusterHeartbeatResponse
+func (c *ClientWithResponses) PostClusterHeartbeatWithBodyWithResponse(ctx context.Context, clusterId ClusterIdParameter, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostClusterHeartbeatResponse, error) {
+	rsp, err := c.PostClusterHeartbeatWithBody(ctx, clusterId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostClusterHeartbeatResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostClusterHeartbeatWithResponse(ctx context.Context, clusterId ClusterIdParameter, body PostClusterHeartbeatJSONRequestBody, reqEditors ...RequestEditorFn) (*PostClusterHeartbeatResponse, error) {
+	rsp, err := c.PostClusterHeartbeat(ctx, clusterId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostClusterHeartbeatResponse(rsp)
+}
+
+// RotateStewardTokenWithResponse request returning *RotateStewardTokenResponse
+func (c *ClientWithResponses) RotateStewardTokenWithResponse(ctx context.Context, clusterId ClusterIdParameter, params *RotateStewardTokenParams, reqEditors ...RequestEditorFn) (*RotateStewardTokenResponse, error) {
+	rsp, err := c.RotateStewardToken(ctx, clusterId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRotateStewardTokenResponse(rsp)
+}
+
 // DocsWithResponse request returning *DocsResponse
 func (c *ClientWithResponses) DocsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*DocsResponse, error) {
 	rsp, err := c.Docs(ctx, reqEditors...)
@@ -2336,24 +3948,50 @@ func (c *ClientWithResponses) QueryInventoryWithResponse(ctx context.Context, pa
 	if err != nil {
 		return nil, err
 	}
-	return ParseQueryInventoryResponse(rsp)
+	return ParseQueryInventoryResponse(rsp)
+}
+
+// UpdateInventoryWithBodyWithResponse request with arbitrary body returning *UpdateInventoryResponse
+func (c *ClientWithResponses) UpdateInventoryWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateInventoryResponse, error) {
+	rsp, err := c.UpdateInventoryWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateInventoryResponse(rsp)
+}
+
+func (c *ClientWithResponses) UpdateInventoryWithResponse(ctx context.Context, body UpdateInventoryJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateInventoryResponse, error) {
+	rsp, err := c.UpdateInventory(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateInventoryResponse(rsp)
+}
+
+// SearchInventoryWithBodyWithResponse request with arbitrary body returning *SearchInventoryResponse
+func (c *ClientWithResponses) SearchInventoryWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SearchInventoryResponse, error) {
+	rsp, err := c.SearchInventoryWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSearchInventoryResponse(rsp)
 }
 
-// UpdateInventoryWithBodyWithResponse request with arbitrary body returning *UpdateInventoryResponse
-func (c *ClientWithResponses) UpdateInventoryWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateInventoryResponse, error) {
-	rsp, err := c.UpdateInventoryWithBody(ctx, contentType, body, reqEditors...)
+func (c *ClientWithResponses) SearchInventoryWithResponse(ctx context.Context, body SearchInventoryJSONRequestBody, reqEditors ...RequestEditorFn) (*SearchInventoryResponse, error) {
+	rsp, err := c.SearchInventory(ctx, body, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
-	return ParseUpdateInventoryResponse(rsp)
+	return ParseSearchInventoryResponse(rsp)
 }
 
-func (c *ClientWithResponses) UpdateInventoryWithResponse(ctx context.Context, body UpdateInventoryJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateInventoryResponse, error) {
-	rsp, err := c.UpdateInventory(ctx, body, reqEditors...)
+// MetricsWithResponse request returning *MetricsResponse
+func (c *ClientWithResponses) MetricsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*MetricsResponse, error) {
+	rsp, err := c.Metrics(ctx, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
-	return ParseUpdateInventoryResponse(rsp)
+	return ParseMetricsResponse(rsp)
 }
 
 // OpenapiWithResponse request returning *OpenapiResponse
@@ -2365,6 +4003,24 @@ func (c *ClientWithResponses) OpenapiWithResponse(ctx context.Context, reqEditor
 	return ParseOpenapiResponse(rsp)
 }
 
+// GetComponentReportWithResponse request returning *GetComponentReportResponse
+func (c *ClientWithResponses) GetComponentReportWithResponse(ctx context.Context, params *GetComponentReportParams, reqEditors ...RequestEditorFn) (*GetComponentReportResponse, error) {
+	rsp, err := c.GetComponentReport(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetComponentReportResponse(rsp)
+}
+
+// GetDriftReportWithResponse request returning *GetDriftReportResponse
+func (c *ClientWithResponses) GetDriftReportWithResponse(ctx context.Context, params *GetDriftReportParams, reqEditors ...RequestEditorFn) (*GetDriftReportResponse, error) {
+	rsp, err := c.GetDriftReport(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetDriftReportResponse(rsp)
+}
+
 // ListTenantsWithResponse request returning *ListTenantsResponse
 func (c *ClientWithResponses) ListTenantsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListTenantsResponse, error) {
 	rsp, err := c.ListTenants(ctx, reqEditors...)
@@ -2443,6 +4099,15 @@ func (c *ClientWithResponses) PutTenantWithResponse(ctx context.Context, tenantI
 	return ParsePutTenantResponse(rsp)
 }
 
+// GetTenantFactsSchemaWithResponse request returning *GetTenantFactsSchemaResponse
+func (c *ClientWithResponses) GetTenantFactsSchemaWithResponse(ctx context.Context, tenantId TenantIdParameter, reqEditors ...RequestEditorFn) (*GetTenantFactsSchemaResponse, error) {
+	rsp, err := c.GetTenantFactsSchema(ctx, tenantId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTenantFactsSchemaResponse(rsp)
+}
+
 // ParseDiscoveryResponse parses an HTTP response from a DiscoveryWithResponse call
 func ParseDiscoveryResponse(rsp *http.Response) (*DiscoveryResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -2476,27 +4141,295 @@ func ParseDiscoveryResponse(rsp *http.Response) (*DiscoveryResponse, error) {
 	return response, nil
 }
 
-// ParseListClustersResponse parses an HTTP response from a ListClustersWithResponse call
-func ParseListClustersResponse(rsp *http.Response) (*ListClustersResponse, error) {
+// ParseFlushClientCacheResponse parses an HTTP response from a FlushClientCacheWithResponse call
+func ParseFlushClientCacheResponse(rsp *http.Response) (*FlushClientCacheResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &FlushClientCacheResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Reason
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Default
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListClustersResponse parses an HTTP response from a ListClustersWithResponse call
+func ParseListClustersResponse(rsp *http.Response) (*ListClustersResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListClustersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Cluster
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Default
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateClusterResponse parses an HTTP response from a CreateClusterWithResponse call
+func ParseCreateClusterResponse(rsp *http.Response) (*CreateClusterResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateClusterResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Cluster
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Reason
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Reason
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest Reason
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON422 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Default
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteClusterResponse parses an HTTP response from a DeleteClusterWithResponse call
+func ParseDeleteClusterResponse(rsp *http.Response) (*DeleteClusterResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteClusterResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Reason
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Default
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetClusterResponse parses an HTTP response from a GetClusterWithResponse call
+func ParseGetClusterResponse(rsp *http.Response) (*GetClusterResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetClusterResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Cluster
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Default
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUpdateClusterResponse parses an HTTP response from a UpdateClusterWithResponse call
+func ParseUpdateClusterResponse(rsp *http.Response) (*UpdateClusterResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UpdateClusterResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Cluster
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Reason
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Reason
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest Reason
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON422 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Default
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePutClusterResponse parses an HTTP response from a PutClusterWithResponse call
+func ParsePutClusterResponse(rsp *http.Response) (*PutClusterResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &ListClustersResponse{
+	response := &PutClusterResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest []Cluster
+		var dest Cluster
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Cluster
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Reason
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Reason
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest Reason
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON422 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
 		var dest Default
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -2509,33 +4442,33 @@ func ParseListClustersResponse(rsp *http.Response) (*ListClustersResponse, error
 	return response, nil
 }
 
-// ParseCreateClusterResponse parses an HTTP response from a CreateClusterWithResponse call
-func ParseCreateClusterResponse(rsp *http.Response) (*CreateClusterResponse, error) {
+// ParsePostClusterCompileMetaResponse parses an HTTP response from a PostClusterCompileMetaWithResponse call
+func ParsePostClusterCompileMetaResponse(rsp *http.Response) (*PostClusterCompileMetaResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &CreateClusterResponse{
+	response := &PostClusterCompileMetaResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
-		var dest Cluster
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Reason
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON201 = &dest
+		response.JSON403 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
 		var dest Reason
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON400 = &dest
+		response.JSON409 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
 		var dest Default
@@ -2549,26 +4482,26 @@ func ParseCreateClusterResponse(rsp *http.Response) (*CreateClusterResponse, err
 	return response, nil
 }
 
-// ParseDeleteClusterResponse parses an HTTP response from a DeleteClusterWithResponse call
-func ParseDeleteClusterResponse(rsp *http.Response) (*DeleteClusterResponse, error) {
+// ParseGetClusterCompileMetaHistoryResponse parses an HTTP response from a GetClusterCompileMetaHistoryWithResponse call
+func ParseGetClusterCompileMetaHistoryResponse(rsp *http.Response) (*GetClusterCompileMetaHistoryResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &DeleteClusterResponse{
+	response := &GetClusterCompileMetaHistoryResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
-		var dest Reason
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []ClusterCompileMeta
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON403 = &dest
+		response.JSON200 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
 		var dest Default
@@ -2582,26 +4515,33 @@ func ParseDeleteClusterResponse(rsp *http.Response) (*DeleteClusterResponse, err
 	return response, nil
 }
 
-// ParseGetClusterResponse parses an HTTP response from a GetClusterWithResponse call
-func ParseGetClusterResponse(rsp *http.Response) (*GetClusterResponse, error) {
+// ParsePutClusterDynamicFactsResponse parses an HTTP response from a PutClusterDynamicFactsWithResponse call
+func ParsePutClusterDynamicFactsResponse(rsp *http.Response) (*PutClusterDynamicFactsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetClusterResponse{
+	response := &PutClusterDynamicFactsResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest Cluster
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Reason
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON200 = &dest
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest Reason
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON422 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
 		var dest Default
@@ -2615,33 +4555,33 @@ func ParseGetClusterResponse(rsp *http.Response) (*GetClusterResponse, error) {
 	return response, nil
 }
 
-// ParseUpdateClusterResponse parses an HTTP response from a UpdateClusterWithResponse call
-func ParseUpdateClusterResponse(rsp *http.Response) (*UpdateClusterResponse, error) {
+// ParseGetClusterFactsHistoryResponse parses an HTTP response from a GetClusterFactsHistoryWithResponse call
+func ParseGetClusterFactsHistoryResponse(rsp *http.Response) (*GetClusterFactsHistoryResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &UpdateClusterResponse{
+	response := &GetClusterFactsHistoryResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest Cluster
+		var dest []FactChange
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
 		var dest Reason
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON403 = &dest
+		response.JSON400 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
 		var dest Default
@@ -2655,34 +4595,20 @@ func ParseUpdateClusterResponse(rsp *http.Response) (*UpdateClusterResponse, err
 	return response, nil
 }
 
-// ParsePutClusterResponse parses an HTTP response from a PutClusterWithResponse call
-func ParsePutClusterResponse(rsp *http.Response) (*PutClusterResponse, error) {
+// ParsePostClusterHeartbeatResponse parses an HTTP response from a PostClusterHeartbeatWithResponse call
+func ParsePostClusterHeartbeatResponse(rsp *http.Response) (*PostClusterHeartbeatResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &PutClusterResponse{
+	response := &PostClusterHeartbeatResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest Cluster
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON200 = &dest
-
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
-		var dest Cluster
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON201 = &dest
-
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
 		var dest Reason
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -2702,26 +4628,33 @@ func ParsePutClusterResponse(rsp *http.Response) (*PutClusterResponse, error) {
 	return response, nil
 }
 
-// ParsePostClusterCompileMetaResponse parses an HTTP response from a PostClusterCompileMetaWithResponse call
-func ParsePostClusterCompileMetaResponse(rsp *http.Response) (*PostClusterCompileMetaResponse, error) {
+// ParseRotateStewardTokenResponse parses an HTTP response from a RotateStewardTokenWithResponse call
+func ParseRotateStewardTokenResponse(rsp *http.Response) (*RotateStewardTokenResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &PostClusterCompileMetaResponse{
+	response := &RotateStewardTokenResponse{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest StewardRotation
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
 		var dest Reason
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON403 = &dest
+		response.JSON400 = &dest
 
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
 		var dest Default
@@ -2825,12 +4758,19 @@ func ParseQueryInventoryResponse(rsp *http.Response) (*QueryInventoryResponse, e
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest Inventory
+		var dest []Inventory
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Reason
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
 		var dest Default
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -2856,6 +4796,86 @@ func ParseUpdateInventoryResponse(rsp *http.Response) (*UpdateInventoryResponse,
 		HTTPResponse: rsp,
 	}
 
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Reason
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Reason
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Default
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSearchInventoryResponse parses an HTTP response from a SearchInventoryWithResponse call
+func ParseSearchInventoryResponse(rsp *http.Response) (*SearchInventoryResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SearchInventoryResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []InventoryQueryRow
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Reason
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Default
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseMetricsResponse parses an HTTP response from a MetricsWithResponse call
+func ParseMetricsResponse(rsp *http.Response) (*MetricsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &MetricsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
 		var dest Default
@@ -2888,7 +4908,87 @@ func ParseOpenapiResponse(rsp *http.Response) (*OpenapiResponse, error) {
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON200 = &dest
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetComponentReportResponse parses an HTTP response from a GetComponentReportWithResponse call
+func ParseGetComponentReportResponse(rsp *http.Response) (*GetComponentReportResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetComponentReportResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ComponentReport
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Reason
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Default
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetDriftReportResponse parses an HTTP response from a GetDriftReportWithResponse call
+func ParseGetDriftReportResponse(rsp *http.Response) (*GetDriftReportResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetDriftReportResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []ClusterDrift
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Reason
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Default
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
 
 	}
 
@@ -2956,6 +5056,13 @@ func ParseCreateTenantResponse(rsp *http.Response) (*CreateTenantResponse, error
 		}
 		response.JSON400 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Reason
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
 		var dest Default
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -3055,6 +5162,13 @@ func ParseUpdateTenantResponse(rsp *http.Response) (*UpdateTenantResponse, error
 		}
 		response.JSON200 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Reason
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
 		var dest Reason
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -3102,6 +5216,13 @@ func ParsePutTenantResponse(rsp *http.Response) (*PutTenantResponse, error) {
 		}
 		response.JSON201 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Reason
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
 		var dest Reason
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -3121,11 +5242,47 @@ func ParsePutTenantResponse(rsp *http.Response) (*PutTenantResponse, error) {
 	return response, nil
 }
 
+// ParseGetTenantFactsSchemaResponse parses an HTTP response from a GetTenantFactsSchemaWithResponse call
+func ParseGetTenantFactsSchemaResponse(rsp *http.Response) (*GetTenantFactsSchemaResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetTenantFactsSchemaResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest FactsSchema
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Default
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 	// Lieutenant API Root
 	// (GET /)
 	Discovery(ctx echo.Context) error
+	// Flushes the client cache
+	// (DELETE /admin/clientCache)
+	FlushClientCache(ctx echo.Context) error
 	// Returns a list of clusters
 	// (GET /clusters)
 	ListClusters(ctx echo.Context, params ListClustersParams) error
@@ -3147,6 +5304,21 @@ type ServerInterface interface {
 	// Stores compilation metadata for a cluster
 	// (POST /clusters/{clusterId}/compileMeta)
 	PostClusterCompileMeta(ctx echo.Context, clusterId ClusterIdParameter) error
+	// Returns the reported compilation metadata of a cluster
+	// (GET /clusters/{clusterId}/compileMeta/history)
+	GetClusterCompileMetaHistory(ctx echo.Context, clusterId ClusterIdParameter) error
+	// Replaces the dynamic facts of a cluster
+	// (PUT /clusters/{clusterId}/dynamicFacts)
+	PutClusterDynamicFacts(ctx echo.Context, clusterId ClusterIdParameter) error
+	// Returns the changes of the dynamic facts of a cluster
+	// (GET /clusters/{clusterId}/facts/history)
+	GetClusterFactsHistory(ctx echo.Context, clusterId ClusterIdParameter, params GetClusterFactsHistoryParams) error
+	// Reports that the cluster's Steward agent is alive
+	// (POST /clusters/{clusterId}/heartbeat)
+	PostClusterHeartbeat(ctx echo.Context, clusterId ClusterIdParameter) error
+	// Rotates the credentials of Steward
+	// (POST /clusters/{clusterId}/steward/rotate)
+	RotateStewardToken(ctx echo.Context, clusterId ClusterIdParameter, params RotateStewardTokenParams) error
 	// API documentation
 	// (GET /docs)
 	Docs(ctx echo.Context) error
@@ -3162,9 +5334,21 @@ type ServerInterface interface {
 	// Write inventory data
 	// (POST /inventory)
 	UpdateInventory(ctx echo.Context) error
+	// Queries inventory data
+	// (POST /inventory/query)
+	SearchInventory(ctx echo.Context) error
+	// API metrics
+	// (GET /metrics)
+	Metrics(ctx echo.Context) error
 	// OpenAPI JSON spec
 	// (GET /openapi.json)
 	Openapi(ctx echo.Context) error
+	// Returns the versions of components and packages used across clusters
+	// (GET /reports/components)
+	GetComponentReport(ctx echo.Context, params GetComponentReportParams) error
+	// Returns clusters whose compilation is out of sync or stale
+	// (GET /reports/drift)
+	GetDriftReport(ctx echo.Context, params GetDriftReportParams) error
 	// Returns a list of tenants
 	// (GET /tenants)
 	ListTenants(ctx echo.Context) error
@@ -3183,6 +5367,9 @@ type ServerInterface interface {
 	// Updates or creates a tenant
 	// (PUT /tenants/{tenantId})
 	PutTenant(ctx echo.Context, tenantId TenantIdParameter) error
+	// Returns the schemas for the facts of the tenant's clusters
+	// (GET /tenants/{tenantId}/factsSchema)
+	GetTenantFactsSchema(ctx echo.Context, tenantId TenantIdParameter) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -3199,6 +5386,17 @@ func (w *ServerInterfaceWrapper) Discovery(ctx echo.Context) error {
 	return err
 }
 
+// FlushClientCache converts echo context to params.
+func (w *ServerInterfaceWrapper) FlushClientCache(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.FlushClientCache(ctx)
+	return err
+}
+
 // ListClusters converts echo context to params.
 func (w *ServerInterfaceWrapper) ListClusters(ctx echo.Context) error {
 	var err error
@@ -3221,6 +5419,20 @@ func (w *ServerInterfaceWrapper) ListClusters(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sort_by: %s", err))
 	}
 
+	// ------------- Optional query parameter "connectivity" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "connectivity", ctx.QueryParams(), &params.Connectivity, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter connectivity: %s", err))
+	}
+
+	// ------------- Optional query parameter "gitRepoUrl" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "gitRepoUrl", ctx.QueryParams(), &params.GitRepoUrl, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter gitRepoUrl: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
 	err = w.Handler.ListClusters(ctx, params)
 	return err
@@ -3327,6 +5539,128 @@ func (w *ServerInterfaceWrapper) PostClusterCompileMeta(ctx echo.Context) error
 	return err
 }
 
+// GetClusterCompileMetaHistory converts echo context to params.
+func (w *ServerInterfaceWrapper) GetClusterCompileMetaHistory(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "clusterId" -------------
+	var clusterId ClusterIdParameter
+
+	err = runtime.BindStyledParameterWithOptions("simple", "clusterId", ctx.Param("clusterId"), &clusterId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter clusterId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetClusterCompileMetaHistory(ctx, clusterId)
+	return err
+}
+
+// PutClusterDynamicFacts converts echo context to params.
+func (w *ServerInterfaceWrapper) PutClusterDynamicFacts(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "clusterId" -------------
+	var clusterId ClusterIdParameter
+
+	err = runtime.BindStyledParameterWithOptions("simple", "clusterId", ctx.Param("clusterId"), &clusterId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter clusterId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PutClusterDynamicFacts(ctx, clusterId)
+	return err
+}
+
+// GetClusterFactsHistory converts echo context to params.
+func (w *ServerInterfaceWrapper) GetClusterFactsHistory(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "clusterId" -------------
+	var clusterId ClusterIdParameter
+
+	err = runtime.BindStyledParameterWithOptions("simple", "clusterId", ctx.Param("clusterId"), &clusterId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter clusterId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetClusterFactsHistoryParams
+	// ------------- Optional query parameter "key" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "key", ctx.QueryParams(), &params.Key, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter key: %s", err))
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "from", ctx.QueryParams(), &params.From, runtime.BindQueryParameterOptions{Type: "string", Format: "date-time"})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter from: %s", err))
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "to", ctx.QueryParams(), &params.To, runtime.BindQueryParameterOptions{Type: "string", Format: "date-time"})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter to: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetClusterFactsHistory(ctx, clusterId, params)
+	return err
+}
+
+// PostClusterHeartbeat converts echo context to params.
+func (w *ServerInterfaceWrapper) PostClusterHeartbeat(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "clusterId" -------------
+	var clusterId ClusterIdParameter
+
+	err = runtime.BindStyledParameterWithOptions("simple", "clusterId", ctx.Param("clusterId"), &clusterId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter clusterId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostClusterHeartbeat(ctx, clusterId)
+	return err
+}
+
+// RotateStewardToken converts echo context to params.
+func (w *ServerInterfaceWrapper) RotateStewardToken(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "clusterId" -------------
+	var clusterId ClusterIdParameter
+
+	err = runtime.BindStyledParameterWithOptions("simple", "clusterId", ctx.Param("clusterId"), &clusterId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter clusterId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RotateStewardTokenParams
+	// ------------- Optional query parameter "gracePeriod" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "gracePeriod", ctx.QueryParams(), &params.GracePeriod, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter gracePeriod: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.RotateStewardToken(ctx, clusterId, params)
+	return err
+}
+
 // Docs converts echo context to params.
 func (w *ServerInterfaceWrapper) Docs(ctx echo.Context) error {
 	var err error
@@ -3371,11 +5705,32 @@ func (w *ServerInterfaceWrapper) QueryInventory(ctx echo.Context) error {
 
 	// Parameter object where we will unmarshal all parameters from the context
 	var params QueryInventoryParams
-	// ------------- Optional query parameter "q" -------------
+	// ------------- Optional query parameter "cluster" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "cluster", ctx.QueryParams(), &params.Cluster, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cluster: %s", err))
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "from", ctx.QueryParams(), &params.From, runtime.BindQueryParameterOptions{Type: "string", Format: "date-time"})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter from: %s", err))
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "to", ctx.QueryParams(), &params.To, runtime.BindQueryParameterOptions{Type: "string", Format: "date-time"})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter to: %s", err))
+	}
 
-	err = runtime.BindQueryParameterWithOptions("form", true, false, "q", ctx.QueryParams(), &params.Q, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	// ------------- Optional query parameter "latest" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "latest", ctx.QueryParams(), &params.Latest, runtime.BindQueryParameterOptions{Type: "boolean", Format: ""})
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter q: %s", err))
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter latest: %s", err))
 	}
 
 	// Invoke the callback with all the unmarshaled arguments
@@ -3394,6 +5749,26 @@ func (w *ServerInterfaceWrapper) UpdateInventory(ctx echo.Context) error {
 	return err
 }
 
+// SearchInventory converts echo context to params.
+func (w *ServerInterfaceWrapper) SearchInventory(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.SearchInventory(ctx)
+	return err
+}
+
+// Metrics converts echo context to params.
+func (w *ServerInterfaceWrapper) Metrics(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.Metrics(ctx)
+	return err
+}
+
 // Openapi converts echo context to params.
 func (w *ServerInterfaceWrapper) Openapi(ctx echo.Context) error {
 	var err error
@@ -3403,6 +5778,81 @@ func (w *ServerInterfaceWrapper) Openapi(ctx echo.Context) error {
 	return err
 }
 
+// GetComponentReport converts echo context to params.
+func (w *ServerInterfaceWrapper) GetComponentReport(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetComponentReportParams
+	// ------------- Optional query parameter "tenant" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "tenant", ctx.QueryParams(), &params.Tenant, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter tenant: %s", err))
+	}
+
+	// ------------- Optional query parameter "fact" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "fact", ctx.QueryParams(), &params.Fact, runtime.BindQueryParameterOptions{Type: "array", Format: ""})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter fact: %s", err))
+	}
+
+	// ------------- Optional query parameter "component" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "component", ctx.QueryParams(), &params.Component, runtime.BindQueryParameterOptions{Type: "array", Format: ""})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter component: %s", err))
+	}
+
+	// ------------- Optional query parameter "package" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "package", ctx.QueryParams(), &params.Package, runtime.BindQueryParameterOptions{Type: "array", Format: ""})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter package: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetComponentReport(ctx, params)
+	return err
+}
+
+// GetDriftReport converts echo context to params.
+func (w *ServerInterfaceWrapper) GetDriftReport(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetDriftReportParams
+	// ------------- Optional query parameter "tenant" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "tenant", ctx.QueryParams(), &params.Tenant, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter tenant: %s", err))
+	}
+
+	// ------------- Optional query parameter "fact" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "fact", ctx.QueryParams(), &params.Fact, runtime.BindQueryParameterOptions{Type: "array", Format: ""})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter fact: %s", err))
+	}
+
+	// ------------- Optional query parameter "staleAfter" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "staleAfter", ctx.QueryParams(), &params.StaleAfter, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter staleAfter: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetDriftReport(ctx, params)
+	return err
+}
+
 // ListTenants converts echo context to params.
 func (w *ServerInterfaceWrapper) ListTenants(ctx echo.Context) error {
 	var err error
@@ -3497,6 +5947,24 @@ func (w *ServerInterfaceWrapper) PutTenant(ctx echo.Context) error {
 	return err
 }
 
+// GetTenantFactsSchema converts echo context to params.
+func (w *ServerInterfaceWrapper) GetTenantFactsSchema(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "tenantId" -------------
+	var tenantId TenantIdParameter
+
+	err = runtime.BindStyledParameterWithOptions("simple", "tenantId", ctx.Param("tenantId"), &tenantId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter tenantId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetTenantFactsSchema(ctx, tenantId)
+	return err
+}
+
 // This is a simple interface which specifies echo.Route addition functions which
 // are present on both echo.Echo and echo.Group, since we want to allow using
 // either of them for path registration
@@ -3526,6 +5994,7 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 	}
 
 	router.GET(baseURL+"/", wrapper.Discovery)
+	router.DELETE(baseURL+"/admin/clientCache", wrapper.FlushClientCache)
 	router.GET(baseURL+"/clusters", wrapper.ListClusters)
 	router.POST(baseURL+"/clusters", wrapper.CreateCluster)
 	router.DELETE(baseURL+"/clusters/:clusterId", wrapper.DeleteCluster)
@@ -3533,102 +6002,186 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 	router.PATCH(baseURL+"/clusters/:clusterId", wrapper.UpdateCluster)
 	router.PUT(baseURL+"/clusters/:clusterId", wrapper.PutCluster)
 	router.POST(baseURL+"/clusters/:clusterId/compileMeta", wrapper.PostClusterCompileMeta)
+	router.GET(baseURL+"/clusters/:clusterId/compileMeta/history", wrapper.GetClusterCompileMetaHistory)
+	router.PUT(baseURL+"/clusters/:clusterId/dynamicFacts", wrapper.PutClusterDynamicFacts)
+	router.GET(baseURL+"/clusters/:clusterId/facts/history", wrapper.GetClusterFactsHistory)
+	router.POST(baseURL+"/clusters/:clusterId/heartbeat", wrapper.PostClusterHeartbeat)
+	router.POST(baseURL+"/clusters/:clusterId/steward/rotate", wrapper.RotateStewardToken)
 	router.GET(baseURL+"/docs", wrapper.Docs)
 	router.GET(baseURL+"/healthz", wrapper.Healthz)
 	router.GET(baseURL+"/install/steward.json", wrapper.InstallSteward)
 	router.GET(baseURL+"/inventory", wrapper.QueryInventory)
 	router.POST(baseURL+"/inventory", wrapper.UpdateInventory)
+	router.POST(baseURL+"/inventory/query", wrapper.SearchInventory)
+	router.GET(baseURL+"/metrics", wrapper.Metrics)
 	router.GET(baseURL+"/openapi.json", wrapper.Openapi)
+	router.GET(baseURL+"/reports/components", wrapper.GetComponentReport)
+	router.GET(baseURL+"/reports/drift", wrapper.GetDriftReport)
 	router.GET(baseURL+"/tenants", wrapper.ListTenants)
 	router.POST(baseURL+"/tenants", wrapper.CreateTenant)
 	router.DELETE(baseURL+"/tenants/:tenantId", wrapper.DeleteTenant)
 	router.GET(baseURL+"/tenants/:tenantId", wrapper.GetTenant)
 	router.PATCH(baseURL+"/tenants/:tenantId", wrapper.UpdateTenant)
 	router.PUT(baseURL+"/tenants/:tenantId", wrapper.PutTenant)
+	router.GET(baseURL+"/tenants/:tenantId/factsSchema", wrapper.GetTenantFactsSchema)
 
 }
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+w8+VPburr/isbvzbSdmwVIoAdm7rzL0kJOKVAS6OkpzEOxPycCWXIlGUg7/O9vtHiL",
-	"naUtlPvOPb+BLX369O2b883zeRRzBkxJb+ubF2OBI1AgzH+7NJEKRC84SR/rpwFIX5BYEc68LW+PSEWY",
-	"rxAJEA+RGgPy7baW1/CIXhJjNfYaHsMReFuenwL1Gp6ALwkREHhbSiTQ8KQ/hgjrQ/5bQOhtef/VzvFr",
-	"27ey3Qu8h4eGNwCGmfpe5JTZNQM35UD+HGoPereMOZNgyLgHIU6o0n/6nClg5k8cx5T4WGPavpYa3W9L",
-	"HnIKWK83B5Xvu40CexZKEUB3RI0RRsLsaRnCOTj6mG3GuDI4yCr1zphUIvFVIiBANzBBt5gmgCIcI30P",
-	"TBhhI4TFkCiBxQRFoHCAFfYaHtzjKKagYUacEcUFYaOWnLCW4pzKtqTY2/LWuu3X6BSwr8gteA0vf28Z",
-	"oTnS1KyhIGUz5ixorq51ut5Dw1OTWDOMD6/BV/qBk1VDWUqPQ2/r83wqZsLtPTSWWmnlbdnVJ4LHIBQB",
-	"6T1c5vjt8igmFN6DwlWCF16mFJaIsJCLyPAI4SFPlBFjiqVCvllvXxk+7/Io4gEXoOU7zjEwkude7SSE",
-	"Bj0WckOqICB6O6YnpdWOvFJpvnkVOdutAENEGrxCAaDxRUP9poS8gJgLBQEaTszSDAgaEqblJ5EQoJCL",
-	"2gvqG1WYPqJ8iGmVkPvmeU5DDbCIirMFo2xZSEaJsO8WYlGm64io/riGl/tE9Q+2U7KMiAETEYX0U3e+",
-	"Oco+LlwvJ7oxThXIJ1iNU7ix+ZtJEkB2jqaz1Co3QXdjEPZF+Y5EIqm4gKD22ETUkPTs9DA9VP+ZUrB0",
-	"Xi20WxCSWPNWhnhuX6RQ3brMiRQRbl2wXcxQzAlTSHGEkcKjBhoKzPwx4gJhNkFcjUE4jEIQwHyoQajO",
-	"dhAmFWa+ZegslZipqj23293HaMMC7S3cFaPMhqAUj1rttWvqMXHbLSG1wZwHucKhv0X4/78Il/FKRVIu",
-	"NIFVEZELLOAFG4zBhANT8lYPrtZua6BOg6pEPcxfpkcokh9hEJKJ74OUYUKnrbO9obflBVhBU2+sF0v/",
-	"Bo9+UOF/XNGLAqxlzvAdOWT+9i1/fcU8cXK3UC9TAf2BoCiNnadpYuPXhSerbNnfQdF/ruDOSbHeYl/V",
-	"GEjz2Jk+nNYAkN3dQn2dZfqY0kl2CZtUtm1SGWMiZKucOfqUJ4G35eE76TW8gGhsh4k7jsfA5JiEqmuS",
-	"9ZF9CknzDqRqrs7LEXuBKXWUJJYES1YdZgGd5zu2M3IEEBKrbJYu1pnulwWUaK1PDK0izPAoT5m2T3oI",
-	"swDhRPERMBBYQeCASDneg5jyyTuYoDtCKRpCcX9fwR0WQUVXcbkEMI8ExWrBQ8Pzy7nsEklxMft9MCyN",
-	"KZ4cmeJLTe1Gv0RHxfDCJfkFMfHeT7SWTBCJdHppTFe2qprGThiOiJ+J8Dyc9+zaktQ/NLxwmb3Tm0ZE",
-	"nULMF23bd8uy1NY9OIVbUm8vrOjYt9oIJGnJp5LfisSauSnrpIVHGxt8AxLFAnwItJFA/Bastc+gF/RW",
-	"nzUGNMhqaTk7bldba61OHe1NREjp2elhvSFVHIWg/DFyC7XwkhCkksbzOPnNVAmPgKkWMtgbceeMTrTM",
-	"S1CIlOTlhUSK34Az8XrtLaYkKCM+ViqWW+02jokpVN3KMWsxUG2HTltaBFrXkrP/MfD+eZGsrHR8Cb4A",
-	"NdBPzAMwFgkHx4xO0vJhhRrWzf4cf0uu+pn5O8c0DrKApGx5ZgUqvaliLVL6Binfh0A5G2mOlvCKEqqI",
-	"z0Vci1pezf2cHntZg3CdylcNk12EwrkOb1sAikEQHji3lwQxdtWvE8H1ItSfMGPO5ZgnNECMq1R+I8yM",
-	"A5jyiDfJEAQDBfI8DyBMpW0PK32VtZW11eZKt7m6MVjd3FrpbnW7f3qZpRbeljfyPWOOdk2E5W15vwUr",
-	"3c7ab2tdvBkGm93X3eHG69XOb8PXG50Vv9MJ135bDzqdtdBuGwgA7cttJR8ws48zdIx8rLQ2/nHTkav6",
-	"Hc9fjfhqa3W9tbriNbwIX3ONjl4TEWb+XtMvYoqVjkm9LY8Slty3cRRsdOvlaz+3qtPJWjGwK7vXRuZc",
-	"Mw9acK8V9xikPrV6Sr9/gOJkSIlv0uE2smvNP9piOZEoR5k+Vpjy0RRSzo65o00IUHXdZUWUctyEYG19",
-	"fXUTbW9vb+92jr7i3VX6515v9WjwZl0/6+3tb+L1j3eHyZ1///50Ehx96XV5mHz9I/HFzrt4//j25Hzz",
-	"5Lg7Sq4vWJ3RHnOp3sFE1t/+hvE7hvQaWQx+JQhtXV4anaSEAYq5lGRIwdDFPI6pKSLIV6VLjYiieNjy",
-	"eYSWut92mOwevDsfXH9J7m/Vxu77DRXsd/uH8eqOYm12DAcHb9bPjr+eBuEFKwAHP5C4Kcd4rcmIVPHa",
-	"+oY55M3a+fWfB0fjwz+O+KdBTw0j+jU42J4cDT6Z88r/7+zsvO2///L1dzjfFGdfz7o3H4nav4bT7snH",
-	"Pl7b7J98+X01PL8Zq+vOwd3m/fXh+R/nn8TZ5gf66aM4PvxjJ/6w8e7j9fB6sDcI9m44H7/9Ohq++fTP",
-	"embYBxVGxOCTkIDUXg/buoKzKOUIMM+5mBKcUhAttO2aOjxELxLmFr9AEWAmEVEvpLFKEWYFGPn+Eu90",
-	"ULp05vQ2obQuZ5oW8a12e0TUv0ZEjRPDujb2I9B2Xj/nsWxGk7TfOCJqOb9kE4DpGD1h5IumhFmGSABM",
-	"abIKZEG10HaieJSlMXXWQztOX4DV9JfEelHTDrnwbKxAQSkQNkxo2kc40AeSWyg9ZTxhpQcBGREl7aML",
-	"z2WxOkyyICXCAhDldyB8LKGBInyPNjrIH2OBfbNA48MVpq9a9eLVY7fAtDWqccnpKxRghV05Kwuzp2rV",
-	"eSeuJgYsHFFNOYtuOgVT56ffp53GSkyBY1JwjZXzOQn8RUH4cW9v1zoQk6mnrdt5W871onTP1D0KGNVd",
-	"pXBa5TI+JWDa0bUdOSJ9HcRNzqx+ZYXPRJCmgkg7UlgYDJWgNPIT61B1feca1bHdZWPc8873tFyIGdsH",
-	"Jgg1ACKQEo+gZAR2wMc66uWhWyUXXsqdVH+HPNKeRm9WDH5aF3/jxWWehznnQ1AIXpZrWOfJ4aLxAHcR",
-	"03fOg+/lTkmnKhYeYxdOt7mz7Y9YYamcVCOBLlMIICTsKSos25Si/D4I7n2IlW19aLvLY9tBKDqA1iOW",
-	"WpaqlBQbMS7JKSrSth8B2uUintUEXKZCUZXfX1irWLLQUEKnttxQiT7qBgF+PCSx4JaPR4ruo+rRgkB8",
-	"h31veJSPCHsPaszrHMe0d9LQL+vKvxL8RBA16WvOW1R2AAsQ24ktwA/Nf29TtH7/OPDcbJGGZN/m+I2V",
-	"iu3IEnGzJ6YR4RvTBBEm1Nsyr/5lCi9+YSrrvH9whLb3PRdKZpWadGG1y1LIr98b/Y6AKZfeUeIDk0aN",
-	"HPyd/h7qNHepcTOH7vX0Yf6YcwnY7TZsd3/L9lAGzU7TNwDaJlAnykjLIYHEWSZ7+G2eJ6+01lsrJiaJ",
-	"geGYeFtep7XSWvNsi8MQvG2aKVBTI9kHlc1buRpEfphngFox1qZYWwjr5b2p0bS1lZVHG0vLgrKawbQC",
-	"IbIpMbMonBdeZai20xG6omB6W58vG55MogjreHKK2OiUc6N+eCS1oMuJVBB5lxpC28WWciZ5D4lU2jik",
-	"CxG+xYRincG6qN7ys0xmvWs3Bd0oDVN+rpggQhWI/ADtfyzyJCinVfCV8410XvFLYrlYGlj0iuOJFX2v",
-	"5ItcKET1BYcTFBKg5fPM8XVnSS7U/w4npcMyBtptwJJIE9v8k+FWdF6XVYN4+ZMySRREyxbkC20hLASe",
-	"1MmqW2pIZPK+N1GsJsis1yk2445TBalo/bg0Z/J7CioRTCJsmVOQvoIYZ1nRQ8OLuaybB9LhBxQqkrnA",
-	"umCot6cDoNr8tZSzXvk/nqFe2QT1gukMVUO84yL4zhy1p5A/Bv9GpuV8pyBwT6SSaAghF+DCLTYyK6z/",
-	"ati25x2RgEJMqLTA0hvb1uqV4/NVmvHrsI8oia7sbVz80I/BT6+kV1wNOVdSCRybgv+VrZ+Y1mXZGFg+",
-	"7GZJsva6INUODyaPZnIzqa6R4lQMGNyhMhb5WPNDRfVWfwluTjIN60CnGl73ER3R7Pno7GDMXphaewmD",
-	"9RplSptNVAAOJk70HkHZLXuk40+hY1nR86K/an/LpucfLLIUVF1GYJ7LUpFmKiIwK3K5mPJVdVfKl7Rr",
-	"PgyoMePd2fS0iDu6d34h583BxNYqhiQIgD0CM+vIXWewa0ONzOzbtmQCcrq8VubcPqinZdvKrzQBIU+Y",
-	"E4PuvMGJLE+UrtodIBKgOyy1Ihsgj+qCZ/Ki1g9j5deMJJ3FAZ6vhHbFo3NzGT8TgRhB02D+jx9iarHw",
-	"U2WvvVmZe4XyieLG+I5tW+Hl6dtd9LqzufFqCQf1S6UzMdd4BjNlD35UI1UnjrXSnKjZssxTpz1PrE8S",
-	"9Swy/Wjsd9KbXRbh74ignktAnzN2+4uoxgzpXj4ma0+NxNVnaH3FhRkBzr/eyko5oZkITzuaF+yC9TRV",
-	"Azd+m33FpU2o/aBq1pT+bjrnm86QlibUWWCG6qXCUSxtP3BKiXlWyyhO7f37K3RpxrAqRksTfwlVr4lY",
-	"9jQcO0b8l1CN7yHXtJ40vMIXoU4KmwUpdIoUcH92IU5Lv7AfiJoCRcD9JAJmWyNoiKWdVuvf4dEIBDqr",
-	"FuX2NPiFVlrBvWqPVUTL/JiuVVXFKTsYYSlB54ZzSpSVG8wqUI4BUzX+OpMsGpBdY0sklVsfOADLXTym",
-	"mExJYl4Q5Dc1XYzqp8eUIiJRSBg8emW35ra1VKub1JxNwmKHD/3ePz4qfdXsylYMQNve3KIZqR/jW70o",
-	"HUlNYmNQRcL0Xlts6qms1iR4MhobiHkVebc0Lmgi4pBoo5wVsVCkg/O0sOXmV111jgAN0JVOhVrlclTL",
-	"rLuqLZ6lE7Bm9hW9NEBkPRSzxNa75pxlVp0xReiVKdeFDnQAlGh1N8cawpohR1ByEfbuXMXRVYiphKtq",
-	"Wa1neVyYZp9XZe8xogimKDvHkmFWNd29m63/P5stT7fZKmr0LpvwLIw/K57NRLt7N4rjzXYU2rqb1Zr5",
-	"Cic6hrgzU+1UIBlXeVL+qFqcJtca81RzjHS4uznv4m5d0PGMebFmQqrqhUGiWv3uAxZmmLw4uVQRpw9a",
-	"AvLBp4XiFNLk/sMhMoLjBsNK3ZP+m8M3uwN0uN0fvHQur2G+Y3mF3p4ev0fZtNoMEfzypOI3d/oiI0KN",
-	"WH6w901839jDRyy1lPmDsO9zERirx1FKm1QQcqbPbn98FETBIq7bgL/I9qcISOdSdP5E3VJl+rkA8xj0",
-	"Jxk1g6B1PNGK6brZ833vcQxMO3Wj/q6i56cBUZlVx647/tSGt4LSXFtWXT0jJHGdwoWNZhNvTHcVF/Wa",
-	"Bw74T9Ims17a3hUHjbwdMsomhrIBoWwkojABMyQjMwCT5adNO0vjZmBIkDeyTUO6dEo+mIRe9pOh6/Lx",
-	"EKXHv1p0fDaAM+98Ljs+dEz6uVTLOPu9l4UdY/dl7fM3jFUmEKkwph+7LG4Xp9MHP9wtVs/eLZ5q8Fq2",
-	"ZP1dh2zhMyftc2WMfUAv03FA02LAERTe5d8L6zuLhMlXjjR+IhWPQDiY01OG7uvg1JbPahEP0jmJp/BB",
-	"qRAvaBCrIg4/2x8ufLA0S9EfRaEzg/LTtEg/jf/V/ej03GXb0W79E3ejM2GoGJGCR2t/S38sbclO9KzR",
-	"NLMg04Hvqy5WfwJuuTb0IJ0W/sVd6OK5T9eEns2/72xBz2DZPqin5NfKLzB+jhGLus/OJz5v83keOxe2",
-	"nmew0C54ZC4+bd+5+r3BzMZdkW3/bl3nhTL5y3vOpXOfqOU8V4aXbjjPEOaTRD2DJD8W22uazUtHY88j",
-	"lv9JUeBfQwHrtaga3pUrHuUPPj5fat2wn3bX1UYPuY8pCuAWKI8jMCfYTyjaXnUIvfiJRmFw/0TwIPFN",
-	"bGSLHuWPMCq/zbE85B5TMHJf9swA3SRM/Sj4PbidCTaA22mwlxn1p+EXvlcpJfTlgf8qXuV9hcnx8s8u",
-	"1+xMK//lMnu2sfx49va8Qmh+rc+2Zl2x0IHKa4VVMDq3dpWz/IsD+//D5cP/BQAA//+Mh4SjqFoAAA==",
+	"H4sIAAAAAAAC/+x9eXMbN7L4V0HN/qqS1KNIXbYjVW39niz50MaHVpKdZCNXCM40SVhDYAJgJNMpffdX",
+	"OAczg+EhS7J3N/8kFgdHo9EXuhuNP5OUzQpGgUqR7P+ZFJjjGUjg+q/DvBQS+HF24n5Wv2YgUk4KSRhN",
+	"9pMjIiShqUQkQ2yM5BRQarr1L+j5FNDxEbomcspKqT8O040hKjiMyacewhThnGCBGNcfRV5O3CgZEUWO",
+	"54jiGTRGRimmaASoFJAhQoUEnJnZ5t9xQBwEy68gQ5LpXsdHiFAkp0TNkwGvz6vnnGGZTgmdoFmZS1Lk",
+	"fiqBiEAcPkIqIdMLQcPdzb1h/4IOT96dDxGj+RylHLAEgTCicO2hJAZoj1OUMRD0O4k4jIEr6DBF8Ekj",
+	"cIIYhX7SS4hCaoHlNOklaunJfpK6bUh6CYc/SsIhS/YlL6GXiHQKM6y25f9xGCf7yd8G1Y4OzFcxOM6S",
+	"m5tecg4UU7nudkrdqwM2aYf8MtBuVG9RMCpAE94RjHGZS/XPlFEJVP8TF0VOUqwgHXwUCtw/V5zkFLBq",
+	"ryeqr/cAZWYu5AAwm4wR1336GnF2HDXNAaVMahhEG3vvqJC8TGXJIUOXMEdXOC8BzXCB1DowoWqnMR8R",
+	"yTGfoxlInGGJ+xf0vWop0KwUUpG2kJzQieghJqfAzTgCYQ4NYtzd3FQcooZVe8XGY6CZ+usS5kLtGXzC",
+	"syIHBeuMUSKZGrcv5rQvGcvFQOQ42U+2dwdP0CngVJIrSHpJ9d1ssNrpDbXlOQixUTCabWxt7+wmN71E",
+	"zgtI9hM2UmCpH6zU0DuW52/Hyf5vi3fHi5nkprdSS0PHq7Y+4awALgmI5OZDBd8hmxUkh9cgcXsjg49u",
+	"5wQidMz4TO89wiMn0HIsJEp1e/NJ78shm81Yxrjm6aKCQFO0/fS0JHl2TMdMoyrLiOqO85Naa4teQw9J",
+	"i34PW4MhIjRcYw6g4EUj9aUGPIeCcUVCo7lu6gdBI0IVXWrBOmY8ukC1otamT3I2wnkbkS/07xUO1YAh",
+	"KFbGTHyzMZmU3HxbCkUdrxMiz6aRvXxB5NnLA4eWCdHDzIhE6lc7v57K/Bwsr0K6FnqtkU+wnLpxC/1v",
+	"KkgGfh6FZ6FYbo6up8DNh/oaiUBCMg5ZdNqSR1D67vSVm1T9k40j80VHuwIuCKPtEd+bD25U284r3RDg",
+	"/gU9xBQVjFCpNRiSeNJDI45pOkWMI0znVmYZiMbAgaYQASgmOwgVEtMUxCKW6GTVY9vbrkdzwxLuDdaK",
+	"kZchyMER5V7TJg6J7W4Q6UyXrpFbO/QXCf/7k3AdLkeSYqkIbJOIWCIBjX2tzIwGvcWHi8ptNajloDZS",
+	"X1Uf3RSSVFNogESZpiDEuMyb0tmsMNlPMixhQ3WMk2V6iSe3ZPjbM3pIwIrm9L4jC8xfuuU/nzFPLN0t",
+	"5UtHoLcwipzt3MSJsV+Xzix9s7+Mov9ewl1wxDpklII6shE5j0hvcgUUhGg4T74T6EzCNeYZwhOgsqcJ",
+	"qFTHgTFnM0SkQFPAXI4AS9GmKEVzZwARjJ03dYMfZmVtICSWEUV0oABF+mN1eNHnrOhcLXypnmVElQwZ",
+	"zQmFoXPV1Ecyrp9U42goJM5NQyK/E4jlGSgexLRGDpAhOeUgpizPLiimGRpSuAKuEKY7O9TrX5FQy8LV",
+	"jPrATstZsv9bYkBLNPC5+r8fKPmwDh27GW2DVfDnfQbJ1WZ/a7u/GaVTDjh7S/O58/dU/p/fHMI/dNPu",
+	"ESfjiGg88N6z6ykTkdO186Fpj51xFIJQ0yIOV0StUHv1FNcyprapLRL9UXWxy8iMZuBUR5NMdalQk27M",
+	"iJAboqQb2z/u7MWoeaFx1eKWYJU9VFIB0pGlRwkWlnBMW8jWYawoELyEyCwKwW4KTSekReZ4EswzYiwH",
+	"TOs6r0KV3MDwmbHHMcBMe4ftNXflpk51JEvcgJ5vFpDgc5zKiFDQP1vLEXusmN59dCaxJCnO83mIjUuY",
+	"D4yvr8CEi8qfdwlQKNQRjv5x9vYNUrD0EPQnfTTcGRoJI0tOFUaF+ql/QQ0AKVa7MAI0pGWeD3t6A9Rq",
+	"Qci2U3odP2CaszJL9hN8LZJekhG1GaPSrp4VQMWUjOWudulOzK9QblyDkBtbizx+x5pD6sxGsmVban3T",
+	"XYMuOglUwiKDMTGmk9kmczR6UTc3iLLhSr11M0zxpHKAHZwcIyWscSnZBChwLMFGFJAQ0yMocjb/Cebo",
+	"muS52pOgv5WvpnE2V5uQWhKiGUqrw4r23hq7Bll+MmKyGd3Q/TA1oYURoHSK6cRoFlZOppacMshIquBE",
+	"QDNteoggBkImVE90PQVqwhM6xsAVPnKcqj9wbcKKnFSPsshMD6JwqaS8tjlHOL1UixV6XViSEcmJnBsS",
+	"zDSWIKubF6JXDVbNqDsQKRC7pkgAvyIpIJymrNR6XmF5BlxZ3bKJVUJtWCeMnJilEx2tyaDgoDHTUyYs",
+	"UnEaNLAzi8GfPpxyMwgHHvpIUktj6FhRlABzCZxi5TTXh+/WVl5PSTqNhatUQ7X+46P+BT0w43vnf86u",
+	"gadYADp6c4ZyPILcUFNJyR8lOOrRMxY4BTWEi2h5sWEjX+q8S43taYHqGewzvigul4WRLDeqwmYYsjIz",
+	"YruKdxo4bXESgdIppJeKQ2DMnJFvkeLNe2V60rTkHKh0ok3PZUkAC0EmllOUa8PMJxnKyFib0NJD2L+g",
+	"Z2U6rSJ7Hg8+GlhSSXJjvXGYsStn8DLq9PCsJih/S3A6g42Cs0xpESJhFvfI2x8w53iu/sb1ENEi4RdG",
+	"k256SVqPSawQ3AijGHqA+olgpRGCLje9xMZd3+j4XiQ8qD6iN+2gbIi65PVc2ZtzRGYF4xJXGxUzAUIu",
+	"XAb0kWlb0+A3vWS8St9mpwmRp1CwZd1e2GY+ymF/CG2W1uHaW6OKXEsXVWyFOnhprNrGQdUKM4kvQSim",
+	"TCEDmgJiV8CtHWBHD2wQZjjl3IdrAzN+q7/d34nhXjsH8/zd6av4mVoyNAZladuGSvORseZTpRCcqPea",
+	"RIn8PtLQa13pdFjbmv1OcfIl2NO+anuFc5LVAZ9KWYj9wQAXRMcsr8SU9inIgQVnIAwAfRUP/v96vL9f",
+	"lJubO6mAlIM8V7/oHyBpn1giVrIe7dgMjuUKFulZpIs3a7+MUmr+n69MKQsstHNv8de1Zpf367iRWWAy",
+	"MxwFjSBndKJoowaXzstIGS86jqLVIcBOG7X73fad6jNwp89HtJzoRv3WXYHWdnQaqDtSJBaEimxKihlO",
+	"KfQk0DSL6M6CalcS0UKhY73D9Xk/Uzf2oxogCYCKbU9Mtrc1kGmExgtPaQccUAGcsMye1cqswDbifcKZ",
+	"aoTO5lTvqpiyMs8QZdIJqhmm+pjQODddliPgFCSI95WzRUfXj7TXKtne3N7a2Nzd2Hp8vrW3v7m7v7v7",
+	"r8RrdZ7sJ5M00XrnUHtVk/3kx2xzd2f7x+1dvDfO9naf7I4eP9na+XH05PHOZrqzM97+8VG2s7M9Nt3O",
+	"OcCZcZElqT5x6589OJp9N/uP/+dyR2ypb6z6NGFb/a1H/S3lzJnhj0yBo9rMCNX/3lYfihxL5VBI9pOc",
+	"0PLTAM+yx7tx9lcbdKiPJhH61r+bYEsW7Jj5pbIF6hxzCRE3ZmhqqCFqYqG9JRGpTuFan8Yj/K5+Rngs",
+	"rbA0J60ewiPtmSPVrNr3Ys1GNSbLs4Vjhmbv4kFxlpkhJZmBkHhWLPYVmeFWdvxccxJN9HonjI8NiXI0",
+	"I1JCFozeQzAr5FzBWtJLyq5pDetiLiTM9u25zR7b9nMCpRG++0t9Yw0ZoTY+RIAH+0MH3Ykzn/dVX5Z2",
+	"slhB5TAmtMvGSgw29hK75w5SbwugygVg+qGM4BxS2SJPF0jtDlUas6LhfzWDBupXGU7KlHJwODhfeQT6",
+	"mG3gCKRMBoMsDjd9KXShXqsr6zUAismMF5XJ3VSIYQCo7rjpebeN980EjpvWNmXOWxPxhZ+9REU5ykmq",
+	"w+YD67PQf6h12zXXo1EpljhnkwZQ1si1U2vnUtspVLethJhuQLb96NHWHjo4ODg43HnzGR9u5f86Ot56",
+	"c/7skfrt+OjFHn708/Wr8jr99Pp0nr3543iXjcvPv5Qpf/pT8eLt1cn7vZO3u5Py40VU2k2ZkD/BXMRX",
+	"r5kZqTYiDJIpVgaOvtdmVk4ooIIJQUY5aLz4rFjlTfyhtqgJkTke9VM2Qyut72BcHr786f35xz/KT1fy",
+	"8eHrxzJ7sXv2qth6KumAvoWXL589evf282k2vqDB4JBmAm+IKd7eoETIYvvRYz3Js+33H//18s301S9v",
+	"2K/nx3I0yz9nLw/mb85/1fPV/3769Onzs9d/fP4HvN/j7z6/2738mcgXH+F09+TnM7y9d3byxz+2xu8v",
+	"p/LjzsvrvU8fX73/5f2v/N3eP/Nff+ZvX/3ytPjn459+/jj6eH50nh1dMjZ9/nkyevbr3+ObYX5obUQB",
+	"KRkTEIqNsCYqZ4XUfYtVbJZKzvIceB8d2KRSNkbfldQ2/g7NAFNhvBuKMWeYBmNU/Wt7p9ydK0dYn5d5",
+	"HoutNkl8fzCYEPm/EyKnpd66gXKjKNNd/c4KsTGbuwzxCZGrHTVe+uhUCy7/yQFWcz+2pENHhPG5y5bU",
+	"391QbojACMR8wtIs2U+mgHM5nUcd4kuDysxHAtcIt1X60o0fU4/HWXvaA+c5NM0QyYBKRYHcJhr30UEp",
+	"2cyHNmKCFjHnSWYUfT+a+8xpq0U1+i4Sc/zOQUrg+t+wYX7C2UftZ4Lar5SVtPZDRiZECvPTRWJzBJS6",
+	"NEOaJGjvIe2hGf6EHu8ow4XjVDdQ8DCJ8x/6caY8pldAlQyPnE3dJ6TSshdbq2mV5xxxqwRTtBX1EkvP",
+	"5IPUILnGzmnaZfktcW40T2UW+CgFuan/WUIMSU9xegk0Q4RmUABVtIT+UE0Vvupw9y/oBX1OIM/MCXrC",
+	"WVmYLRSQB/GrjEkkoMCG6FTeiHLpqlwStW7Mpb17YeEe9tDQGCT6Xw6fQ8Q4GnoQhvUhlMvajFK10KKR",
+	"0BKquEKwApaWM+XQuqCt3ceTCYeJdxFl7sZCQpmO2rfO+yNCbTjDJvOzMQKcTtFYocdFWbFBkbpeogYa",
+	"2vCgUGqCXaMCeAAfUKldQEMVP66aqjlmTEibvGDmU820nV5vR8vZCLiGhUpOqnwoF0nU0KnOmb0ZUu+f",
+	"E6EFr/tqF2cw5lIZLEoUlPo4XGqh6rpE0xm8V6VFfToYlTKdVMSbbGLktqh5ZQKHfvNcspZbX2NCxBOe",
+	"vDFl91Yyi6X6/B7a/pU/rla/mbjsejBxNlsTRTYCqfQl90dfIpAVI6udKjWRPp13IcPkblhcqBGNxaA6",
+	"NXekkkOrrzrHEoSssd0Y5wJ6C/HQZIs26Wh2DEOUVrMpLCBuj92LUh7Wo9T6CUttgUtdWDF1gn3J1nsP",
+	"xXp7f7NUYZyy65gFosSXDkYGUBmtwUGoPWxKWCuQIh4hL7PqopBY+apmUvqyltdWrYRQCRPgnozXOzC/",
+	"98JbR00tIwyNyoqdyg0XfMkkRvD0kFM59Zwcs4bY6TvU966VhabnsRszAF7ba2ltnz4uSOD7bFEkI1m6",
+	"zHv89vjo0Jz2DXbsPb+FDmfVyPVpLCyAKLaUYLbWYtKcgL67GFtKRkSqgijzd+Yw5Hmj5GRDwkx5SmGp",
+	"oV4bpVfNGAPVXlKMsY7+ok/i1TXJJrvwju7nOgikB5iBECZvqxIwTyHFpdAnHtNKLF2UnSm+hirS1QSv",
+	"KwZ2Got/4eW5uzcL5u/ILjyPpAt23Qgw89pUA2FSBVxMSOfFVSq8HfdRDbpPf2H4qJn6147GbUdj5mYV",
+	"7TmejcfmlLUo8ufDJRwROgVOfPZv5elbNSxYI3cLVa9CwiIqgSzwB652V7QKxq+WKGiufJ7F47oNPisz",
+	"Ihdd8pTubGaHi8TEm+k/wMHEzyGSVmSlwUnkGHqiwgK8lryt2tp8IjuiPa00YKjt29bedn+zv93fjRIR",
+	"meFY8MYvT312WUsrTJax9BJ4n7BBYUJsYk5dgH7/arOLlO2gkB3IhefhJq5D5K5suJYCuM4m74iIaI/P",
+	"FyJdBaVSmQ+utvo7m/1N9H0QSvsBVSGrwZN098d0e7RSdnWLiewundr8oZhcVWaVEW9uS1MO2vWDc8Rd",
+	"x2iYoyMl5C01qEVLckMah0idS+anrqWO3GO6R2vzOVyxSzgYRwNimtLMechsuAK8UEKUlaIGfeW8sxfy",
+	"1ajZ6vZzLWW5QnZMUla5FauJR1fhYal8NA2bV+N99zvM423NFDFwbNRLZfHS+8jjPchzVK0HwacUCmmu",
+	"S6odZIUxy0MPZyQL9NZpfSul1IWXNyMa+CCdqYv6vOi6OLxKKltb8T5gUtuKGWk1cKJCqBWJiBUPuH14",
+	"wgy3emwiPJ20D0xZxtc4PvSSnE0IfQ1yymLnkubhR40ekxv1FJ3uKi9XQcJTeEudcZfs1GIDGqXiN9Gr",
+	"x7Vhqm2wMZTuW0xiJZCr7CX7Ww9N7A1GJQQU7ayX0fROn46WJTTZVCkP6gL8mwEjAq+j7ECFL72CWhi+",
+	"FCYfvysOEcsyq/cNrjH2QuwdH90GT3bwmH9uwaXTxddMa1SSjjZHj+HJE/gRb2/tpI+3dp9sPd569AjG",
+	"6XYGe9t7eHtzE9LNte6IRi+GRm2QQEIE5qxHyoYh4riYWC0C2MUrvZUvkN7mjFYdWz3hLKPgoKJPzCpY",
+	"605crcJHW4aIxeUQ2mSs/J0CpEnfCFMNwzx+I2/W8jGvd49twUW0DzHXnIC05ETOdfqNwcVTwBz4QWnu",
+	"VI/0X8+d1vjHz+eJLUOlnc/6awWGollT3YrYckLaAE8N/DNMcu33HLP/1RZ1GhTwen/28g06eJFYnvHk",
+	"7xq2L84H6ZOvtfk1U7tkMnFykgIVUOmJ5OnZEdrZOMy1k+mV/dycLJ0yJgDb3prn7L/FYCSyjZ2NVA8w",
+	"0DtDpN6NIF3KTH5VpUFu9h/1N1VjVgDFBUn2kx11EE7MrXWN8IH6zwQi6vEFSF+ayzoBqskSPaixMo4z",
+	"o5mMjy9pVDHb3ty8swpm3iUbqWEWIGLmm/Wq2Eh8ZA/qwFVbCwkz2f/tQy8R5WyG+bw+hzKyTxnTYg9P",
+	"hKJ3kxCYfFAjDHA2I3Rgzs6HOJ1aBZhDLO/iVKdUCpMPh/UB+yd/QLYHcBVNPDXcZcsKAJ8R4QzRoRl7",
+	"iIamuR5GX+cyLnpfy2yoQXfx1dY+Ps9LMT0MwG5t525MxRJ9DUl1QGM1gknm3N3ceYDqdRpkJxPTEBSi",
+	"D9kjkmVAb08Mfvv1RCBa83TRQGiTRFnslY0bu4YIX2GSY5VwZvft4ORY11VQWUvCHskxB3WrC+cq/0PX",
+	"d9TxZz+I1gYTkHXLSdo7kUE3bu/51SlAAXUYpPMHxTB/ax1CSC6rO3VCn0ANf5C6T8OrDKK66YhXs3xi",
+	"EhZLbGmWlnuOcWnWPZqb2FBtPpJ1zCUYl7+P5rXJPFmYbi5gX79IHR5fP/SWAxhBTXgvzWp4wluZWtUi",
+	"XM2D2DrCsWqLuVXlhJXAr5cVIbwjJ3SNs6YdfcMOZM3I2HLt0d4EkLrJ5MMXKp+V7P9Om78tmmxTTag6",
+	"weyZzijX7U32sOWXgPX7dyCpTn2+TN4QMYGs8lkH6p4ME7Fabrqia3CzpCaVbF1bIuKJcl3JccP09qlw",
+	"Q5MJd0GVplQjXjOerZkMd6AdsU3A7YWE6gdGtf7AuZKSc323TJW4OZbmUq9wPay809eBhcsn8HfNVQtj",
+	"8doiptdEABpjkgszmJvQaJWhJZih7aQPwEQKNDS4sA4hlTrrEKJaDEeMSSE5LrTvd+jyj1qy3Wzooc/l",
+	"s9eNn7JsfmeK2rNHhB0cPakdqENR1cy9afHw1oPAZj6ZrXPmy+YDmC+HVTlnc1e7BsFDGFBnIDWxctAZ",
+	"8BkKnLyIcXtnpGZJ7W4+6vS0eJ4xPKGbb28/hCGo4cxYvTKOHfXLhephrL51VJ6Gxl9YamHRGeBI/y5q",
+	"qbaNI5ZuUbFNwzKLLalqMoiUMb/5sJpx76qL5PCgZFmbmDBap8Av3MwYumOKMWq3e/Vq7mvbLKXunXsB",
+	"8n63bfMhJeSYldSSwe7C2lUuLiLsTY8MkcwVVtKD3Kmp07kXUXtHiYeIc7TI8GImNC3ufDdXUcO6FsyG",
+	"hvx/brWpYaCzvb1mZfXdC8KFkoVVeL4/fX6InuzsPf5hBf39oNSp6+w8pP4+prpkg0dcEWD5oUWlWXxD",
+	"VT+g7mW8dtH5HpVxjFOjjF7KbjZnPHi0oovjT0r5Vdj9zjjDMrZfLMJr2N5fi3f/063+htT47xMV9y4Z",
+	"Oph7dWt90ChEFfeRnEnGQdSqc/qoib7jHrwEdEGPFWYze8Hcv4FhnLEF47Krxvmhq5LsYua1DE2aIX/7",
+	"a0mcoKgQbsruiXLEQbCSp9B+uqgtDJl3DodFt759wRhCGzsCr7qLK4jMiFF8VF08eUBO11EDREQjWtAi",
+	"gfq2GwD3HgBAk5RvC/GGqCet0sIGd4hRuAMJsc5mN8VFL3FNq1jrRsCMK8qTwZQIdzN24RGzSrZvAOoR",
+	"1yhVUUtqaT5WUBcq+ppU80KYGdf4VC+hkDEpUJ1nA7Z6aVf0LZxw1/HjN4oHLnXpRzYj4teXOiJp6uWY",
+	"jbrLw67s4hxPHmx8b2TcrFQYNbFPdYXVeunSqvjMAqXowmGVSjS9JlhOoX17IyTjqqZerKCqrqipy/zU",
+	"4bGRDDOLonptmOkYAJ4onSsD+yRWI+bg5LinNh3TeVRl+vPDUYi4b1hlRstLtjnhqLmr9TqYt1GTtRG/",
+	"mr5EFIgitgZRNQmKMr6uWn0Q+/roYU7gK3L4eia37r+WcuSQKoWXWddU5KGHGmx19UhmqiTAM12l1fSv",
+	"v0fibz64WsDaw9hDWF280bV5XfJms4wYWaI4NVfdpcrsRS9Bm/v3ddwQsVoJuVgc3tQoWyNPIwZF1637",
+	"2IT21nI142rXS1YHI3IDPAaHZOtD8SBWTFCAcAXrRbV2CIhlI9hP5lqZY63+w7tFgmIDd2s1NaTEXcmt",
+	"aViaKe4oONXYbD8g5rs2pYo38NyZXwmhdmWmugll3fT6KmG9+GdQkO02NlPwGs5iv8DL4A2Vb9bEqYCM",
+	"EOH7hfi+nWnjJ/Rc9XCGzRsml+/13VgE+ugop1guemzJ5NWYN3bX4DF77XGg72xCN6O9JjqfSuvrJkGb",
+	"YtuNI3MIH80QEaL0YX6fWWO62sOCtwui44vwRmRQWHXCceoq8tqB/Lsn7vaoSrALX0pgNIXqjumqd0tj",
+	"LKrvyIJdqM4Tuh+748hdPlvpGmmAqT6yZKWDjVvT+l25nc1ZV26gQuuJxuq9JgeuUADd30OOJz/5VRsS",
+	"/grhhpAEv50QvsaG1c/RK8ZRD4ZnzULtrxEcGUu7062VkuTmdW+doegqmxlyHWHDb+jsGk8mwNG743YK",
+	"jhp+KRlJ+CQHUznL6zvXpMe2E9pPjLAQIMXCywitFXSloZsajZ870aIGMm1MamNr1S/tAKstvMgxadBs",
+	"xcXsMuktR4S6qaxOKeRL3L0L0NZYbRRrscv23SgMr1qbN67Cp+5tsioFyHRdPydQtSKa4ivVyGmgstBK",
+	"iJdU9TVJosfS54iax4/UiNVdgcNa3XctPcfE3pw0v5nzv1MWRgm6nFxdeG+oGLxfTyPt63bDaNKrGUEY",
+	"Xxn6Xg8i4qPoJiZPdcFcutU7Kkk+VEm6x2MzdA8RiRQrLIPVzqJuwugqaGZCZs1cqpLIyJW9B6E3yATV",
+	"Wu/uVBIPz9w6jY4yL0OZF8gqPay+6VnsBFZlt1NvbdmVSqAtvFhxTImSgU3zo/Nwar7dn+5rXt5rsWxw",
+	"X6lCjmTerrHr7tXf+NL2tFZDW5FaFJZM9dZ2KitH/JTJKjPtTiVGeHx0XKpZnASVdPyqA3kSU1C1mqhR",
+	"WXIGmOtyIq0SoiEg7aJvdb+XQFfElK62BT31kbAeHQpfPrOusHf6qbC3Z+eognSgqU3zlilX2q7FVpU1",
+	"jZRtjRmjukZeVYJ2CTeE3ptYrcvq5ZSkt/AibvQmje95S7fWeqUlH8jJtWbRw7tyeS2E6Y5qT8ZgtcUw",
+	"o7e6bFXMZsnKh3HPVTS+gndOc4V5TF5ZCjEHXQNnWq+D+AoeOrMBd+ecayxMHai5frtTMuQ22wlW33bB",
+	"3aWfOZEQIbEw9FjLkzG6no0jrpIzc8o/MIf8mDwzSUehQLsPr1VATNGNWVC1e6U7NgsHfOhwnPJaBeE1",
+	"/RZKQ7vEEld2HzCH7g5sDs8HMXrtIPmaHWG084L8NK2yhdXZDmUxLbrMdrAuK5tkb51UQTlWbwNAFmMR",
+	"Y9c8GIv808ineNaREyh3m/26nkLwZYL/UgxRhlBrJiBWZ4kZSE4WuH9em++O2JUjwJoXJ5zNQE6hFEi5",
+	"MJA1dZoUbAe4Cz/I39DLZ69OUPVa1O+4IL+bogK/66ICv0+JFL/rW6uoqvVcnVO1W1W7iHFnzYj+Bf0b",
+	"Ov/15NmqE2n9BvyCrth+dzv2uESbNhzm7sOZM/O7EvXj2Noni/037u0r83CW8XWmzqlWJ4K3Zrzkvg/U",
+	"LZAWIqLdugMdNr1vUH+XMe7UcqJ9QRbiqorjlb4drX7KYlXXwGRgOJjCdyZ7XUXA9FPFOvY5RkPf1Tx+",
+	"Yfvqp+Un5ApcjST3YHTW+ail8fWYoExXBkfjAc1VDq+EpnmZwbrV7u+qWkYchqpwoUKRy4+p3dW/hPnf",
+	"tcIf1otJhe/l/z14Lf9DxwEXp3WIVy1BtfRAKWCVglfRAhq2173B5WiqDlWFrI0Zo0QyPUEHkFXdwFuA",
+	"eK/XRBs80JEoq5vYRIaHt0QU1aGxLmRyx1kkoexa+DwuTjkTIlZ9wwrihem3TlhnrpB7VE4rRGN3irVl",
+	"QLXMDF9OdkHZq8bjvs27JqtJ9AvqZQf4UuvNmvIusxbaxdiJaJyyh9H6q0aYR1+RHvYuqI3im+9LBpk0",
+	"f6y/w42mWCDKKGivgIW/Xm+pXkDeZoj6b3oLMq2PKl1XSVo3EGKlLsci5jRtKzbzYkxrS+rXIYa6oI+u",
+	"3zzs0FC68P9f2ukraKeDSb18Nm5upHuVBnRqUW5ySV8wlFkGrSdB2GcLHd3hCdSTIrYe/zjt2IiKSr6Z",
+	"ikmaLNcomyRq3MK4w1itamvrLFx7VFaxHKF6gK9wFDa6R0GO7zSRsSEwmrIigrQ1FY/h4uWF6xSuWwWs",
+	"blG7zo1RK13nfoxUrpuAjBeuO7eAfyFVV7Lkz3rd8uQpmfgC5L7eeKxc7ohMTI0zd8lzwzCyK3KWVVXx",
+	"bnrNWao65+j7s3JkNTkbIzf9D8umr2qsLZifiZ0UdnSS5Up8bLC7Cgeblt9A3TPpCcLRv6tIu7zqmX+G",
+	"+g6Knsl/86JnjTplZn99mTK71EBVKS0kCpwC+t49U+CzKqpvemEeebyk4geL47QUks2A2zGbrx+oNEki",
+	"kPPfdlU6O3fWyH24mh03LKlzJkMYvrTMWfAGb5fEuBPJ4CXTF+Pi3J5CHrrAgpv3376qml1Iu6janZYz",
+	"81TaEpOBPTD4U9q3UVYsZdZVLFo38My5XhKye55l3Tpm5+55lQcuYxbOe39VzLr3b80aZh1b9gLkfe7X",
+	"5gNIZfNlafkyq/W/bvWyRdu5tHZZxxaaBne8i/dbuKz9QFNneaNw2761smVLafKrFS2zWPsaNctqS79T",
+	"qRjhhBgbrVwZrIOfTkr5FZjprigvUhVsZUv163DGXxbyfcqA/yTGj3PvipatqUFw5he5tAKBXbEv/dAs",
+	"ieHjEzWvZKvKSK0OyawUrmjDiMmwcoMbNXiLxL0N5AMKjDYe0euIE5ideB6s9xs37kJQOyvL2cZ3HPJz",
+	"u+zuji7e4ii51fM36o8d/fZBIU6fFKNxmlcsxTnK4ApyVsxM2No8HzSI3MEMnycKCOWEs6xMVRv7WFD9",
+	"AaLWg6Orj6yu1k04XjT0BqHytsMfwVXnsBlcNYf94LHfygGr3mqqOQfr0aub3uJ+wS43LhG0e7pc0fpl",
+	"EN+x/nN39yoLTufCm8uKNiHODkWCVPO2vLJVvYJ0zVZo2o7jAgSR6NbJMbLZRL61/fvmw83/DQAdU3RC",
+	"R7kAAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -2,16 +2,18 @@ package api
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
 	"net/url"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/taion809/haikunator"
 
 	synv1alpha1 "github.com/projectsyn/lieutenant-operator/api/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 const (
@@ -21,6 +23,17 @@ const (
 	TenantIDPrefix = "t-"
 	// ContentJSONPatch is the content type to do JSON updates
 	ContentJSONPatch = "application/merge-patch+json"
+	// ClusterAliasLabelPrefix is prefixed to the aliases of a cluster to get the name of the label holding the alias
+	ClusterAliasLabelPrefix = "alias.syn.tools/"
+
+	// StewardInstalledAtAnnotation records when the Steward install manifests were fetched
+	StewardInstalledAtAnnotation = "steward.syn.tools/installed-at"
+	// StewardInstalledFromAnnotation records the IP address of the client which fetched the Steward install manifests
+	StewardInstalledFromAnnotation = "steward.syn.tools/installed-from"
+	// StewardInstalledUserAgentAnnotation records the user agent of the client which fetched the Steward install manifests
+	StewardInstalledUserAgentAnnotation = "steward.syn.tools/installed-user-agent"
+	// StewardInstalledImageAnnotation records the Steward image used in the install manifests
+	StewardInstalledImageAnnotation = "steward.syn.tools/installed-image"
 )
 
 var (
@@ -43,19 +56,9 @@ func GenerateTenantID() (TenantId, error) {
 	}, err
 }
 
-// GenerateID generates a new id from random alphanumeric characters
+// generateID generates a new id with the haikunator
 func generateID(prefix string) (Id, error) {
-	retry := 10
-	var id = ""
-	var i int
-	// let's try a few times
-	for i = 0; i < retry; i++ {
-		id = prefix + h.Haikunate()
-		if len(id) <= 63 {
-			return Id(id), nil
-		}
-	}
-	return "", errors.New("could not generate a DNS-compatible ID")
+	return GenerateID(HaikunatorIDGenerator{}, prefix, "", 0, nil)
 }
 
 // NewAPITenantFromCRD transforms a CRD tenant into the API representation
@@ -140,20 +143,20 @@ func NewCRDFromAPITenant(apiTenant Tenant) (*synv1alpha1.Tenant, error) {
 		tenant.Spec.GitRepoTemplate = tmpl
 	}
 
-	SyncCRDFromAPITenant(apiTenant.TenantProperties, tenant)
+	if err := SyncCRDFromAPITenant(apiTenant.TenantProperties, tenant); err != nil {
+		return nil, err
+	}
 
 	return tenant, nil
 }
 
-func SyncCRDFromAPITenant(source TenantProperties, target *synv1alpha1.Tenant) {
+func SyncCRDFromAPITenant(source TenantProperties, target *synv1alpha1.Tenant) error {
 	if source.Annotations != nil {
 		if target.Annotations == nil {
 			target.Annotations = map[string]string{}
 		}
-		for key, val := range *source.Annotations {
-			if str, ok := val.(string); ok {
-				target.Annotations[key] = str
-			}
+		if err := syncAnnotations(*source.Annotations, target.Annotations); err != nil {
+			return err
 		}
 	}
 
@@ -177,6 +180,7 @@ func SyncCRDFromAPITenant(source TenantProperties, target *synv1alpha1.Tenant) {
 	if source.GlobalGitRepoRevision != nil {
 		target.Spec.GlobalGitRepoRevision = *source.GlobalGitRepoRevision
 	}
+	return nil
 }
 
 // NewAPIClusterFromCRD transforms a CRD cluster into the API representation
@@ -201,6 +205,10 @@ func NewAPIClusterFromCRD(cluster synv1alpha1.Cluster) (*Cluster, error) {
 		apiCluster.DisplayName = &cluster.Spec.DisplayName
 	}
 
+	if aliases := ClusterAliases(cluster); len(aliases) > 0 {
+		apiCluster.Aliases = &aliases
+	}
+
 	if len(cluster.Spec.GitRepoURL) > 0 {
 		apiCluster.GitRepo.Url = &cluster.Spec.GitRepoURL
 	}
@@ -220,7 +228,7 @@ func NewAPIClusterFromCRD(cluster synv1alpha1.Cluster) (*Cluster, error) {
 	if cluster.Spec.Facts != nil {
 		facts := ClusterFacts{}
 		for key, value := range cluster.Spec.Facts {
-			facts[key] = value
+			facts[key] = UnmarshalFact(value)
 		}
 		apiCluster.Facts = &facts
 	}
@@ -228,12 +236,14 @@ func NewAPIClusterFromCRD(cluster synv1alpha1.Cluster) (*Cluster, error) {
 	if cluster.Status.Facts != nil {
 		facts := DynamicClusterFacts{}
 		for key, value := range cluster.Status.Facts {
-			facts[key] = unmarshalFact(value)
+			facts[key] = UnmarshalFact(value)
 		}
 		apiCluster.DynamicFacts = &facts
 	}
 
-	acm, err := crdCompileMetaToAPICompileMeta(cluster.Status.CompileMeta)
+	apiCluster.StewardInstallation = stewardInstallationFromAnnotations(cluster.Annotations)
+
+	acm, err := CRDCompileMetaToAPICompileMeta(cluster.Status.CompileMeta)
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert compile meta: %w", err)
 	}
@@ -253,7 +263,31 @@ func NewAPIClusterFromCRD(cluster synv1alpha1.Cluster) (*Cluster, error) {
 	return apiCluster, nil
 }
 
-func unmarshalFact(fact string) interface{} {
+// stewardInstallationFromAnnotations reads the Steward installation audit data from the annotations.
+// Returns nil if Steward wasn't installed through the API yet.
+func stewardInstallationFromAnnotations(annotations map[string]string) *StewardInstallation {
+	installedAt, ok := annotations[StewardInstalledAtAnnotation]
+	if !ok {
+		return nil
+	}
+	installation := &StewardInstallation{}
+	if t, err := time.Parse(time.RFC3339, installedAt); err == nil {
+		installation.InstalledAt = &t
+	}
+	if v, ok := annotations[StewardInstalledFromAnnotation]; ok {
+		installation.ClientIP = &v
+	}
+	if v, ok := annotations[StewardInstalledUserAgentAnnotation]; ok {
+		installation.UserAgent = &v
+	}
+	if v, ok := annotations[StewardInstalledImageAnnotation]; ok {
+		installation.Image = &v
+	}
+	return installation
+}
+
+// UnmarshalFact decodes a JSON encoded fact, facts which aren't valid JSON are returned as string
+func UnmarshalFact(fact string) interface{} {
 	var intFact interface{}
 	err := json.Unmarshal([]byte(fact), &intFact)
 	if err != nil {
@@ -264,6 +298,64 @@ func unmarshalFact(fact string) interface{} {
 	return intFact
 }
 
+// MarshalFact encodes a static fact the way it's stored in the CRD, UnmarshalFact returns the original value.
+// Strings are stored as is, unless they would be decoded as another value, all other values are stored JSON encoded.
+func MarshalFact(value any) (string, error) {
+	if v, ok := value.(string); ok {
+		if decoded, ok := UnmarshalFact(v).(string); ok && decoded == v {
+			return v, nil
+		}
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+// syncFacts copies the typed facts to the string map of the CRD.
+// Returns an error naming every fact which can't be stored.
+func syncFacts(source map[string]any, target map[string]string) error {
+	rejected := []string{}
+	for key, value := range source {
+		if value == nil {
+			rejected = append(rejected, key)
+			continue
+		}
+		encoded, err := MarshalFact(value)
+		if err != nil {
+			rejected = append(rejected, key)
+			continue
+		}
+		target[key] = encoded
+	}
+	return rejectedKeysError(rejected, "facts", "can't be null")
+}
+
+// syncAnnotations copies the annotations to the CRD.
+// Returns an error naming every annotation which isn't a string.
+func syncAnnotations(source map[string]any, target map[string]string) error {
+	rejected := []string{}
+	for key, value := range source {
+		v, ok := value.(string)
+		if !ok {
+			rejected = append(rejected, key)
+			continue
+		}
+		target[key] = v
+	}
+	return rejectedKeysError(rejected, "annotations", "must be strings")
+}
+
+// rejectedKeysError returns an error listing the rejected keys, nil if there are none
+func rejectedKeysError(rejected []string, field, reason string) error {
+	if len(rejected) == 0 {
+		return nil
+	}
+	sort.Strings(rejected)
+	return fmt.Errorf("values of %s '%s' %s", field, strings.Join(rejected, "', '"), reason)
+}
+
 // NewCRDFromAPICluster transforms an API cluster into the CRD representation
 func NewCRDFromAPICluster(apiCluster Cluster) (*synv1alpha1.Cluster, error) {
 	if !strings.HasPrefix(apiCluster.Id.String(), ClusterIDPrefix) {
@@ -309,10 +401,8 @@ func SyncCRDFromAPICluster(source ClusterProperties, target *synv1alpha1.Cluster
 		if target.Annotations == nil {
 			target.Annotations = map[string]string{}
 		}
-		for key, val := range *source.Annotations {
-			if str, ok := val.(string); ok {
-				target.Annotations[key] = str
-			}
+		if err := syncAnnotations(*source.Annotations, target.Annotations); err != nil {
+			return err
 		}
 	}
 
@@ -320,6 +410,12 @@ func SyncCRDFromAPICluster(source ClusterProperties, target *synv1alpha1.Cluster
 		target.Spec.DisplayName = *source.DisplayName
 	}
 
+	if source.Aliases != nil {
+		if err := SetClusterAliases(target, *source.Aliases); err != nil {
+			return err
+		}
+	}
+
 	if source.GitRepo != nil {
 		if source.GitRepo.Url != nil {
 			target.Spec.GitRepoURL = *source.GitRepo.Url
@@ -361,10 +457,8 @@ func SyncCRDFromAPICluster(source ClusterProperties, target *synv1alpha1.Cluster
 			target.Spec.Facts = synv1alpha1.Facts{}
 		}
 
-		for key, value := range *source.Facts {
-			if valueStr, ok := value.(string); ok {
-				target.Spec.Facts[key] = valueStr
-			}
+		if err := syncFacts(*source.Facts, target.Spec.Facts); err != nil {
+			return err
 		}
 	}
 
@@ -383,7 +477,7 @@ func SyncCRDFromAPICluster(source ClusterProperties, target *synv1alpha1.Cluster
 	}
 
 	if source.CompileMeta != nil {
-		clcm, err := apiCompileMetaToCRDCompileMeta(source.CompileMeta)
+		clcm, err := APICompileMetaToCRDCompileMeta(source.CompileMeta)
 		if err != nil {
 			return fmt.Errorf("failed to convert compile meta: %w", err)
 		}
@@ -393,6 +487,42 @@ func SyncCRDFromAPICluster(source ClusterProperties, target *synv1alpha1.Cluster
 	return nil
 }
 
+// ClusterAliases returns the sorted aliases of the cluster
+func ClusterAliases(cluster synv1alpha1.Cluster) []string {
+	aliases := []string{}
+	for key := range cluster.Labels {
+		if alias, ok := strings.CutPrefix(key, ClusterAliasLabelPrefix); ok {
+			aliases = append(aliases, alias)
+		}
+	}
+	sort.Strings(aliases)
+	return aliases
+}
+
+// SetClusterAliases replaces the aliases of the cluster
+func SetClusterAliases(cluster *synv1alpha1.Cluster, aliases []string) error {
+	for _, alias := range aliases {
+		if errs := validation.IsDNS1123Label(alias); len(errs) > 0 {
+			return fmt.Errorf("invalid alias '%s': %s", alias, strings.Join(errs, ", "))
+		}
+	}
+	for key := range cluster.Labels {
+		if strings.HasPrefix(key, ClusterAliasLabelPrefix) {
+			delete(cluster.Labels, key)
+		}
+	}
+	if len(aliases) == 0 {
+		return nil
+	}
+	if cluster.Labels == nil {
+		cluster.Labels = map[string]string{}
+	}
+	for _, alias := range aliases {
+		cluster.Labels[ClusterAliasLabelPrefix+alias] = ""
+	}
+	return nil
+}
+
 func newGitRepoTemplate(repo *GitRepo, name string) (*synv1alpha1.GitRepoTemplate, error) {
 	if repo == nil {
 		// No git info was specified
@@ -429,10 +559,10 @@ func newGitRepoTemplate(repo *GitRepo, name string) (*synv1alpha1.GitRepoTemplat
 	return nil, nil
 }
 
-// crdCompileMetaToAPICompileMeta converts a CRD compile meta to an API compile meta.
+// CRDCompileMetaToAPICompileMeta converts a CRD compile meta to an API compile meta.
 // Uses json marshalling to convert the structs since their codegen representations are very different.
 // Errors only if the marshalling fails.
-func crdCompileMetaToAPICompileMeta(crdCompileMeta synv1alpha1.CompileMeta) (*ClusterCompileMeta, error) {
+func CRDCompileMetaToAPICompileMeta(crdCompileMeta synv1alpha1.CompileMeta) (*ClusterCompileMeta, error) {
 	j, err := json.Marshal(crdCompileMeta)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal compile meta for conversion: %w", err)
@@ -445,10 +575,10 @@ func crdCompileMetaToAPICompileMeta(crdCompileMeta synv1alpha1.CompileMeta) (*Cl
 	return &apiCompileMeta, nil
 }
 
-// apiCompileMetaToCRDCompileMeta converts an API compile meta to a CRD compile meta.
+// APICompileMetaToCRDCompileMeta converts an API compile meta to a CRD compile meta.
 // Uses json marshalling to convert the structs since their codegen representations are very different.
 // Errors only if the marshalling fails.
-func apiCompileMetaToCRDCompileMeta(apiCompileMeta *ClusterCompileMeta) (synv1alpha1.CompileMeta, error) {
+func APICompileMetaToCRDCompileMeta(apiCompileMeta *ClusterCompileMeta) (synv1alpha1.CompileMeta, error) {
 	if apiCompileMeta == nil {
 		return synv1alpha1.CompileMeta{}, nil
 	}
@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/projectsyn/lieutenant-operator/api/v1alpha1"
 	"github.com/stretchr/testify/assert"
@@ -299,16 +300,101 @@ func TestFactEncoding(t *testing.T) {
 	assert.JSONEq(t, string(exp), string(act))
 }
 
+func TestSyncCRDFromAPIClusterTypedFacts(t *testing.T) {
+	cluster := &v1alpha1.Cluster{
+		Spec: v1alpha1.ClusterSpec{
+			Facts: v1alpha1.Facts{"cloud": "cloudscale"},
+		},
+	}
+	facts := ClusterFacts{
+		"nodes":   3.0,
+		"ratio":   0.5,
+		"enabled": true,
+		"zones":   []any{"a", "b"},
+		"region":  map[string]any{"name": "lpg"},
+		"cloud":   "exoscale",
+		// Strings which look like other values keep their type
+		"version": "3",
+		"flag":    "true",
+		"quoted":  `"bar"`,
+	}
+	require.NoError(t, SyncCRDFromAPICluster(ClusterProperties{Facts: &facts}, cluster))
+	assert.Equal(t, v1alpha1.Facts{
+		"nodes":   "3",
+		"ratio":   "0.5",
+		"enabled": "true",
+		"zones":   `["a","b"]`,
+		"region":  `{"name":"lpg"}`,
+		"cloud":   "exoscale",
+		"version": `"3"`,
+		"flag":    `"true"`,
+		"quoted":  `"\"bar\""`,
+	}, cluster.Spec.Facts)
+
+	apiCluster, err := NewAPIClusterFromCRD(*cluster)
+	require.NoError(t, err)
+	require.NotNil(t, apiCluster.Facts)
+	assert.Equal(t, facts, *apiCluster.Facts, "Facts must be returned with their original types")
+}
+
+func TestSyncCRDFromAPIClusterRejectedValues(t *testing.T) {
+	cluster := &v1alpha1.Cluster{
+		Spec: v1alpha1.ClusterSpec{
+			Facts: v1alpha1.Facts{"removed": "fact"},
+		},
+	}
+	err := SyncCRDFromAPICluster(ClusterProperties{
+		Facts: &ClusterFacts{"removed": nil, "other": nil, "nodes": 3},
+	}, cluster)
+	assert.EqualError(t, err, "values of facts 'other', 'removed' can't be null")
+	assert.Equal(t, "fact", cluster.Spec.Facts["removed"])
+
+	err = SyncCRDFromAPICluster(ClusterProperties{
+		Annotations: &Annotations{"replicas": 2, "monitoring": map[string]any{"sla": "247"}, "valid": "yes"},
+	}, cluster)
+	assert.EqualError(t, err, "values of annotations 'monitoring', 'replicas' must be strings")
+}
+
+func TestSyncCRDFromAPITenantNonStringValues(t *testing.T) {
+	tenant := &v1alpha1.Tenant{}
+	err := SyncCRDFromAPITenant(TenantProperties{
+		Annotations: &Annotations{"sla": 247, "managed": false, "contacts": []any{"ops"}, "removed": nil},
+	}, tenant)
+	assert.EqualError(t, err, "values of annotations 'contacts', 'managed', 'removed', 'sla' must be strings")
+
+	require.NoError(t, SyncCRDFromAPITenant(TenantProperties{
+		Annotations: &Annotations{"sla": "247"},
+	}, tenant))
+	assert.Equal(t, map[string]string{"sla": "247"}, tenant.Annotations)
+}
+
 func TestDecodeFact(t *testing.T) {
 	facts := []string{`"foo"`, "foo", `{"name": "bar"}`, "[1,2,3]"}
 	decoded := []interface{}{}
 
 	for _, f := range facts {
 		require.NotPanics(t, func() {
-			d := unmarshalFact(f)
+			d := UnmarshalFact(f)
 			decoded = append(decoded, d)
 		})
 	}
 	_, err := json.Marshal(decoded)
 	assert.NoError(t, err)
 }
+
+func TestStewardInstallationFromAnnotations(t *testing.T) {
+	assert.Nil(t, stewardInstallationFromAnnotations(map[string]string{"some": "annotation"}))
+
+	installation := stewardInstallationFromAnnotations(map[string]string{
+		StewardInstalledAtAnnotation:        "2024-04-14T21:05:56Z",
+		StewardInstalledFromAnnotation:      "192.0.2.42",
+		StewardInstalledUserAgentAnnotation: "curl/8.5.0",
+		StewardInstalledImageAnnotation:     "docker.io/projectsyn/steward:v0.2.2",
+	})
+	require.NotNil(t, installation)
+	require.NotNil(t, installation.InstalledAt)
+	assert.Equal(t, time.Date(2024, time.April, 14, 21, 5, 56, 0, time.UTC), installation.InstalledAt.UTC())
+	assert.Equal(t, "192.0.2.42", pointer.GetString(installation.ClientIP))
+	assert.Equal(t, "curl/8.5.0", pointer.GetString(installation.UserAgent))
+	assert.Equal(t, "docker.io/projectsyn/steward:v0.2.2", pointer.GetString(installation.Image))
+}
@@ -0,0 +1,332 @@
+package inventory
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	// DefaultBoltPath is the database file used if no path is configured
+	DefaultBoltPath = "inventory.db"
+
+	boltOpenTimeout = 10 * time.Second
+)
+
+var (
+	clustersBucket    = []byte("clusters")
+	factChangesBucket = []byte("factChanges")
+	compileMetaBucket = []byte("compileMeta")
+)
+
+// BoltStore stores the inventory in an embedded bbolt database.
+// Each cluster has its own bucket with the entries keyed by their timestamp.
+// Fact changes and compilation metadata are stored the same way in separate buckets.
+type BoltStore struct {
+	db *bolt.DB
+}
+
+var _ Store = &BoltStore{}
+
+// NewBoltStore opens or creates the database at the given path
+func NewBoltStore(path string) (*BoltStore, error) {
+	if path == "" {
+		path = DefaultBoltPath
+	}
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: boltOpenTimeout})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open inventory database '%s': %w", path, err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, b := range [][]byte{clustersBucket, factChangesBucket, compileMetaBucket} {
+			if _, err := tx.CreateBucketIfNotExists(b); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize inventory database '%s': %w", path, err)
+	}
+	return &BoltStore{db: db}, nil
+}
+
+// Update stores the inventory of the cluster at the given time
+func (s *BoltStore) Update(_ context.Context, entry Entry) error {
+	value, err := json.Marshal(entry.Inventory)
+	if err != nil {
+		return fmt.Errorf("failed to marshal inventory: %w", err)
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.Bucket(clustersBucket).CreateBucketIfNotExists([]byte(entry.Cluster))
+		if err != nil {
+			return err
+		}
+		return b.Put(timeKey(entry.Timestamp), value)
+	})
+}
+
+// Query calls fn with each entry matching the query, ordered by cluster and time
+func (s *BoltStore) Query(_ context.Context, query Query, fn func(Entry) error) error {
+	return s.db.View(func(tx *bolt.Tx) error {
+		clusters := tx.Bucket(clustersBucket)
+		if query.Cluster != "" {
+			b := clusters.Bucket([]byte(query.Cluster))
+			if b == nil {
+				return nil
+			}
+			return queryBucket(query.Cluster, b, query, fn)
+		}
+		return clusters.ForEachBucket(func(name []byte) error {
+			return queryBucket(string(name), clusters.Bucket(name), query, fn)
+		})
+	})
+}
+
+// boltFactChange is the stored representation of a fact change, the cluster and timestamp are part of the bucket and key
+type boltFactChange struct {
+	Key    string  `json:"key"`
+	Old    *string `json:"old,omitempty"`
+	New    *string `json:"new,omitempty"`
+	Writer string  `json:"writer,omitempty"`
+}
+
+// RecordFactChanges stores the changes
+func (s *BoltStore) RecordFactChanges(_ context.Context, changes []FactChange) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		for _, c := range changes {
+			b, err := tx.Bucket(factChangesBucket).CreateBucketIfNotExists([]byte(c.Cluster))
+			if err != nil {
+				return err
+			}
+			value, err := json.Marshal(boltFactChange{Key: c.Key, Old: c.Old, New: c.New, Writer: c.Writer})
+			if err != nil {
+				return fmt.Errorf("failed to marshal fact change: %w", err)
+			}
+			// The sequence keeps changes with the same timestamp apart
+			seq, err := b.NextSequence()
+			if err != nil {
+				return err
+			}
+			k := binary.BigEndian.AppendUint64(timeKey(c.Timestamp), seq)
+			if err := b.Put(k, value); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// FactChanges returns the changes of the cluster matching the query, ordered by time
+func (s *BoltStore) FactChanges(_ context.Context, query FactChangeQuery) ([]FactChange, error) {
+	changes := []FactChange{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(factChangesBucket).Bucket([]byte(query.Cluster))
+		if b == nil {
+			return nil
+		}
+		c := b.Cursor()
+		k, v := c.First()
+		if !query.From.IsZero() {
+			k, v = c.Seek(timeKey(query.From))
+		}
+		for ; k != nil; k, v = c.Next() {
+			ts := keyTime(k[:8])
+			if !query.To.IsZero() && ts.After(query.To) {
+				break
+			}
+			stored := boltFactChange{}
+			if err := json.Unmarshal(v, &stored); err != nil {
+				return fmt.Errorf("failed to unmarshal fact change of cluster '%s': %w", query.Cluster, err)
+			}
+			change := FactChange{
+				Cluster:   query.Cluster,
+				Key:       stored.Key,
+				Old:       stored.Old,
+				New:       stored.New,
+				Timestamp: ts,
+				Writer:    stored.Writer,
+			}
+			if query.matches(change) {
+				changes = append(changes, change)
+			}
+		}
+		return nil
+	})
+	return changes, err
+}
+
+// RecordCompileMeta stores the report and drops the oldest reports of the cluster exceeding the limit.
+// A limit of 0 keeps all reports.
+func (s *BoltStore) RecordCompileMeta(_ context.Context, report CompileMetaReport, limit int) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.Bucket(compileMetaBucket).CreateBucketIfNotExists([]byte(report.Cluster))
+		if err != nil {
+			return err
+		}
+		seq, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+		k := binary.BigEndian.AppendUint64(timeKey(report.LastCompile), seq)
+		if err := b.Put(k, []byte(report.Meta)); err != nil {
+			return err
+		}
+		if limit <= 0 {
+			return nil
+		}
+		// Keys are collected first, deleting while iterating moves the cursor
+		expired := [][]byte{}
+		c := b.Cursor()
+		kept := 0
+		for k, _ := c.Last(); k != nil; k, _ = c.Prev() {
+			if kept < limit {
+				kept++
+				continue
+			}
+			expired = append(expired, bytes.Clone(k))
+		}
+		for _, k := range expired {
+			if err := b.Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// CompileMetaReports returns at most limit of the most recent reports of the cluster, ordered by time of compilation.
+// A limit of 0 returns all reports.
+func (s *BoltStore) CompileMetaReports(_ context.Context, cluster string, limit int) ([]CompileMetaReport, error) {
+	reports := []CompileMetaReport{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(compileMetaBucket).Bucket([]byte(cluster))
+		if b == nil {
+			return nil
+		}
+		c := b.Cursor()
+		for k, v := c.Last(); k != nil && (limit <= 0 || len(reports) < limit); k, v = c.Prev() {
+			reports = append(reports, CompileMetaReport{
+				Cluster:     cluster,
+				LastCompile: keyTime(k[:8]),
+				Meta:        string(v),
+			})
+		}
+		return nil
+	})
+	slices.Reverse(reports)
+	return reports, err
+}
+
+// Prune deletes the inventory, fact changes and compilation metadata recorded before the given time.
+// The buckets of clusters without any remaining data are removed.
+func (s *BoltStore) Prune(_ context.Context, before time.Time) error {
+	cutoff := timeKey(before)
+	return s.db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{clustersBucket, factChangesBucket, compileMetaBucket} {
+			parent := tx.Bucket(name)
+			// Buckets are collected first, they can't be changed while iterating
+			clusters := [][]byte{}
+			err := parent.ForEachBucket(func(cluster []byte) error {
+				clusters = append(clusters, bytes.Clone(cluster))
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			for _, cluster := range clusters {
+				// All keys start with the timestamp, deleting the first key moves the cursor to the next one
+				c := parent.Bucket(cluster).Cursor()
+				k, _ := c.First()
+				for ; k != nil && bytes.Compare(k[:8], cutoff) < 0; k, _ = c.First() {
+					if err := c.Delete(); err != nil {
+						return err
+					}
+				}
+				if k == nil {
+					if err := parent.DeleteBucket(cluster); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// Close closes the database
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
+
+func queryBucket(cluster string, b *bolt.Bucket, query Query, fn func(Entry) error) error {
+	c := b.Cursor()
+	if query.Latest {
+		k, v := c.Last()
+		if !query.To.IsZero() {
+			// Seek positions the cursor on the first key after the end of the range
+			k, v = c.Seek(timeKey(query.To.Add(time.Nanosecond)))
+			if k == nil {
+				k, v = c.Last()
+			} else {
+				k, v = c.Prev()
+			}
+		}
+		if k == nil || !query.contains(keyTime(k)) {
+			return nil
+		}
+		entry, err := newEntry(cluster, k, v)
+		if err != nil {
+			return err
+		}
+		return fn(entry)
+	}
+
+	k, v := c.First()
+	if !query.From.IsZero() {
+		k, v = c.Seek(timeKey(query.From))
+	}
+	var end []byte
+	if !query.To.IsZero() {
+		end = timeKey(query.To)
+	}
+	for ; k != nil && (end == nil || bytes.Compare(k, end) <= 0); k, v = c.Next() {
+		entry, err := newEntry(cluster, k, v)
+		if err != nil {
+			return err
+		}
+		if err := fn(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newEntry(cluster string, k, v []byte) (Entry, error) {
+	entry := Entry{
+		Cluster:   cluster,
+		Timestamp: keyTime(k),
+	}
+	if err := json.Unmarshal(v, &entry.Inventory); err != nil {
+		return Entry{}, fmt.Errorf("failed to unmarshal inventory of cluster '%s': %w", cluster, err)
+	}
+	return entry, nil
+}
+
+// timeKey encodes the timestamp so the keys sort chronologically
+func timeKey(t time.Time) []byte {
+	k := make([]byte, 8)
+	binary.BigEndian.PutUint64(k, uint64(t.UnixNano()))
+	return k
+}
+
+func keyTime(k []byte) time.Time {
+	return time.Unix(0, int64(binary.BigEndian.Uint64(k))).UTC()
+}
@@ -0,0 +1,301 @@
+package inventory
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	t0 = time.Date(2024, time.April, 14, 12, 0, 0, 0, time.UTC)
+	t1 = t0.Add(time.Hour)
+	t2 = t0.Add(2 * time.Hour)
+)
+
+func newTestBoltStore(t *testing.T) *BoltStore {
+	store, err := NewBoltStore(filepath.Join(t.TempDir(), "inventory.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+	for _, entry := range []Entry{
+		{Cluster: "c-b", Timestamp: t1, Inventory: map[string]any{"version": "b1"}},
+		{Cluster: "c-a", Timestamp: t2, Inventory: map[string]any{"version": "a2"}},
+		{Cluster: "c-a", Timestamp: t0, Inventory: map[string]any{"version": "a0"}},
+		{Cluster: "c-a", Timestamp: t1, Inventory: map[string]any{"version": "a1"}},
+	} {
+		require.NoError(t, store.Update(t.Context(), entry))
+	}
+	return store
+}
+
+// queryAll collects the entries of the query
+func queryAll(t *testing.T, store Store, query Query) ([]Entry, error) {
+	t.Helper()
+	entries := []Entry{}
+	err := store.Query(t.Context(), query, func(e Entry) error {
+		entries = append(entries, e)
+		return nil
+	})
+	return entries, err
+}
+
+func versions(entries []Entry) []string {
+	v := make([]string, 0, len(entries))
+	for _, e := range entries {
+		v = append(v, e.Inventory["version"].(string))
+	}
+	return v
+}
+
+func TestBoltStoreQuery(t *testing.T) {
+	store := newTestBoltStore(t)
+
+	tcs := map[string]struct {
+		query    Query
+		expected []string
+	}{
+		"all": {
+			expected: []string{"a0", "a1", "a2", "b1"},
+		},
+		"cluster": {
+			query:    Query{Cluster: "c-b"},
+			expected: []string{"b1"},
+		},
+		"unknown cluster": {
+			query:    Query{Cluster: "c-unknown"},
+			expected: []string{},
+		},
+		"from": {
+			query:    Query{From: t1},
+			expected: []string{"a1", "a2", "b1"},
+		},
+		"to": {
+			query:    Query{To: t1},
+			expected: []string{"a0", "a1", "b1"},
+		},
+		"range": {
+			query:    Query{Cluster: "c-a", From: t1, To: t1},
+			expected: []string{"a1"},
+		},
+		"latest": {
+			query:    Query{Latest: true},
+			expected: []string{"a2", "b1"},
+		},
+		"latest before": {
+			query:    Query{Latest: true, To: t1.Add(-time.Minute)},
+			expected: []string{"a0"},
+		},
+		"latest in range": {
+			query:    Query{Latest: true, From: t1, To: t1},
+			expected: []string{"a1", "b1"},
+		},
+		"latest after range": {
+			query:    Query{Latest: true, From: t2.Add(time.Minute)},
+			expected: []string{},
+		},
+	}
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			entries, err := queryAll(t, store, tc.query)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, versions(entries))
+		})
+	}
+}
+
+func TestBoltStoreEntry(t *testing.T) {
+	store := newTestBoltStore(t)
+
+	entries, err := queryAll(t, store, Query{Cluster: "c-b"})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, Entry{
+		Cluster:   "c-b",
+		Timestamp: t1,
+		Inventory: map[string]any{"version": "b1"},
+	}, entries[0])
+}
+
+func TestBoltStorePersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "inventory.db")
+	store, err := NewBoltStore(path)
+	require.NoError(t, err)
+	require.NoError(t, store.Update(t.Context(), Entry{Cluster: "c-a", Timestamp: t0, Inventory: map[string]any{"version": "a0"}}))
+	require.NoError(t, store.Close())
+
+	store, err = NewBoltStore(path)
+	require.NoError(t, err)
+	defer store.Close()
+	entries, err := queryAll(t, store, Query{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a0"}, versions(entries))
+}
+
+func TestNewStore(t *testing.T) {
+	store, err := NewStore(Config{Path: filepath.Join(t.TempDir(), "inventory.db")})
+	require.NoError(t, err)
+	assert.Nil(t, store, "No store must be opened without a backend")
+
+	store, err = NewStore(Config{Backend: BackendBolt, Path: filepath.Join(t.TempDir(), "inventory.db")})
+	require.NoError(t, err)
+	assert.IsType(t, &BoltStore{}, store)
+	require.NoError(t, store.Close())
+
+	store, err = NewStore(Config{Backend: BackendInfluxDB, URL: "http://influxdb:8086", Database: "lieutenant"})
+	require.NoError(t, err)
+	assert.IsType(t, &InfluxDBStore{}, store)
+
+	_, err = NewStore(Config{Backend: BackendInfluxDB})
+	assert.Error(t, err)
+	_, err = NewStore(Config{Backend: "mongodb"})
+	assert.Error(t, err)
+}
+
+func TestBoltStoreFactChanges(t *testing.T) {
+	store := newTestBoltStore(t)
+	require.NoError(t, store.RecordFactChanges(t.Context(), []FactChange{
+		{Cluster: "c-a", Key: "version", New: pointer.ToString(`"1.28"`), Timestamp: t0, Writer: "steward"},
+		{Cluster: "c-a", Key: "nodes", New: pointer.ToString("3"), Timestamp: t0, Writer: "steward"},
+		{Cluster: "c-b", Key: "version", New: pointer.ToString(`"1.29"`), Timestamp: t1},
+	}))
+	require.NoError(t, store.RecordFactChanges(t.Context(), []FactChange{
+		{Cluster: "c-a", Key: "version", Old: pointer.ToString(`"1.28"`), New: pointer.ToString(`"1.29"`), Timestamp: t1, Writer: "steward"},
+		{Cluster: "c-a", Key: "nodes", Old: pointer.ToString("3"), Timestamp: t2, Writer: "admin"},
+	}))
+
+	keys := func(changes []FactChange) []string {
+		k := make([]string, 0, len(changes))
+		for _, c := range changes {
+			k = append(k, c.Key+"@"+c.Timestamp.Format("15"))
+		}
+		return k
+	}
+
+	changes, err := store.FactChanges(t.Context(), FactChangeQuery{Cluster: "c-a"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"version@12", "nodes@12", "version@13", "nodes@14"}, keys(changes))
+	assert.Equal(t, FactChange{Cluster: "c-a", Key: "nodes", Old: pointer.ToString("3"), Timestamp: t2, Writer: "admin"}, changes[3])
+
+	changes, err = store.FactChanges(t.Context(), FactChangeQuery{Cluster: "c-a", Key: "version"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"version@12", "version@13"}, keys(changes))
+
+	changes, err = store.FactChanges(t.Context(), FactChangeQuery{Cluster: "c-a", From: t1, To: t1})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"version@13"}, keys(changes))
+
+	changes, err = store.FactChanges(t.Context(), FactChangeQuery{Cluster: "c-unknown"})
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestBoltStoreCompileMeta(t *testing.T) {
+	store := newTestBoltStore(t)
+	for _, report := range []CompileMetaReport{
+		{Cluster: "c-a", LastCompile: t1, Meta: `{"v":1}`},
+		{Cluster: "c-a", LastCompile: t0, Meta: `{"v":0}`},
+		{Cluster: "c-b", LastCompile: t0, Meta: `{"v":0}`},
+		{Cluster: "c-a", LastCompile: t2, Meta: `{"v":2}`},
+	} {
+		require.NoError(t, store.RecordCompileMeta(t.Context(), report, 0))
+	}
+
+	reports, err := store.CompileMetaReports(t.Context(), "c-a", 0)
+	require.NoError(t, err)
+	assert.Equal(t, []CompileMetaReport{
+		{Cluster: "c-a", LastCompile: t0, Meta: `{"v":0}`},
+		{Cluster: "c-a", LastCompile: t1, Meta: `{"v":1}`},
+		{Cluster: "c-a", LastCompile: t2, Meta: `{"v":2}`},
+	}, reports)
+
+	reports, err = store.CompileMetaReports(t.Context(), "c-a", 2)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{t1, t2}, compileTimes(reports))
+
+	reports, err = store.CompileMetaReports(t.Context(), "c-unknown", 0)
+	require.NoError(t, err)
+	assert.Empty(t, reports)
+}
+
+func TestBoltStoreCompileMetaLimit(t *testing.T) {
+	store := newTestBoltStore(t)
+	for _, ts := range []time.Time{t0, t2, t1, t2} {
+		require.NoError(t, store.RecordCompileMeta(t.Context(), CompileMetaReport{Cluster: "c-a", LastCompile: ts}, 3))
+	}
+
+	reports, err := store.CompileMetaReports(t.Context(), "c-a", 0)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{t1, t2, t2}, compileTimes(reports))
+}
+
+func compileTimes(reports []CompileMetaReport) []time.Time {
+	ts := make([]time.Time, 0, len(reports))
+	for _, r := range reports {
+		ts = append(ts, r.LastCompile)
+	}
+	return ts
+}
+
+func TestBoltStorePrune(t *testing.T) {
+	store := newTestBoltStore(t)
+	require.NoError(t, store.RecordFactChanges(t.Context(), []FactChange{
+		{Cluster: "c-a", Key: "version", New: pointer.ToString(`"1.28"`), Timestamp: t0},
+		{Cluster: "c-b", Key: "version", New: pointer.ToString(`"1.29"`), Timestamp: t0},
+		{Cluster: "c-b", Key: "version", Old: pointer.ToString(`"1.29"`), New: pointer.ToString(`"1.30"`), Timestamp: t2},
+	}))
+	for _, ts := range []time.Time{t0, t2} {
+		require.NoError(t, store.RecordCompileMeta(t.Context(), CompileMetaReport{Cluster: "c-a", LastCompile: ts}, 0))
+	}
+
+	require.NoError(t, store.Prune(t.Context(), t1))
+
+	entries, err := queryAll(t, store, Query{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a1", "a2", "b1"}, versions(entries))
+	changes, err := store.FactChanges(t.Context(), FactChangeQuery{Cluster: "c-a"})
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+	changes, err = store.FactChanges(t.Context(), FactChangeQuery{Cluster: "c-b"})
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, t2, changes[0].Timestamp)
+	reports, err := store.CompileMetaReports(t.Context(), "c-a", 0)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{t2}, compileTimes(reports))
+
+	// Clusters without remaining data are removed
+	require.NoError(t, store.Prune(t.Context(), t2.Add(time.Minute)))
+	entries, err = queryAll(t, store, Query{})
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+	require.NoError(t, store.db.View(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{clustersBucket, factChangesBucket, compileMetaBucket} {
+			k, _ := tx.Bucket(name).Cursor().First()
+			assert.Nil(t, k, "bucket '%s' must be empty", name)
+		}
+		return nil
+	}))
+}
+
+func TestParseRetention(t *testing.T) {
+	retention, err := ParseRetention("")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultRetention, retention)
+
+	retention, err = ParseRetention("720h")
+	require.NoError(t, err)
+	assert.Equal(t, 30*24*time.Hour, retention)
+
+	retention, err = ParseRetention("0")
+	require.NoError(t, err)
+	assert.Zero(t, retention)
+
+	for _, raw := range []string{"-1h", "month"} {
+		_, err = ParseRetention(raw)
+		assert.ErrorContains(t, err, raw)
+	}
+}
@@ -0,0 +1,24 @@
+package inventory
+
+import (
+	"context"
+	"time"
+)
+
+// CompileMetaReport is the compilation metadata reported for a cluster
+type CompileMetaReport struct {
+	Cluster string
+	// LastCompile is the time of the reported compilation
+	LastCompile time.Time
+	// Meta is the JSON encoded compilation metadata
+	Meta string
+}
+
+// CompileMetaHistory persists the compilation metadata reported for clusters
+type CompileMetaHistory interface {
+	// RecordCompileMeta stores the report.
+	// Backends which support it drop the oldest reports of the cluster exceeding the limit.
+	RecordCompileMeta(ctx context.Context, report CompileMetaReport, limit int) error
+	// CompileMetaReports returns at most limit of the most recent reports of the cluster, ordered by time of compilation
+	CompileMetaReports(ctx context.Context, cluster string, limit int) ([]CompileMetaReport, error)
+}
@@ -0,0 +1,45 @@
+package inventory
+
+import (
+	"context"
+	"time"
+)
+
+// FactChange records the change of a dynamic fact of a cluster
+type FactChange struct {
+	Cluster string
+	Key     string
+	// Old is the JSON encoded value before the change, nil if the fact was added
+	Old *string
+	// New is the JSON encoded value after the change, nil if the fact was removed
+	New       *string
+	Timestamp time.Time
+	// Writer is the user who changed the fact
+	Writer string
+}
+
+// FactChangeQuery selects fact changes of a cluster.
+// Zero values don't restrict the result.
+type FactChangeQuery struct {
+	Cluster string
+	Key     string
+	// From is the inclusive start of the time range
+	From time.Time
+	// To is the inclusive end of the time range
+	To time.Time
+}
+
+// FactHistory persists the changes of dynamic facts
+type FactHistory interface {
+	// RecordFactChanges stores the changes
+	RecordFactChanges(ctx context.Context, changes []FactChange) error
+	// FactChanges returns the changes of the cluster matching the query, ordered by time
+	FactChanges(ctx context.Context, query FactChangeQuery) ([]FactChange, error)
+}
+
+func (q FactChangeQuery) matches(c FactChange) bool {
+	if q.Key != "" && c.Key != q.Key {
+		return false
+	}
+	return Query{From: q.From, To: q.To}.contains(c.Timestamp)
+}
@@ -0,0 +1,406 @@
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/AlekSi/pointer"
+)
+
+const (
+	influxMeasurement = "inventory"
+	influxClusterTag  = "cluster"
+	influxField       = "inventory"
+
+	influxFactChangeMeasurement  = "fact_change"
+	influxCompileMetaMeasurement = "compile_meta"
+	influxCompileMetaField       = "meta"
+)
+
+// InfluxDBStore stores the inventory in an external InfluxDB using the v1 HTTP API.
+// The inventory is stored JSON encoded in a single field, tagged with the cluster.
+type InfluxDBStore struct {
+	url      *url.URL
+	database string
+	token    string
+	client   *http.Client
+}
+
+var _ Store = &InfluxDBStore{}
+
+// NewInfluxDBStore creates a store writing to the database of the InfluxDB at the URL
+func NewInfluxDBStore(rawURL, database, token string) (*InfluxDBStore, error) {
+	if rawURL == "" || database == "" {
+		return nil, fmt.Errorf("URL and database of the InfluxDB are required")
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid InfluxDB URL '%s': %w", rawURL, err)
+	}
+	return &InfluxDBStore{
+		url:      u,
+		database: database,
+		token:    token,
+		client:   &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// Update stores the inventory of the cluster at the given time
+func (s *InfluxDBStore) Update(ctx context.Context, entry Entry) error {
+	value, err := json.Marshal(entry.Inventory)
+	if err != nil {
+		return fmt.Errorf("failed to marshal inventory: %w", err)
+	}
+	line := fmt.Sprintf("%s,%s=%s %s=\"%s\" %d\n",
+		influxMeasurement,
+		influxClusterTag, escapeTag(entry.Cluster),
+		influxField, escapeFieldString(string(value)),
+		entry.Timestamp.UnixNano(),
+	)
+
+	return s.write(ctx, line)
+}
+
+// Query calls fn with each entry matching the query, ordered by cluster and time.
+// The response is read in chunks, InfluxDB returns the series of the clusters ordered by the cluster tag.
+func (s *InfluxDBStore) Query(ctx context.Context, query Query, fn func(Entry) error) error {
+	return s.queryEach(ctx, http.MethodGet, influxQL(query), func(ser influxSeries) error {
+		for _, row := range ser.Values {
+			entry, err := ser.entry(row)
+			if err != nil {
+				return err
+			}
+			if err := fn(entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// RecordFactChanges stores the changes
+func (s *InfluxDBStore) RecordFactChanges(ctx context.Context, changes []FactChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	lines := strings.Builder{}
+	for _, c := range changes {
+		fields := []string{fmt.Sprintf(`writer="%s"`, escapeFieldString(c.Writer))}
+		if c.Old != nil {
+			fields = append(fields, fmt.Sprintf(`old="%s"`, escapeFieldString(*c.Old)))
+		}
+		if c.New != nil {
+			fields = append(fields, fmt.Sprintf(`new="%s"`, escapeFieldString(*c.New)))
+		}
+		fmt.Fprintf(&lines, "%s,%s=%s,key=%s %s %d\n",
+			influxFactChangeMeasurement,
+			influxClusterTag, escapeTag(c.Cluster),
+			escapeTag(c.Key),
+			strings.Join(fields, ","),
+			c.Timestamp.UnixNano(),
+		)
+	}
+	return s.write(ctx, lines.String())
+}
+
+// FactChanges returns the changes of the cluster matching the query, ordered by time
+func (s *InfluxDBStore) FactChanges(ctx context.Context, query FactChangeQuery) ([]FactChange, error) {
+	conditions := []string{fmt.Sprintf(`"%s" = '%s'`, influxClusterTag, escapeString(query.Cluster))}
+	if query.Key != "" {
+		conditions = append(conditions, fmt.Sprintf(`"key" = '%s'`, escapeString(query.Key)))
+	}
+	conditions = append(conditions, timeConditions(query.From, query.To)...)
+	q := fmt.Sprintf(`SELECT "old", "new", "writer" FROM "%s" WHERE %s GROUP BY "key"`, influxFactChangeMeasurement, strings.Join(conditions, " AND "))
+
+	series, err := s.query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	changes := []FactChange{}
+	for _, ser := range series {
+		for _, row := range ser.Values {
+			change := FactChange{
+				Cluster: query.Cluster,
+				Key:     ser.Tags["key"],
+			}
+			for i, column := range ser.Columns {
+				if i >= len(row) || row[i] == nil {
+					continue
+				}
+				switch column {
+				case "time":
+					ts, err := parseInfluxTime(row[i])
+					if err != nil {
+						return nil, err
+					}
+					change.Timestamp = ts
+				case "old":
+					change.Old = pointer.ToString(fmt.Sprint(row[i]))
+				case "new":
+					change.New = pointer.ToString(fmt.Sprint(row[i]))
+				case "writer":
+					change.Writer = fmt.Sprint(row[i])
+				}
+			}
+			changes = append(changes, change)
+		}
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Timestamp.Before(changes[j].Timestamp)
+	})
+	return changes, nil
+}
+
+// RecordCompileMeta stores the report at the time of the compilation.
+// The limit is ignored, old reports are expected to be dropped by the retention policy of the database.
+func (s *InfluxDBStore) RecordCompileMeta(ctx context.Context, report CompileMetaReport, _ int) error {
+	line := fmt.Sprintf("%s,%s=%s %s=\"%s\" %d\n",
+		influxCompileMetaMeasurement,
+		influxClusterTag, escapeTag(report.Cluster),
+		influxCompileMetaField, escapeFieldString(report.Meta),
+		report.LastCompile.UnixNano(),
+	)
+	return s.write(ctx, line)
+}
+
+// CompileMetaReports returns at most limit of the most recent reports of the cluster, ordered by time of compilation.
+// A limit of 0 returns all reports.
+func (s *InfluxDBStore) CompileMetaReports(ctx context.Context, cluster string, limit int) ([]CompileMetaReport, error) {
+	q := fmt.Sprintf(`SELECT "%s" FROM "%s" WHERE "%s" = '%s' ORDER BY time DESC`,
+		influxCompileMetaField, influxCompileMetaMeasurement, influxClusterTag, escapeString(cluster))
+	if limit > 0 {
+		q += fmt.Sprintf(" LIMIT %d", limit)
+	}
+
+	series, err := s.query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	reports := []CompileMetaReport{}
+	for _, ser := range series {
+		for _, row := range ser.Values {
+			report := CompileMetaReport{Cluster: cluster}
+			for i, column := range ser.Columns {
+				if i >= len(row) || row[i] == nil {
+					continue
+				}
+				switch column {
+				case "time":
+					ts, err := parseInfluxTime(row[i])
+					if err != nil {
+						return nil, err
+					}
+					report.LastCompile = ts
+				case influxCompileMetaField:
+					report.Meta = fmt.Sprint(row[i])
+				}
+			}
+			reports = append(reports, report)
+		}
+	}
+	sort.SliceStable(reports, func(i, j int) bool {
+		return reports[i].LastCompile.Before(reports[j].LastCompile)
+	})
+	return reports, nil
+}
+
+// Prune deletes the inventory, fact changes and compilation metadata recorded before the given time
+func (s *InfluxDBStore) Prune(ctx context.Context, before time.Time) error {
+	statements := []string{}
+	for _, m := range []string{influxMeasurement, influxFactChangeMeasurement, influxCompileMetaMeasurement} {
+		statements = append(statements, fmt.Sprintf(`DELETE FROM "%s" WHERE time < %d`, m, before.UnixNano()))
+	}
+	return s.queryEach(ctx, http.MethodPost, strings.Join(statements, "; "), func(influxSeries) error { return nil })
+}
+
+// Close is a noop as the InfluxDB is accessed over HTTP
+func (s *InfluxDBStore) Close() error {
+	return nil
+}
+
+func (s *InfluxDBStore) write(ctx context.Context, lines string) error {
+	req, err := s.newRequest(ctx, http.MethodPost, "write", url.Values{"precision": {"ns"}}, strings.NewReader(lines))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to write to InfluxDB: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to write to InfluxDB: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+func (s *InfluxDBStore) query(ctx context.Context, q string) ([]influxSeries, error) {
+	series := []influxSeries{}
+	err := s.queryEach(ctx, http.MethodGet, q, func(ser influxSeries) error {
+		series = append(series, ser)
+		return nil
+	})
+	return series, err
+}
+
+// queryEach runs the query and calls fn with the series of each chunk of the response as they're read
+func (s *InfluxDBStore) queryEach(ctx context.Context, method, q string, fn func(influxSeries) error) error {
+	req, err := s.newRequest(ctx, method, "query", url.Values{"q": {q}, "epoch": {"ns"}, "chunked": {"true"}}, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to query InfluxDB: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to query InfluxDB: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	// Timestamps in nanoseconds don't fit into a float64
+	dec := json.NewDecoder(resp.Body)
+	dec.UseNumber()
+	for {
+		result := influxResponse{}
+		if err := dec.Decode(&result); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to decode InfluxDB response: %w", err)
+		}
+		for _, r := range result.Results {
+			if r.Error != "" {
+				return fmt.Errorf("failed to query InfluxDB: %s", r.Error)
+			}
+			for _, ser := range r.Series {
+				if err := fn(ser); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+func (s *InfluxDBStore) newRequest(ctx context.Context, method, path string, params url.Values, body io.Reader) (*http.Request, error) {
+	params.Set("db", s.database)
+	u := s.url.JoinPath(path)
+	u.RawQuery = params.Encode()
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if s.token != "" {
+		req.Header.Set("Authorization", "Token "+s.token)
+	}
+	return req, nil
+}
+
+// influxQL translates the query to InfluxQL
+func influxQL(query Query) string {
+	selection := fmt.Sprintf(`"%s"`, influxField)
+	if query.Latest {
+		selection = fmt.Sprintf(`LAST("%s") AS "%s"`, influxField, influxField)
+	}
+	conditions := []string{}
+	if query.Cluster != "" {
+		conditions = append(conditions, fmt.Sprintf(`"%s" = '%s'`, influxClusterTag, escapeString(query.Cluster)))
+	}
+	conditions = append(conditions, timeConditions(query.From, query.To)...)
+	q := fmt.Sprintf(`SELECT %s FROM "%s"`, selection, influxMeasurement)
+	if len(conditions) > 0 {
+		q += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	return q + fmt.Sprintf(` GROUP BY "%s"`, influxClusterTag)
+}
+
+func timeConditions(from, to time.Time) []string {
+	conditions := []string{}
+	if !from.IsZero() {
+		conditions = append(conditions, fmt.Sprintf("time >= %d", from.UnixNano()))
+	}
+	if !to.IsZero() {
+		conditions = append(conditions, fmt.Sprintf("time <= %d", to.UnixNano()))
+	}
+	return conditions
+}
+
+type influxResponse struct {
+	Results []struct {
+		Series []influxSeries `json:"series"`
+		Error  string         `json:"error"`
+	} `json:"results"`
+}
+
+type influxSeries struct {
+	Tags    map[string]string `json:"tags"`
+	Columns []string          `json:"columns"`
+	Values  [][]any           `json:"values"`
+}
+
+func (s influxSeries) entry(row []any) (Entry, error) {
+	entry := Entry{
+		Cluster: s.Tags[influxClusterTag],
+	}
+	for i, column := range s.Columns {
+		if i >= len(row) {
+			break
+		}
+		switch column {
+		case "time":
+			ts, err := parseInfluxTime(row[i])
+			if err != nil {
+				return Entry{}, err
+			}
+			entry.Timestamp = ts
+		case influxField:
+			raw, ok := row[i].(string)
+			if !ok {
+				return Entry{}, fmt.Errorf("unexpected inventory '%v' in InfluxDB response", row[i])
+			}
+			if err := json.Unmarshal([]byte(raw), &entry.Inventory); err != nil {
+				return Entry{}, fmt.Errorf("failed to unmarshal inventory of cluster '%s': %w", entry.Cluster, err)
+			}
+		}
+	}
+	return entry, nil
+}
+
+func parseInfluxTime(v any) (time.Time, error) {
+	ts, ok := v.(json.Number)
+	if !ok {
+		return time.Time{}, fmt.Errorf("unexpected time '%v' in InfluxDB response", v)
+	}
+	ns, err := ts.Int64()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unexpected time '%v' in InfluxDB response: %w", v, err)
+	}
+	return time.Unix(0, ns).UTC(), nil
+}
+
+var (
+	tagEscaper         = strings.NewReplacer(",", `\,`, "=", `\=`, " ", `\ `)
+	fieldStringEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	stringEscaper      = strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+)
+
+func escapeTag(s string) string {
+	return tagEscaper.Replace(s)
+}
+
+func escapeFieldString(s string) string {
+	return fieldStringEscaper.Replace(s)
+}
+
+func escapeString(s string) string {
+	return stringEscaper.Replace(s)
+}
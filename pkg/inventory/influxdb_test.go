@@ -0,0 +1,181 @@
+package inventory
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInfluxDBStoreUpdate(t *testing.T) {
+	var request *http.Request
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request = r
+		raw, _ := io.ReadAll(r.Body)
+		body = string(raw)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	store, err := NewInfluxDBStore(server.URL, "lieutenant", "secret")
+	require.NoError(t, err)
+	require.NoError(t, store.Update(t.Context(), Entry{
+		Cluster:   "c-a b",
+		Timestamp: t0,
+		Inventory: map[string]any{"version": `"quoted"`},
+	}))
+
+	require.NotNil(t, request)
+	assert.Equal(t, "/write", request.URL.Path)
+	assert.Equal(t, "lieutenant", request.URL.Query().Get("db"))
+	assert.Equal(t, "ns", request.URL.Query().Get("precision"))
+	assert.Equal(t, "Token secret", request.Header.Get("Authorization"))
+	assert.Equal(t, `inventory,cluster=c-a\ b inventory="{\"version\":\"\\\"quoted\\\"\"}" 1713096000000000000`+"\n", body)
+}
+
+func TestInfluxDBStoreUpdateError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "database not found", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	store, err := NewInfluxDBStore(server.URL, "lieutenant", "")
+	require.NoError(t, err)
+	err = store.Update(t.Context(), Entry{Cluster: "c-a", Timestamp: t0})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "database not found")
+}
+
+func TestInfluxDBStoreQuery(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query().Get("q")
+		w.Header().Set("Content-Type", "application/json")
+		// Chunked responses contain a JSON document per chunk
+		_, _ = io.WriteString(w, `{"results":[{"statement_id":0,"series":[
+			{"name":"inventory","tags":{"cluster":"c-a"},"columns":["time","inventory"],"values":[[1713096000000000000,"{\"version\":\"a0\"}"]],"partial":true}
+		],"partial":true}]}
+		{"results":[{"statement_id":0,"series":[
+			{"name":"inventory","tags":{"cluster":"c-a"},"columns":["time","inventory"],"values":[[1713099600000000000,"{\"version\":\"a1\"}"]]},
+			{"name":"inventory","tags":{"cluster":"c-b"},"columns":["time","inventory"],"values":[[1713099600000000001,"{\"version\":\"b1\"}"]]}
+		]}]}`)
+	}))
+	defer server.Close()
+
+	store, err := NewInfluxDBStore(server.URL, "lieutenant", "")
+	require.NoError(t, err)
+	entries, err := queryAll(t, store, Query{Cluster: "c-'a", From: t0, To: t1})
+	require.NoError(t, err)
+	assert.Equal(t, `SELECT "inventory" FROM "inventory" WHERE "cluster" = 'c-\'a' AND time >= 1713096000000000000 AND time <= 1713099600000000000 GROUP BY "cluster"`, query)
+	assert.Equal(t, []string{"a0", "a1", "b1"}, versions(entries))
+	assert.Equal(t, t1.Add(1), entries[2].Timestamp)
+
+	_, err = queryAll(t, store, Query{Latest: true})
+	require.NoError(t, err)
+	assert.Equal(t, `SELECT LAST("inventory") AS "inventory" FROM "inventory" GROUP BY "cluster"`, query)
+}
+
+func TestInfluxDBStoreQueryError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"results":[{"statement_id":0,"error":"database not found: lieutenant"}]}`)
+	}))
+	defer server.Close()
+
+	store, err := NewInfluxDBStore(server.URL, "lieutenant", "")
+	require.NoError(t, err)
+	_, err = queryAll(t, store, Query{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "database not found")
+}
+
+func TestInfluxDBStoreFactChanges(t *testing.T) {
+	var body, query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/write" {
+			raw, _ := io.ReadAll(r.Body)
+			body = string(raw)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		query = r.URL.Query().Get("q")
+		_, _ = io.WriteString(w, `{"results":[{"statement_id":0,"series":[
+			{"name":"fact_change","tags":{"key":"version"},"columns":["time","old","new","writer"],"values":[[1713099600000000000,"\"1.28\"","\"1.29\"","steward"]]},
+			{"name":"fact_change","tags":{"key":"nodes"},"columns":["time","old","new","writer"],"values":[[1713096000000000000,null,"3",""]]}
+		]}]}`)
+	}))
+	defer server.Close()
+
+	store, err := NewInfluxDBStore(server.URL, "lieutenant", "")
+	require.NoError(t, err)
+	require.NoError(t, store.RecordFactChanges(t.Context(), []FactChange{
+		{Cluster: "c-a", Key: "version", Old: pointer.ToString(`"1.28"`), New: pointer.ToString(`"1.29"`), Timestamp: t1, Writer: "steward"},
+		{Cluster: "c-a", Key: "nodes", Old: pointer.ToString("3"), Timestamp: t2},
+	}))
+	assert.Equal(t, `fact_change,cluster=c-a,key=version writer="steward",old="\"1.28\"",new="\"1.29\"" 1713099600000000000`+"\n"+
+		`fact_change,cluster=c-a,key=nodes writer="",old="3" 1713103200000000000`+"\n", body)
+
+	changes, err := store.FactChanges(t.Context(), FactChangeQuery{Cluster: "c-a", From: t0})
+	require.NoError(t, err)
+	assert.Equal(t, `SELECT "old", "new", "writer" FROM "fact_change" WHERE "cluster" = 'c-a' AND time >= 1713096000000000000 GROUP BY "key"`, query)
+	assert.Equal(t, []FactChange{
+		{Cluster: "c-a", Key: "nodes", New: pointer.ToString("3"), Timestamp: t0},
+		{Cluster: "c-a", Key: "version", Old: pointer.ToString(`"1.28"`), New: pointer.ToString(`"1.29"`), Timestamp: t1, Writer: "steward"},
+	}, changes)
+}
+
+func TestInfluxDBStoreCompileMeta(t *testing.T) {
+	var body, query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/write" {
+			raw, _ := io.ReadAll(r.Body)
+			body = string(raw)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		query = r.URL.Query().Get("q")
+		_, _ = io.WriteString(w, `{"results":[{"statement_id":0,"series":[
+			{"name":"compile_meta","columns":["time","meta"],"values":[[1713099600000000000,"{\"v\":1}"],[1713096000000000000,"{\"v\":0}"]]}
+		]}]}`)
+	}))
+	defer server.Close()
+
+	store, err := NewInfluxDBStore(server.URL, "lieutenant", "")
+	require.NoError(t, err)
+	require.NoError(t, store.RecordCompileMeta(t.Context(), CompileMetaReport{Cluster: "c-a", LastCompile: t0, Meta: `{"v":0}`}, 10))
+	assert.Equal(t, `compile_meta,cluster=c-a meta="{\"v\":0}" 1713096000000000000`+"\n", body)
+
+	reports, err := store.CompileMetaReports(t.Context(), "c-a", 10)
+	require.NoError(t, err)
+	assert.Equal(t, `SELECT "meta" FROM "compile_meta" WHERE "cluster" = 'c-a' ORDER BY time DESC LIMIT 10`, query)
+	assert.Equal(t, []CompileMetaReport{
+		{Cluster: "c-a", LastCompile: t0, Meta: `{"v":0}`},
+		{Cluster: "c-a", LastCompile: t1, Meta: `{"v":1}`},
+	}, reports)
+
+	_, err = store.CompileMetaReports(t.Context(), "c-a", 0)
+	require.NoError(t, err)
+	assert.Equal(t, `SELECT "meta" FROM "compile_meta" WHERE "cluster" = 'c-a' ORDER BY time DESC`, query)
+}
+
+func TestInfluxDBStorePrune(t *testing.T) {
+	var request *http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request = r
+		_, _ = io.WriteString(w, `{"results":[{"statement_id":0},{"statement_id":1},{"statement_id":2}]}`)
+	}))
+	defer server.Close()
+
+	store, err := NewInfluxDBStore(server.URL, "lieutenant", "")
+	require.NoError(t, err)
+	require.NoError(t, store.Prune(t.Context(), t0))
+	require.NotNil(t, request)
+	assert.Equal(t, http.MethodPost, request.Method)
+	assert.Equal(t, `DELETE FROM "inventory" WHERE time < 1713096000000000000; `+
+		`DELETE FROM "fact_change" WHERE time < 1713096000000000000; `+
+		`DELETE FROM "compile_meta" WHERE time < 1713096000000000000`, request.URL.Query().Get("q"))
+}
@@ -0,0 +1,294 @@
+package inventory
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Aggregation combines the values of a field within a group
+type Aggregation string
+
+const (
+	// AggregationNone returns a row per entry
+	AggregationNone Aggregation = "none"
+	// AggregationLast returns the most recent value of each field
+	AggregationLast Aggregation = "last"
+	// AggregationCount returns the number of entries containing each field
+	AggregationCount Aggregation = "count"
+	// AggregationDistinct returns the distinct values of each field
+	AggregationDistinct Aggregation = "distinct"
+)
+
+// Paths into an entry start with one of these fields.
+// Paths starting with `inventory` continue into the inventory document, for example `inventory.kubernetes.version`.
+const (
+	PathCluster   = "cluster"
+	PathTenant    = "tenant"
+	PathTimestamp = "timestamp"
+	PathInventory = "inventory"
+)
+
+// Selection describes what's computed from the entries returned by the store
+type Selection struct {
+	// Fields are the paths whose values are returned
+	Fields []string
+	// Aggregation combines the values of the fields within each group, defaults to AggregationNone
+	Aggregation Aggregation
+	// GroupBy are the paths whose values form the groups
+	GroupBy []string
+	// LatestPerCluster only considers the most recent entry of each cluster
+	LatestPerCluster bool
+}
+
+// Row is a result of the selection
+type Row struct {
+	// Group contains the values of the GroupBy paths
+	Group map[string]any
+	// Values contains the (aggregated) values of the fields
+	Values map[string]any
+	// Entries is the number of entries the row was computed from
+	Entries int
+}
+
+// Validate checks the selection for unknown aggregations and invalid paths
+func (s Selection) Validate() error {
+	switch s.Aggregation {
+	case "", AggregationNone, AggregationLast, AggregationCount, AggregationDistinct:
+	default:
+		return fmt.Errorf("unknown aggregation '%s'", s.Aggregation)
+	}
+	for _, p := range append(append([]string{}, s.Fields...), s.GroupBy...) {
+		if err := validatePath(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validatePath(path string) error {
+	segments := strings.Split(path, ".")
+	for _, s := range segments {
+		if s == "" {
+			return fmt.Errorf("invalid path '%s'", path)
+		}
+	}
+	switch segments[0] {
+	case PathCluster, PathTenant, PathTimestamp:
+		if len(segments) > 1 {
+			return fmt.Errorf("invalid path '%s': '%s' has no fields", path, segments[0])
+		}
+	case PathInventory:
+	default:
+		return fmt.Errorf("invalid path '%s': must start with one of '%s', '%s', '%s' or '%s'", path, PathCluster, PathTenant, PathTimestamp, PathInventory)
+	}
+	return nil
+}
+
+// Evaluate computes the rows of the selection.
+// The entries must be ordered by cluster and time as returned by Store.Query.
+// Tenants maps the cluster names to their tenant.
+func Evaluate(entries []Entry, tenants map[string]string, sel Selection) []Row {
+	ev := NewEvaluator(tenants, sel)
+	for _, e := range entries {
+		ev.Add(e)
+	}
+	return ev.Rows()
+}
+
+// Evaluator computes the rows of a selection from entries added one by one.
+// Only the aggregated values of each group are kept, not the entries themselves.
+type Evaluator struct {
+	tenants map[string]string
+	sel     Selection
+
+	// latest is the most recent entry of the current cluster if only the latest entries are considered
+	latest *Entry
+	rows   []Row
+	groups map[string]*group
+}
+
+type group struct {
+	values  map[string]any
+	fields  map[string]*fieldState
+	entries int
+}
+
+// fieldState is the aggregated value of a field within a group
+type fieldState struct {
+	last     any
+	lastTime time.Time
+	hasLast  bool
+	count    int
+	distinct map[string]any
+}
+
+// NewEvaluator creates an evaluator of the selection.
+// Tenants maps the cluster names to their tenant.
+func NewEvaluator(tenants map[string]string, sel Selection) *Evaluator {
+	return &Evaluator{
+		tenants: tenants,
+		sel:     sel,
+		rows:    []Row{},
+		groups:  map[string]*group{},
+	}
+}
+
+// Add adds the entry to the selection.
+// The entries must be added ordered by cluster and time as returned by Store.Query.
+func (ev *Evaluator) Add(e Entry) {
+	if !ev.sel.LatestPerCluster {
+		ev.add(e)
+		return
+	}
+	if ev.latest != nil && ev.latest.Cluster == e.Cluster {
+		if !e.Timestamp.Before(ev.latest.Timestamp) {
+			ev.latest = &e
+		}
+		return
+	}
+	if ev.latest != nil {
+		ev.add(*ev.latest)
+	}
+	ev.latest = &e
+}
+
+// Rows returns the rows computed from the added entries
+func (ev *Evaluator) Rows() []Row {
+	if ev.latest != nil {
+		ev.add(*ev.latest)
+		ev.latest = nil
+	}
+	if ev.sel.Aggregation == "" || ev.sel.Aggregation == AggregationNone {
+		return ev.rows
+	}
+
+	keys := make([]string, 0, len(ev.groups))
+	for k := range ev.groups {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	rows := make([]Row, 0, len(ev.groups))
+	for _, k := range keys {
+		g := ev.groups[k]
+		values := map[string]any{}
+		for _, f := range ev.sel.Fields {
+			values[f] = g.fields[f].value(ev.sel.Aggregation)
+		}
+		rows = append(rows, Row{
+			Group:   g.values,
+			Values:  values,
+			Entries: g.entries,
+		})
+	}
+	return rows
+}
+
+func (ev *Evaluator) add(e Entry) {
+	if ev.sel.Aggregation == "" || ev.sel.Aggregation == AggregationNone {
+		ev.rows = append(ev.rows, Row{
+			Group:   lookupAll(e, ev.tenants, ev.sel.GroupBy, false),
+			Values:  lookupAll(e, ev.tenants, ev.sel.Fields, false),
+			Entries: 1,
+		})
+		return
+	}
+
+	values := lookupAll(e, ev.tenants, ev.sel.GroupBy, true)
+	key := encode(values)
+	g, ok := ev.groups[key]
+	if !ok {
+		g = &group{values: values, fields: map[string]*fieldState{}}
+		for _, f := range ev.sel.Fields {
+			g.fields[f] = &fieldState{distinct: map[string]any{}}
+		}
+		ev.groups[key] = g
+	}
+	g.entries++
+	for _, f := range ev.sel.Fields {
+		if v, ok := lookup(e, ev.tenants, f); ok {
+			g.fields[f].add(e.Timestamp, v, ev.sel.Aggregation)
+		}
+	}
+}
+
+func (s *fieldState) add(ts time.Time, v any, agg Aggregation) {
+	switch agg {
+	case AggregationLast:
+		if !s.hasLast || !ts.Before(s.lastTime) {
+			s.last, s.lastTime, s.hasLast = v, ts, true
+		}
+	case AggregationCount:
+		s.count++
+	case AggregationDistinct:
+		s.distinct[encode(v)] = v
+	}
+}
+
+func (s *fieldState) value(agg Aggregation) any {
+	switch agg {
+	case AggregationLast:
+		return s.last
+	case AggregationCount:
+		return s.count
+	case AggregationDistinct:
+		keys := make([]string, 0, len(s.distinct))
+		for k := range s.distinct {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		values := make([]any, 0, len(keys))
+		for _, k := range keys {
+			values = append(values, s.distinct[k])
+		}
+		return values
+	}
+	return nil
+}
+
+// lookupAll returns the values of the paths in the entry.
+// Missing values are omitted, unless withMissing is set in which case they're nil.
+func lookupAll(e Entry, tenants map[string]string, paths []string, withMissing bool) map[string]any {
+	values := map[string]any{}
+	for _, p := range paths {
+		v, ok := lookup(e, tenants, p)
+		if ok || withMissing {
+			values[p] = v
+		}
+	}
+	return values
+}
+
+func lookup(e Entry, tenants map[string]string, path string) (any, bool) {
+	segments := strings.Split(path, ".")
+	switch segments[0] {
+	case PathCluster:
+		return e.Cluster, true
+	case PathTenant:
+		t, ok := tenants[e.Cluster]
+		return t, ok
+	case PathTimestamp:
+		return e.Timestamp, true
+	}
+	var value any = e.Inventory
+	for _, s := range segments[1:] {
+		m, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		value, ok = m[s]
+		if !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// encode returns a string usable to compare the values
+func encode(v any) string {
+	// Maps are encoded with sorted keys, errors can't occur with values decoded from JSON
+	b, _ := json.Marshal(v)
+	return string(b)
+}
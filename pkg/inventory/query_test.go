@@ -0,0 +1,108 @@
+package inventory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	queryEntries = []Entry{
+		{Cluster: "c-a", Timestamp: t0, Inventory: map[string]any{"k8s": map[string]any{"version": "1.28"}, "cloud": "cloudscale"}},
+		{Cluster: "c-a", Timestamp: t1, Inventory: map[string]any{"k8s": map[string]any{"version": "1.29"}, "cloud": "cloudscale"}},
+		{Cluster: "c-b", Timestamp: t1, Inventory: map[string]any{"k8s": map[string]any{"version": "1.29"}}},
+		{Cluster: "c-c", Timestamp: t2, Inventory: map[string]any{"k8s": map[string]any{"version": "1.30"}, "cloud": "exoscale"}},
+	}
+	queryTenants = map[string]string{
+		"c-a": "t-a",
+		"c-b": "t-a",
+		"c-c": "t-b",
+	}
+)
+
+func TestEvaluate(t *testing.T) {
+	tcs := map[string]struct {
+		sel      Selection
+		expected []Row
+	}{
+		"fields": {
+			sel: Selection{Fields: []string{"cluster", "inventory.cloud"}, LatestPerCluster: true},
+			expected: []Row{
+				{Group: map[string]any{}, Values: map[string]any{"cluster": "c-a", "inventory.cloud": "cloudscale"}, Entries: 1},
+				{Group: map[string]any{}, Values: map[string]any{"cluster": "c-b"}, Entries: 1},
+				{Group: map[string]any{}, Values: map[string]any{"cluster": "c-c", "inventory.cloud": "exoscale"}, Entries: 1},
+			},
+		},
+		"last": {
+			sel: Selection{Fields: []string{"inventory.k8s.version", "timestamp"}, Aggregation: AggregationLast, GroupBy: []string{"tenant"}},
+			expected: []Row{
+				{Group: map[string]any{"tenant": "t-a"}, Values: map[string]any{"inventory.k8s.version": "1.29", "timestamp": t1}, Entries: 3},
+				{Group: map[string]any{"tenant": "t-b"}, Values: map[string]any{"inventory.k8s.version": "1.30", "timestamp": t2}, Entries: 1},
+			},
+		},
+		"count": {
+			sel: Selection{Fields: []string{"cluster", "inventory.cloud"}, Aggregation: AggregationCount, GroupBy: []string{"inventory.k8s.version"}, LatestPerCluster: true},
+			expected: []Row{
+				{Group: map[string]any{"inventory.k8s.version": "1.29"}, Values: map[string]any{"cluster": 2, "inventory.cloud": 1}, Entries: 2},
+				{Group: map[string]any{"inventory.k8s.version": "1.30"}, Values: map[string]any{"cluster": 1, "inventory.cloud": 1}, Entries: 1},
+			},
+		},
+		"distinct": {
+			sel: Selection{Fields: []string{"inventory.k8s.version"}, Aggregation: AggregationDistinct},
+			expected: []Row{
+				{Group: map[string]any{}, Values: map[string]any{"inventory.k8s.version": []any{"1.28", "1.29", "1.30"}}, Entries: 4},
+			},
+		},
+		"group by missing value": {
+			sel: Selection{Aggregation: AggregationCount, GroupBy: []string{"inventory.cloud"}, LatestPerCluster: true},
+			expected: []Row{
+				{Group: map[string]any{"inventory.cloud": "cloudscale"}, Values: map[string]any{}, Entries: 1},
+				{Group: map[string]any{"inventory.cloud": "exoscale"}, Values: map[string]any{}, Entries: 1},
+				{Group: map[string]any{"inventory.cloud": nil}, Values: map[string]any{}, Entries: 1},
+			},
+		},
+	}
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Evaluate(queryEntries, queryTenants, tc.sel))
+		})
+	}
+	assert.Empty(t, Evaluate(nil, queryTenants, Selection{Aggregation: AggregationLast}))
+}
+
+func TestSelectionValidate(t *testing.T) {
+	tcs := map[string]struct {
+		sel   Selection
+		valid bool
+	}{
+		"empty": {
+			valid: true,
+		},
+		"valid": {
+			sel:   Selection{Fields: []string{"inventory.k8s.version", "timestamp"}, Aggregation: AggregationDistinct, GroupBy: []string{"cluster", "tenant"}},
+			valid: true,
+		},
+		"unknown aggregation": {
+			sel: Selection{Aggregation: "sum"},
+		},
+		"unknown root": {
+			sel: Selection{Fields: []string{"version"}},
+		},
+		"field of cluster": {
+			sel: Selection{GroupBy: []string{"cluster.name"}},
+		},
+		"empty segment": {
+			sel: Selection{Fields: []string{"inventory..version"}},
+		},
+	}
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			err := tc.sel.Validate()
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
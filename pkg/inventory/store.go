@@ -0,0 +1,133 @@
+// Package inventory stores time based inventory data, fact changes and compilation metadata of clusters.
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	crlog "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// BackendBolt stores the inventory in an embedded bbolt database
+	BackendBolt = "bolt"
+	// BackendInfluxDB stores the inventory in an external InfluxDB
+	BackendInfluxDB = "influxdb"
+
+	// DefaultRetention is how long data is kept if no retention is configured
+	DefaultRetention = 90 * 24 * time.Hour
+
+	pruneInterval = time.Hour
+)
+
+// Entry is the inventory of a cluster at a point in time
+type Entry struct {
+	Cluster   string
+	Timestamp time.Time
+	Inventory map[string]any
+}
+
+// Query selects inventory entries.
+// Zero values don't restrict the result.
+type Query struct {
+	// Cluster restricts the result to a single cluster
+	Cluster string
+	// From is the inclusive start of the time range
+	From time.Time
+	// To is the inclusive end of the time range
+	To time.Time
+	// Latest only returns the most recent entry of each cluster within the time range
+	Latest bool
+}
+
+// Store persists inventory entries, the history of dynamic facts and the reported compilation metadata
+type Store interface {
+	FactHistory
+	CompileMetaHistory
+
+	// Update stores the inventory of the cluster at the given time
+	Update(ctx context.Context, entry Entry) error
+	// Query calls fn with each entry matching the query, ordered by cluster and time.
+	// The entries aren't kept by the store, the query stops at the first error returned by fn.
+	Query(ctx context.Context, query Query, fn func(Entry) error) error
+	// Prune deletes the inventory, fact changes and compilation metadata recorded before the given time
+	Prune(ctx context.Context, before time.Time) error
+	// Close releases the resources held by the store
+	Close() error
+}
+
+// Config holds the config options for the inventory store
+type Config struct {
+	// Backend is either BackendBolt or BackendInfluxDB, no store is created if it's empty
+	Backend string
+
+	// Path to the database file of the bolt backend
+	Path string
+
+	// URL of the InfluxDB
+	URL string
+	// Database to use in the InfluxDB
+	Database string
+	// Token to authenticate to the InfluxDB, optional
+	Token string
+}
+
+// NewStore creates the store of the configured backend.
+// Returns nil if no backend is configured, the inventory is then disabled.
+func NewStore(conf Config) (Store, error) {
+	switch conf.Backend {
+	case "":
+		return nil, nil
+	case BackendBolt:
+		return NewBoltStore(conf.Path)
+	case BackendInfluxDB:
+		return NewInfluxDBStore(conf.URL, conf.Database, conf.Token)
+	default:
+		return nil, fmt.Errorf("unknown inventory backend '%s'", conf.Backend)
+	}
+}
+
+// contains returns true if the timestamp is within the time range of the query
+func (q Query) contains(t time.Time) bool {
+	if !q.From.IsZero() && t.Before(q.From) {
+		return false
+	}
+	if !q.To.IsZero() && t.After(q.To) {
+		return false
+	}
+	return true
+}
+
+// ParseRetention parses the retention as Go duration, an empty retention is DefaultRetention and 0 keeps all data
+func ParseRetention(raw string) (time.Duration, error) {
+	if raw == "" {
+		return DefaultRetention, nil
+	}
+	retention, err := time.ParseDuration(raw)
+	if err != nil || retention < 0 {
+		return 0, fmt.Errorf("invalid inventory retention '%s', expected a duration such as '2160h' or 0 to keep all data", raw)
+	}
+	return retention, nil
+}
+
+// RunRetention deletes the data older than the retention from the store every hour until the context is done.
+// A retention of 0 keeps all data.
+func RunRetention(ctx context.Context, store Store, retention time.Duration) {
+	if retention == 0 {
+		return
+	}
+	log := crlog.FromContext(ctx).WithName("inventory-retention")
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+	for {
+		if err := store.Prune(ctx, time.Now().Add(-retention)); err != nil {
+			log.Error(err, "failed to prune inventory")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -5,27 +5,48 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"os"
 	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/hashicorp/golang-lru/v2/expirable"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	oapimiddleware "github.com/oapi-codegen/echo-middleware"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/projectsyn/lieutenant-api/pkg/api"
+	"github.com/projectsyn/lieutenant-api/pkg/inventory"
 	swaggerui "github.com/projectsyn/lieutenant-api/swagger-ui"
 )
 
+// TrustedProxiesEnvVar is the env var name that's used to get the comma separated CIDRs of proxies whose X-Forwarded-For header is trusted
+const TrustedProxiesEnvVar = "TRUSTED_PROXIES"
+
 // APIImpl implements the API interface
 type APIImpl struct {
 	namespace string
 
 	// Metadata on the API itself
 	metadata api.Metadata
+
+	inventory inventory.Store
+
+	// clientCaches are the client caches of the Kubernetes auth middlewares
+	clientCaches []*clientCache
+	// reviewCaches are the token review caches of the Kubernetes auth middlewares
+	reviewCaches []*tokenCache[authenticationv1.UserInfo]
+
+	// readCache serves reads of tenants and clusters, they're read from Kubernetes if it's nil
+	readCache client.Reader
+	// accessReviews caches the read access of users
+	accessReviews *expirable.LRU[string, bool]
 }
 
 // APIConfig holds the config options for the API
@@ -39,12 +60,55 @@ type APIConfig struct {
 
 	VaultAddr        string
 	VaultLoginMethod string
+
+	// Inventory stores the inventory data, the inventory endpoints are unavailable if it's nil
+	Inventory inventory.Store
+
+	// ReadCache serves reads of tenants and clusters, they're read from Kubernetes if it's nil
+	ReadCache client.Reader
 }
 
 // APIContext is a custom echo context
 type APIContext struct {
 	echo.Context
 	client client.Client
+
+	token string
+	auth  *KubernetesAuth
+	user  *authenticationv1.UserInfo
+}
+
+// userInfo returns the user making the request, the token is only reviewed once per request
+func (c *APIContext) userInfo() (authenticationv1.UserInfo, error) {
+	if c.user != nil {
+		return *c.user, nil
+	}
+	if c.token == "" || c.auth == nil {
+		return authenticationv1.UserInfo{}, echo.NewHTTPError(http.StatusUnauthorized, "Request isn't authenticated")
+	}
+	user, err := c.auth.reviewToken(c.Request().Context(), c.token)
+	if err != nil {
+		return authenticationv1.UserInfo{}, err
+	}
+	c.user = &user
+	return user, nil
+}
+
+// accessAllowed returns true if the user making the request is allowed to access the resource
+func (c *APIContext) accessAllowed(attrs authorizationv1.ResourceAttributes) (bool, error) {
+	user, err := c.userInfo()
+	if err != nil {
+		return false, err
+	}
+	return c.auth.reviewAccess(c.Request().Context(), user, attrs)
+}
+
+// apiClient returns the client authenticated as the API itself
+func (c *APIContext) apiClient() (client.Client, error) {
+	if c.auth == nil {
+		return c.client, nil
+	}
+	return c.auth.getClient("")
 }
 
 var (
@@ -77,6 +141,12 @@ func NewAPIServer(conf APIConfig, k8sMiddleware ...KubernetesAuth) (*echo.Echo,
 		metadata: api.Metadata{
 			ApiVersion: conf.APIVersion,
 		},
+		inventory: conf.Inventory,
+		readCache: conf.ReadCache,
+	}
+	apiImpl.accessReviews, err = newAccessReviewCache()
+	if err != nil {
+		return nil, err
 	}
 	if conf.OidcCLientID != "" || conf.OidcDiscoveryURL != "" {
 		apiImpl.metadata.Oidc = &api.OIDCConfig{
@@ -93,9 +163,26 @@ func NewAPIServer(conf APIConfig, k8sMiddleware ...KubernetesAuth) (*echo.Echo,
 		}
 	}
 
+	// Fail early instead of falling back to legacy tokens on every install
+	if _, err := tokenExpiration(); err != nil {
+		return nil, err
+	}
+	if _, err := parseHeartbeatStaleAfter(); err != nil {
+		return nil, err
+	}
+	if err := validateIDGenerator(); err != nil {
+		return nil, err
+	}
+
 	e := echo.New()
+	e.IPExtractor, err = ipExtractor(os.Getenv(TrustedProxiesEnvVar))
+	if err != nil {
+		return nil, err
+	}
 	e.Use(middleware.LoggerWithConfig(middleware.LoggerConfig{
-		Skipper: func(c echo.Context) bool { return strings.HasSuffix(c.Path(), "/healthz") },
+		Skipper: func(c echo.Context) bool {
+			return strings.HasSuffix(c.Path(), "/healthz") || strings.HasSuffix(c.Path(), "/metrics")
+		},
 	}))
 	e.Use(middleware.Recover())
 	e.Pre(middleware.RemoveTrailingSlash())
@@ -119,16 +206,68 @@ func NewAPIServer(conf APIConfig, k8sMiddleware ...KubernetesAuth) (*echo.Echo,
 	}
 	e.Use(oapimiddleware.OapiRequestValidatorWithOptions(swagger, options))
 	if len(k8sMiddleware) == 0 {
-		e.Use(DefaultKubernetesAuth.JWTAuth)
+		auth, err := defaultKubernetesAuth(conf)
+		if err != nil {
+			return nil, err
+		}
+		e.Use(auth.JWTAuth)
+		apiImpl.clientCaches = append(apiImpl.clientCaches, auth.cache)
+		apiImpl.reviewCaches = append(apiImpl.reviewCaches, auth.reviews)
 	} else {
 		for _, middle := range k8sMiddleware {
 			e.Use(middle.JWTAuth)
+			apiImpl.clientCaches = append(apiImpl.clientCaches, middle.cache)
+			if middle.reviews != nil {
+				apiImpl.reviewCaches = append(apiImpl.reviewCaches, middle.reviews)
+			}
 		}
 	}
+	e.Use(apiImpl.resolveClusterIDParam)
 	api.RegisterHandlers(e, apiImpl)
 	return e, nil
 }
 
+// defaultKubernetesAuth returns the DefaultKubernetesAuth for the configured auth mode
+func defaultKubernetesAuth(conf APIConfig) (*KubernetesAuth, error) {
+	switch mode := os.Getenv(K8sAuthModeEnvKey); mode {
+	case "", K8sAuthModeToken, K8sAuthModeImpersonation:
+		return DefaultKubernetesAuth, nil
+	case K8sAuthModeOIDC:
+		oidcAuth, err := NewOIDCAuth(conf.OidcDiscoveryURL, conf.OidcCLientID)
+		if err != nil {
+			return nil, err
+		}
+		auth := *DefaultKubernetesAuth
+		auth.Impersonate = true
+		auth.OIDC = oidcAuth
+		return &auth, nil
+	default:
+		return nil, fmt.Errorf("unknown %s '%s', expected one of '%s', '%s' or '%s'",
+			K8sAuthModeEnvKey, mode, K8sAuthModeToken, K8sAuthModeImpersonation, K8sAuthModeOIDC)
+	}
+}
+
+// ipExtractor returns the extractor of the client IP.
+// The X-Forwarded-For header is only used if the request comes from one of the trusted proxies, otherwise the IP of the connection is used.
+func ipExtractor(trustedProxies string) (echo.IPExtractor, error) {
+	if trustedProxies == "" {
+		return echo.ExtractIPDirect(), nil
+	}
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	for _, cidr := range strings.Split(trustedProxies, ",") {
+		_, ipNet, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", TrustedProxiesEnvVar, err)
+		}
+		options = append(options, echo.TrustIPRange(ipNet))
+	}
+	return echo.ExtractIPFromXFFHeader(options...), nil
+}
+
 func customHTTPErrorHandler(err error, c echo.Context) {
 	code := http.StatusInternalServerError
 	message := err.Error()
@@ -153,8 +292,14 @@ func (s *APIImpl) Discovery(ctx echo.Context) error {
 	return ctx.JSON(http.StatusOK, &s.metadata)
 }
 
-// Healthz implements the API health check
+// Healthz implements the API health check.
+// It fails if the read cache stopped.
 func (s *APIImpl) Healthz(ctx echo.Context) error {
+	if hc, ok := s.readCache.(healthChecker); ok {
+		if err := hc.Healthy(); err != nil {
+			return echo.NewHTTPError(http.StatusServiceUnavailable, err.Error())
+		}
+	}
 	return ctx.String(http.StatusOK, "ok")
 }
 
@@ -0,0 +1,205 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync/atomic"
+	"time"
+
+	lruCache "github.com/hashicorp/golang-lru/v2"
+	"github.com/labstack/echo/v4"
+	synv1alpha1 "github.com/projectsyn/lieutenant-operator/api/v1alpha1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// K8sCacheTTLEnvKey is the env var name that's used to get how long a cached client is kept at most
+	K8sCacheTTLEnvKey = "K8S_AUTH_CLIENT_CACHE_TTL"
+	// K8sReviewCacheTTLEnvKey is the env var name that's used to get how long the reviewed user of a token is kept at most
+	K8sReviewCacheTTLEnvKey = "K8S_AUTH_TOKEN_REVIEW_CACHE_TTL"
+
+	defaultCacheTTL       = time.Hour
+	defaultReviewCacheTTL = 2 * time.Minute
+)
+
+// tokenCache keeps values derived from tokens, like the Kubernetes client or the reviewed user of a token.
+// The values are keyed by the hash of the token and removed once the token expires or after the TTL.
+type tokenCache[V any] struct {
+	lru *lruCache.Cache[string, cachedValue[V]]
+	ttl time.Duration
+	now func() time.Time
+
+	hits             atomic.Uint64
+	misses           atomic.Uint64
+	sizeEvictions    atomic.Uint64
+	expiredEvictions atomic.Uint64
+	flushEvictions   atomic.Uint64
+}
+
+type cachedValue[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// clientCache keeps the Kubernetes clients of tokens
+type clientCache = tokenCache[client.Client]
+
+func getCacheTTLOrDefault(def time.Duration) time.Duration {
+	return getDurationOrDefault(K8sCacheTTLEnvKey, def)
+}
+
+func getDurationOrDefault(key string, def time.Duration) time.Duration {
+	rawTTL := os.Getenv(key)
+	if rawTTL == "" {
+		return def
+	}
+	parsed, err := time.ParseDuration(rawTTL)
+	if err != nil || parsed <= 0 {
+		return def
+	}
+	return parsed
+}
+
+func createCache() *clientCache {
+	return newTokenCache[client.Client](getCacheSizeOrDefault(128), getCacheTTLOrDefault(defaultCacheTTL))
+}
+
+// createReviewCache creates the cache of the users of reviewed tokens
+func createReviewCache() *tokenCache[authenticationv1.UserInfo] {
+	return newTokenCache[authenticationv1.UserInfo](getCacheSizeOrDefault(128), getDurationOrDefault(K8sReviewCacheTTLEnvKey, defaultReviewCacheTTL))
+}
+
+func newTokenCache[V any](size int, ttl time.Duration) *tokenCache[V] {
+	lru, err := lruCache.New[string, cachedValue[V]](size)
+	runtime.Must(err)
+	return &tokenCache[V]{
+		lru: lru,
+		ttl: ttl,
+		now: time.Now,
+	}
+}
+
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// Get returns the cached value of the token, if it hasn't expired
+func (c *tokenCache[V]) Get(token string) (V, bool) {
+	key := hashToken(token)
+	entry, ok := c.lru.Get(key)
+	if ok && !c.now().Before(entry.expiresAt) {
+		c.lru.Remove(key)
+		c.expiredEvictions.Add(1)
+		ok = false
+	}
+	if !ok {
+		c.misses.Add(1)
+		var zero V
+		return zero, false
+	}
+	c.hits.Add(1)
+	return entry.value, true
+}
+
+// Add caches the value of the token until the token expires or the TTL is reached
+func (c *tokenCache[V]) Add(token string, value V) {
+	now := c.now()
+	c.removeExpired(now)
+	expiresAt := now.Add(c.ttl)
+	if claims, ok := parseUnverifiedClaims(token); ok && claims.Expiry != 0 {
+		if exp := time.Unix(claims.Expiry, 0); exp.Before(expiresAt) {
+			expiresAt = exp
+		}
+	}
+	if evicted := c.lru.Add(hashToken(token), cachedValue[V]{value: value, expiresAt: expiresAt}); evicted {
+		c.sizeEvictions.Add(1)
+	}
+}
+
+func (c *tokenCache[V]) removeExpired(now time.Time) {
+	for _, key := range c.lru.Keys() {
+		if entry, ok := c.lru.Peek(key); ok && !now.Before(entry.expiresAt) {
+			c.lru.Remove(key)
+			c.expiredEvictions.Add(1)
+		}
+	}
+}
+
+// Flush removes all cached values
+func (c *tokenCache[V]) Flush() {
+	c.flushEvictions.Add(uint64(c.lru.Len()))
+	c.lru.Purge()
+}
+
+// Len returns the number of cached values
+func (c *tokenCache[V]) Len() int {
+	return c.lru.Len()
+}
+
+// writeClientCacheMetrics writes the metrics of the caches in the Prometheus text format
+func writeClientCacheMetrics(w io.Writer, caches []*clientCache) {
+	var size int
+	var hits, misses, sizeEvictions, expiredEvictions, flushEvictions uint64
+	for _, c := range caches {
+		size += c.Len()
+		hits += c.hits.Load()
+		misses += c.misses.Load()
+		sizeEvictions += c.sizeEvictions.Load()
+		expiredEvictions += c.expiredEvictions.Load()
+		flushEvictions += c.flushEvictions.Load()
+	}
+	fmt.Fprintf(w, "# HELP lieutenant_api_client_cache_size Number of cached Kubernetes clients.\n")
+	fmt.Fprintf(w, "# TYPE lieutenant_api_client_cache_size gauge\n")
+	fmt.Fprintf(w, "lieutenant_api_client_cache_size %d\n", size)
+	fmt.Fprintf(w, "# HELP lieutenant_api_client_cache_hits_total Number of requests which used a cached Kubernetes client.\n")
+	fmt.Fprintf(w, "# TYPE lieutenant_api_client_cache_hits_total counter\n")
+	fmt.Fprintf(w, "lieutenant_api_client_cache_hits_total %d\n", hits)
+	fmt.Fprintf(w, "# HELP lieutenant_api_client_cache_misses_total Number of requests which had to create a Kubernetes client.\n")
+	fmt.Fprintf(w, "# TYPE lieutenant_api_client_cache_misses_total counter\n")
+	fmt.Fprintf(w, "lieutenant_api_client_cache_misses_total %d\n", misses)
+	fmt.Fprintf(w, "# HELP lieutenant_api_client_cache_evictions_total Number of Kubernetes clients removed from the cache.\n")
+	fmt.Fprintf(w, "# TYPE lieutenant_api_client_cache_evictions_total counter\n")
+	fmt.Fprintf(w, "lieutenant_api_client_cache_evictions_total{reason=\"size\"} %d\n", sizeEvictions)
+	fmt.Fprintf(w, "lieutenant_api_client_cache_evictions_total{reason=\"expired\"} %d\n", expiredEvictions)
+	fmt.Fprintf(w, "lieutenant_api_client_cache_evictions_total{reason=\"flush\"} %d\n", flushEvictions)
+}
+
+// Metrics serves the metrics of the API
+func (s *APIImpl) Metrics(ctx echo.Context) error {
+	ctx.Response().Header().Set(echo.HeaderContentType, "text/plain; version=0.0.4; charset=utf-8")
+	ctx.Response().WriteHeader(http.StatusOK)
+	writeClientCacheMetrics(ctx.Response(), s.clientCaches)
+	return nil
+}
+
+// FlushClientCache removes all cached Kubernetes clients
+func (s *APIImpl) FlushClientCache(c echo.Context) error {
+	ctx := c.(*APIContext)
+	allowed, err := ctx.accessAllowed(authorizationv1.ResourceAttributes{
+		Namespace: s.namespace,
+		Verb:      "delete",
+		Group:     synv1alpha1.GroupVersion.Group,
+		Resource:  "clientcaches",
+	})
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return echo.NewHTTPError(http.StatusForbidden, "Flushing the client cache requires the permission to delete clientcaches")
+	}
+	for _, cache := range s.clientCaches {
+		cache.Flush()
+	}
+	for _, cache := range s.reviewCaches {
+		cache.Flush()
+	}
+	return ctx.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	lruCache "github.com/hashicorp/golang-lru/v2"
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// testClock is a clock which only moves when told to
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+func newTestCache(t *testing.T, size int, ttl time.Duration) (*clientCache, *testClock) {
+	lru, err := lruCache.New[string, cachedValue[client.Client]](size)
+	require.NoError(t, err)
+	clock := &testClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	return &clientCache{lru: lru, ttl: ttl, now: clock.Now}, clock
+}
+
+// unsignedJWT returns a JWT which expires at the given time, its signature is invalid
+func unsignedJWT(exp time.Time) string {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"sub":"user","exp":%d}`, exp.Unix())))
+	return "eyJhbGciOiJSUzI1NiJ9." + payload + ".c2lnbmF0dXJl"
+}
+
+func TestClientCacheHashesTokens(t *testing.T) {
+	cache, _ := newTestCache(t, 10, time.Hour)
+	c := fake.NewClientBuilder().Build()
+
+	cache.Add("secret-token", c)
+	assert.Equal(t, []string{hashToken("secret-token")}, cache.lru.Keys())
+	cached, ok := cache.Get("secret-token")
+	assert.True(t, ok)
+	assert.Same(t, c, cached)
+	_, ok = cache.Get("other-token")
+	assert.False(t, ok)
+	assert.Equal(t, uint64(1), cache.hits.Load())
+	assert.Equal(t, uint64(1), cache.misses.Load())
+}
+
+func TestClientCacheTokenExpiry(t *testing.T) {
+	cache, clock := newTestCache(t, 10, time.Hour)
+	token := unsignedJWT(clock.now.Add(10 * time.Minute))
+
+	cache.Add(token, fake.NewClientBuilder().Build())
+	clock.now = clock.now.Add(9 * time.Minute)
+	_, ok := cache.Get(token)
+	assert.True(t, ok)
+
+	clock.now = clock.now.Add(time.Minute)
+	_, ok = cache.Get(token)
+	assert.False(t, ok)
+	assert.Equal(t, 0, cache.Len())
+	assert.Equal(t, uint64(1), cache.expiredEvictions.Load())
+}
+
+func TestClientCacheTTL(t *testing.T) {
+	cache, clock := newTestCache(t, 10, time.Hour)
+	jwt := unsignedJWT(clock.now.Add(24 * time.Hour))
+
+	cache.Add("opaque", fake.NewClientBuilder().Build())
+	cache.Add(jwt, fake.NewClientBuilder().Build())
+	clock.now = clock.now.Add(time.Hour)
+
+	// Expired clients are removed when a client is added
+	cache.Add("other", fake.NewClientBuilder().Build())
+	assert.Equal(t, []string{hashToken("other")}, cache.lru.Keys())
+	assert.Equal(t, uint64(2), cache.expiredEvictions.Load())
+}
+
+func TestClientCacheSizeEviction(t *testing.T) {
+	cache, _ := newTestCache(t, 1, time.Hour)
+
+	cache.Add("a", fake.NewClientBuilder().Build())
+	cache.Add("b", fake.NewClientBuilder().Build())
+	_, ok := cache.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, uint64(1), cache.sizeEvictions.Load())
+}
+
+func Test_getCacheTTLOrDefault(t *testing.T) {
+	tests := map[string]struct {
+		envValue string
+		expected time.Duration
+	}{
+		"GivenNoEnvVar_WhenGet_ThenReturnDefault": {
+			envValue: "",
+			expected: time.Hour,
+		},
+		"GivenInvalidEnvVar_WhenGet_ThenReturnDefault": {
+			envValue: "forever",
+			expected: time.Hour,
+		},
+		"GivenEnvVar_WhenGet_ThenReturnParsedValue": {
+			envValue: "15m",
+			expected: 15 * time.Minute,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(K8sCacheTTLEnvKey, tt.envValue)
+			assert.Equal(t, tt.expected, getCacheTTLOrDefault(time.Hour))
+		})
+	}
+}
+
+func TestFlushClientCache(t *testing.T) {
+	e, _ := setupTest(t)
+
+	result := testutil.NewRequest().
+		Get("/tenants/"+tenantA.Name).
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+
+	result = testutil.NewRequest().
+		Delete("/admin/clientCache").
+		WithHeader(echo.HeaderAuthorization, unprivilegedBearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusForbidden, result)
+
+	result = testutil.NewRequest().
+		Delete("/admin/clientCache").
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusNoContent, result)
+
+	result = testutil.NewRequest().Get("/metrics").GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+	metrics := result.Recorder.Body.String()
+	assert.Contains(t, metrics, "lieutenant_api_client_cache_size 0\n")
+	assert.Contains(t, metrics, "lieutenant_api_client_cache_hits_total 1\n")
+	assert.Contains(t, metrics, "lieutenant_api_client_cache_misses_total 2\n")
+	assert.Contains(t, metrics, `lieutenant_api_client_cache_evictions_total{reason="flush"} 2`+"\n")
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"os"
 	"sort"
+	"time"
 
 	"github.com/AlekSi/pointer"
 	"github.com/labstack/echo/v4"
@@ -13,7 +14,6 @@ import (
 	"go.uber.org/multierr"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/projectsyn/lieutenant-api/pkg/api"
@@ -38,7 +38,7 @@ func (s *APIImpl) ListClusters(c echo.Context, p api.ListClustersParams) error {
 	}
 
 	clusterList := &synv1alpha1.ClusterList{}
-	err := ctx.client.List(ctx.Request().Context(), clusterList, filterOptions...)
+	err := s.listClusters(ctx, clusterList, filterOptions...)
 	if err != nil {
 		return err
 	}
@@ -47,8 +47,17 @@ func (s *APIImpl) ListClusters(c echo.Context, p api.ListClustersParams) error {
 	errs := make([]error, 0, len(clusterList.Items))
 	for _, cluster := range clusterList.Items {
 		apiCluster, err := apiClusterWithInstallURL(ctx, &cluster)
-		clusters = append(clusters, *apiCluster)
 		errs = append(errs, err)
+		if err != nil {
+			continue
+		}
+		if p.Connectivity != nil && string(apiCluster.Connectivity.Status) != string(*p.Connectivity) {
+			continue
+		}
+		if p.GitRepoUrl != nil && cluster.Spec.GitRepoURL != *p.GitRepoUrl {
+			continue
+		}
+		clusters = append(clusters, *apiCluster)
 	}
 	if err := multierr.Combine(errs...); err != nil {
 		return fmt.Errorf("failed to translate CRD to API representation: %w", err)
@@ -93,12 +102,20 @@ func (s *APIImpl) CreateCluster(c echo.Context) error {
 	}
 	apiCluster := api.Cluster(*newCluster)
 
-	cluster, err := api.NewCRDFromAPICluster(apiCluster)
-	if err != nil {
-		return err
+	create := func() error {
+		cluster, err := api.NewCRDFromAPICluster(apiCluster)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		return s.createCluster(ctx, cluster)
 	}
-
-	return s.createCluster(ctx, cluster)
+	if apiCluster.Id.String() != "" {
+		return create()
+	}
+	return createWithGeneratedID(api.ClusterIDPrefix, pointer.GetString(apiCluster.DisplayName), func(id api.Id) error {
+		apiCluster.Id = &id
+		return create()
+	})
 }
 
 func (s *APIImpl) createCluster(ctx *APIContext, cluster *synv1alpha1.Cluster) error {
@@ -106,15 +123,22 @@ func (s *APIImpl) createCluster(ctx *APIContext, cluster *synv1alpha1.Cluster) e
 	if cluster.Spec.Facts == nil {
 		cluster.Spec.Facts = synv1alpha1.Facts{}
 	}
+	// The instance fact is always set by the API
+	delete(cluster.Spec.Facts, LieutenantInstanceFact)
+	if err := s.authorizeReserved(ctx, "clusters", cluster.Name, changedReservedOfCluster(&synv1alpha1.Cluster{}, cluster)); err != nil {
+		return err
+	}
 	cluster.Spec.Facts[LieutenantInstanceFact] = os.Getenv(LieutenantInstanceFactEnvVar)
-
-	// Need to copy status as Create will modify it
-	status := cluster.Status.DeepCopy()
-	if err := ctx.client.Create(ctx.Request().Context(), cluster); err != nil {
+	if err := s.validateFacts(ctx, cluster); err != nil {
+		return err
+	}
+	if err := s.validateAliases(ctx, cluster, true); err != nil {
 		return err
 	}
-	cluster.Status = *status
-	if err := ctx.client.Status().Update(ctx.Request().Context(), cluster); err != nil {
+
+	// The status can only be set through the dedicated endpoints
+	cluster.Status = synv1alpha1.ClusterStatus{}
+	if err := ctx.client.Create(ctx.Request().Context(), cluster); err != nil {
 		return err
 	}
 	ac, err := apiClusterWithInstallURL(ctx, cluster)
@@ -146,8 +170,7 @@ func (s *APIImpl) GetCluster(c echo.Context, clusterID api.ClusterIdParameter) e
 	ctx := c.(*APIContext)
 	cluster := &synv1alpha1.Cluster{}
 
-	err := ctx.client.Get(ctx.Request().Context(), client.ObjectKey{Name: string(clusterID), Namespace: s.namespace}, cluster)
-	if err != nil {
+	if err := s.getCluster(ctx, string(clusterID), cluster); err != nil {
 		return err
 	}
 
@@ -168,30 +191,49 @@ func (s *APIImpl) UpdateCluster(c echo.Context, clusterID api.ClusterIdParameter
 	if err := dec.Decode(&patchCluster); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, err)
 	}
+	if patchCluster.CompileMeta != nil {
+		return errStatusFieldsInUpdate
+	}
+	if patchCluster.DynamicFacts != nil {
+		if err := s.patchLegacyDynamicFacts(ctx, string(clusterID), *patchCluster.DynamicFacts); err != nil {
+			return err
+		}
+		patchCluster.DynamicFacts = nil
+	}
 
 	existingCluster := &synv1alpha1.Cluster{}
 	if err := ctx.client.Get(ctx.Request().Context(), client.ObjectKey{Name: string(clusterID), Namespace: s.namespace}, existingCluster); err != nil {
 		return err
 	}
 
+	old := existingCluster.DeepCopy()
 	err := api.SyncCRDFromAPICluster(patchCluster, existingCluster)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
+	if err := s.authorizeReserved(ctx, "clusters", existingCluster.Name, changedReservedOfCluster(old, existingCluster)); err != nil {
+		return err
+	}
+	// Existing facts and aliases are only validated if they're changed
+	if patchCluster.Facts != nil {
+		if err := s.validateFacts(ctx, existingCluster); err != nil {
+			return err
+		}
+	}
+	if patchCluster.Aliases != nil {
+		if err := s.validateAliases(ctx, existingCluster, false); err != nil {
+			return err
+		}
+	}
 
 	return s.updateCluster(ctx, existingCluster)
 }
 
+// updateCluster writes the cluster, its status can only be changed through the dedicated endpoints
 func (s *APIImpl) updateCluster(ctx *APIContext, existingCluster *synv1alpha1.Cluster) error {
-	// Need to copy status as the update will modify it
-	status := existingCluster.Status.DeepCopy()
 	if err := ctx.client.Update(ctx.Request().Context(), existingCluster); err != nil {
 		return err
 	}
-	existingCluster.Status = *status
-	if err := ctx.client.Status().Update(ctx.Request().Context(), existingCluster); err != nil {
-		return err
-	}
 
 	ac, err := apiClusterWithInstallURL(ctx, existingCluster)
 	if err != nil {
@@ -213,7 +255,7 @@ func (s *APIImpl) PutCluster(c echo.Context, clusterID api.ClusterIdParameter) e
 
 	cluster, err := api.NewCRDFromAPICluster(apiCluster)
 	if err != nil {
-		return err
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
 
 	found := &synv1alpha1.Cluster{}
@@ -226,41 +268,25 @@ func (s *APIImpl) PutCluster(c echo.Context, clusterID api.ClusterIdParameter) e
 		return s.createCluster(ctx, cluster)
 	}
 
-	found.Spec = cluster.Spec
-	found.Annotations = cluster.Annotations
-	return s.updateCluster(ctx, found)
-}
-
-// PostClusterCompileMeta compiles the meta data of a cluster
-func (s *APIImpl) PostClusterCompileMeta(c echo.Context, clusterID api.ClusterIdParameter) error {
-	ctx := c.(*APIContext)
-
-	body := &api.ClusterCompileMeta{}
-	if err := ctx.Bind(body); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err)
+	reserved := configuredReservedKeys()
+	cluster.Spec.Facts = keepReserved(found.Spec.Facts, cluster.Spec.Facts, reserved.isFact)
+	cluster.Annotations = keepReserved(found.Annotations, cluster.Annotations, reserved.isAnnotation)
+	if err := s.authorizeReserved(ctx, "clusters", found.Name, changedReservedOfCluster(found, cluster)); err != nil {
+		return err
 	}
 
-	toPatch := &synv1alpha1.Cluster{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      string(clusterID),
-			Namespace: s.namespace,
-		},
-	}
-	patch := []map[string]any{
-		{"op": "replace", "path": "/status/compileMeta", "value": body},
+	found.Spec = cluster.Spec
+	found.Annotations = cluster.Annotations
+	if err := api.SetClusterAliases(found, api.ClusterAliases(*cluster)); err != nil {
+		return err
 	}
-	rawPatch, err := json.Marshal(patch)
-	if err != nil {
-		return fmt.Errorf("failed to marshal patch: %w", err)
+	if err := s.validateFacts(ctx, found); err != nil {
+		return err
 	}
-
-	if err := retryOnConflict(func() error {
-		return ctx.client.Status().Patch(ctx.Request().Context(), toPatch, client.RawPatch(types.JSONPatchType, rawPatch))
-	}); err != nil {
+	if err := s.validateAliases(ctx, found, false); err != nil {
 		return err
 	}
-
-	return ctx.NoContent(http.StatusNoContent)
+	return s.updateCluster(ctx, found)
 }
 
 func apiClusterWithInstallURL(ctx *APIContext, cluster *synv1alpha1.Cluster) (*api.Cluster, error) {
@@ -269,15 +295,22 @@ func apiClusterWithInstallURL(ctx *APIContext, cluster *synv1alpha1.Cluster) (*a
 		return nil, err
 	}
 
+	apiCluster.Connectivity = clusterConnectivity(cluster, time.Now())
+
 	token, tokenValid := bootstrapToken(cluster)
 	if tokenValid {
-		installURL := fmt.Sprintf("%s://%s/install/steward.json?token=%s", ctx.Scheme(), ctx.Request().Host, token)
+		installURL := installURL(ctx, token)
 		apiCluster.InstallURL = &installURL
 	}
 
 	return apiCluster, nil
 }
 
+// installURL returns the URL to fetch the Steward install manifests with the given bootstrap token
+func installURL(ctx *APIContext, token string) string {
+	return fmt.Sprintf("%s://%s/install/steward.json?token=%s", ctx.Scheme(), ctx.Request().Host, token)
+}
+
 func bootstrapToken(cluster *synv1alpha1.Cluster) (token string, valid bool) {
 	if cluster.Status.BootstrapToken == nil {
 		return "", false
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	synv1alpha1 "github.com/projectsyn/lieutenant-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/projectsyn/lieutenant-api/pkg/api"
+)
+
+const clusterIDParam = "clusterId"
+
+// resolveClusterIDParam replaces the cluster ID path parameter with the ID of the cluster it refers to.
+// The parameter is kept if it doesn't refer to any cluster.
+func (s *APIImpl) resolveClusterIDParam(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx, ok := c.(*APIContext)
+		if !ok {
+			return next(c)
+		}
+		values := c.ParamValues()
+		for i, name := range c.ParamNames() {
+			if name != clusterIDParam || i >= len(values) {
+				continue
+			}
+			id, err := s.resolveClusterID(ctx, values[i])
+			if err != nil {
+				return err
+			}
+			values[i] = id
+		}
+		c.SetParamValues(values...)
+		return next(c)
+	}
+}
+
+// resolveClusterID returns the ID of the cluster matching the ID, the ID without prefix, an alias or the slug of the display name.
+func (s *APIImpl) resolveClusterID(ctx *APIContext, id string) (string, error) {
+	exists, err := s.clusterExists(ctx, id)
+	if err != nil || exists {
+		return id, nil
+	}
+	if !strings.HasPrefix(id, api.ClusterIDPrefix) {
+		exists, err := s.clusterExists(ctx, api.ClusterIDPrefix+id)
+		if err == nil && exists {
+			return api.ClusterIDPrefix + id, nil
+		}
+	}
+
+	clusterList := &synv1alpha1.ClusterList{}
+	if err := s.listClusters(ctx, clusterList, client.InNamespace(s.namespace)); err != nil {
+		// Callers which can't list clusters can only use IDs
+		return id, nil
+	}
+	aliasMatches, slugMatches := []string{}, []string{}
+	for _, cluster := range clusterList.Items {
+		if _, ok := cluster.Labels[api.ClusterAliasLabelPrefix+id]; ok {
+			aliasMatches = append(aliasMatches, cluster.Name)
+		}
+		if cluster.Spec.DisplayName != "" && api.Slug(cluster.Spec.DisplayName) == id {
+			slugMatches = append(slugMatches, cluster.Name)
+		}
+	}
+	// Concurrent updates can assign the same alias to multiple clusters, see validateAliases
+	if len(aliasMatches) > 1 {
+		return "", echo.NewHTTPError(http.StatusConflict,
+			fmt.Sprintf("'%s' is an alias of multiple clusters: %s", id, strings.Join(aliasMatches, ", ")))
+	}
+	if len(aliasMatches) == 1 {
+		return aliasMatches[0], nil
+	}
+	if len(slugMatches) > 1 {
+		return "", echo.NewHTTPError(http.StatusConflict,
+			fmt.Sprintf("'%s' matches the display name of multiple clusters: %s", id, strings.Join(slugMatches, ", ")))
+	}
+	if len(slugMatches) == 1 {
+		return slugMatches[0], nil
+	}
+	return id, nil
+}
+
+func (s *APIImpl) clusterExists(ctx *APIContext, id string) (bool, error) {
+	err := s.getCluster(ctx, id, &synv1alpha1.Cluster{})
+	if errors.IsNotFound(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// validateAliases checks that no other cluster uses one of the aliases of the cluster as alias or ID.
+// New clusters are also checked for other clusters using their ID as alias, the ID would hide the alias otherwise.
+// The check isn't atomic with the following write, two concurrent requests can still assign the same alias to different clusters.
+// Kubernetes has no uniqueness constraint across objects, resolving such an alias fails with a conflict until one of them is removed.
+func (s *APIImpl) validateAliases(ctx *APIContext, cluster *synv1alpha1.Cluster, isNew bool) error {
+	aliases := api.ClusterAliases(*cluster)
+	if len(aliases) == 0 && !isNew {
+		return nil
+	}
+	// All clusters have to be checked, even those the user can't see
+	c, err := ctx.apiClient()
+	if err != nil {
+		return err
+	}
+	clusterList := &synv1alpha1.ClusterList{}
+	if err := c.List(ctx.Request().Context(), clusterList, client.InNamespace(s.namespace)); err != nil {
+		return err
+	}
+	for _, other := range clusterList.Items {
+		if other.Name == cluster.Name {
+			continue
+		}
+		for _, alias := range aliases {
+			if _, ok := other.Labels[api.ClusterAliasLabelPrefix+alias]; ok {
+				return echo.NewHTTPError(http.StatusConflict, fmt.Sprintf("Alias '%s' is already used by cluster '%s'", alias, other.Name))
+			}
+			if other.Name == alias || other.Name == api.ClusterIDPrefix+alias {
+				return echo.NewHTTPError(http.StatusConflict, fmt.Sprintf("Alias '%s' is the ID of cluster '%s'", alias, other.Name))
+			}
+		}
+		if !isNew {
+			continue
+		}
+		for _, id := range []string{cluster.Name, strings.TrimPrefix(cluster.Name, api.ClusterIDPrefix)} {
+			if _, ok := other.Labels[api.ClusterAliasLabelPrefix+id]; ok {
+				return echo.NewHTTPError(http.StatusConflict, fmt.Sprintf("ID '%s' is an alias of cluster '%s'", cluster.Name, other.Name))
+			}
+		}
+	}
+	return nil
+}
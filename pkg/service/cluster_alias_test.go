@@ -0,0 +1,233 @@
+package service
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/testutil"
+	synv1alpha1 "github.com/projectsyn/lieutenant-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/projectsyn/lieutenant-api/pkg/api"
+)
+
+var aliasCluster = &synv1alpha1.Cluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "c-fragrant-sea-1234",
+		Namespace: "default",
+		Labels: map[string]string{
+			synv1alpha1.LabelNameTenant:               tenantA.Name,
+			api.ClusterAliasLabelPrefix + "acme-prod": "",
+		},
+	},
+	Spec: synv1alpha1.ClusterSpec{
+		DisplayName: "ACME Production",
+		TenantRef:   corev1.LocalObjectReference{Name: tenantA.Name},
+	},
+}
+
+func TestGetClusterByAlternativeID(t *testing.T) {
+	shared := func(name string) *synv1alpha1.Cluster {
+		return &synv1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: synv1alpha1.ClusterSpec{
+				DisplayName: "Shared Name",
+				TenantRef:   corev1.LocalObjectReference{Name: tenantA.Name},
+			},
+		}
+	}
+	e, _ := setupTest(t, aliasCluster, shared("c-shared-1"), shared("c-shared-2"))
+
+	tcs := map[string]struct {
+		id   string
+		code int
+		exp  string
+	}{
+		"ID":                 {id: aliasCluster.Name, code: http.StatusOK, exp: aliasCluster.Name},
+		"ID without prefix":  {id: "fragrant-sea-1234", code: http.StatusOK, exp: aliasCluster.Name},
+		"alias":              {id: "acme-prod", code: http.StatusOK, exp: aliasCluster.Name},
+		"display name slug":  {id: "acme-production", code: http.StatusOK, exp: aliasCluster.Name},
+		"ID before slug":     {id: clusterA.Name, code: http.StatusOK, exp: clusterA.Name},
+		"ambiguous slug":     {id: "shared-name", code: http.StatusConflict},
+		"unknown identifier": {id: "acme-staging", code: http.StatusNotFound},
+	}
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			result := testutil.NewRequest().
+				Get("/clusters/"+tc.id).
+				WithHeader(echo.HeaderAuthorization, bearerToken).
+				GoWithHTTPHandler(t, e)
+			requireHTTPCode(t, tc.code, result)
+			if tc.exp == "" {
+				return
+			}
+			cluster := &api.Cluster{}
+			require.NoError(t, result.UnmarshalJsonToObject(cluster))
+			assert.Equal(t, tc.exp, cluster.Id.String())
+		})
+	}
+}
+
+func TestGetClusterDuplicateAlias(t *testing.T) {
+	duplicate := aliasCluster.DeepCopy()
+	duplicate.Name = "c-duplicate-1234"
+	e, _ := setupTest(t, aliasCluster, duplicate)
+
+	result := testutil.NewRequest().
+		Get("/clusters/acme-prod").
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusConflict, result)
+}
+
+func TestChangeClusterByAlternativeID(t *testing.T) {
+	e, c := setupTest(t, aliasCluster)
+
+	result := testutil.NewRequest().
+		Patch("/clusters/acme-prod").
+		WithJsonBody(api.ClusterProperties{DisplayName: pointer.ToString("ACME Prod")}).
+		WithContentType(api.ContentJSONPatch).
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+	cluster := &synv1alpha1.Cluster{}
+	require.NoError(t, c.Get(t.Context(), client.ObjectKeyFromObject(aliasCluster), cluster))
+	assert.Equal(t, "ACME Prod", cluster.Spec.DisplayName)
+
+	// PUT updates the cluster the identifier refers to instead of creating a new one
+	result = testutil.NewRequest().
+		Put("/clusters/fragrant-sea-1234").
+		WithJsonBody(api.Cluster{
+			ClusterProperties: api.ClusterProperties{
+				DisplayName: pointer.ToString("ACME Production"),
+				Aliases:     &[]string{"acme-prod"},
+			},
+			ClusterTenant: api.ClusterTenant{Tenant: tenantA.Name},
+		}).
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+	updated := &api.Cluster{}
+	require.NoError(t, result.UnmarshalJsonToObject(updated))
+	assert.Equal(t, aliasCluster.Name, updated.Id.String())
+
+	result = testutil.NewRequest().
+		Delete("/clusters/acme-production").
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusNoContent, result)
+	err := c.Get(t.Context(), client.ObjectKeyFromObject(aliasCluster), cluster)
+	assert.True(t, apierrors.IsNotFound(err), "Cluster must be deleted, got %v", err)
+}
+
+func TestChangeClusterDuplicateAlias(t *testing.T) {
+	duplicate := aliasCluster.DeepCopy()
+	duplicate.Name = "c-duplicate-1234"
+	e, _ := setupTest(t, aliasCluster, duplicate)
+
+	result := testutil.NewRequest().
+		Patch("/clusters/acme-prod").
+		WithJsonBody(api.ClusterProperties{DisplayName: pointer.ToString("ACME Prod")}).
+		WithContentType(api.ContentJSONPatch).
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusConflict, result)
+}
+
+func TestUpdateClusterAliases(t *testing.T) {
+	e, c := setupTest(t, aliasCluster)
+
+	tcs := map[string]struct {
+		aliases []string
+		code    int
+	}{
+		"set aliases":                 {aliases: []string{"acme-dev", "dev"}, code: http.StatusOK},
+		"alias of other cluster":      {aliases: []string{"acme-prod"}, code: http.StatusConflict},
+		"ID of other cluster":         {aliases: []string{"fragrant-sea-1234"}, code: http.StatusConflict},
+		"invalid alias":               {aliases: []string{"ACME Dev"}, code: http.StatusBadRequest},
+		"remove aliases":              {aliases: []string{}, code: http.StatusOK},
+		"alias of the cluster itself": {aliases: []string{clusterB.Name}, code: http.StatusOK},
+	}
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			result := testutil.NewRequest().
+				Patch("/clusters/"+clusterB.Name).
+				WithJsonBody(api.ClusterProperties{Aliases: &tc.aliases}).
+				WithContentType(api.ContentJSONPatch).
+				WithHeader(echo.HeaderAuthorization, bearerToken).
+				GoWithHTTPHandler(t, e)
+			requireHTTPCode(t, tc.code, result)
+			if tc.code != http.StatusOK {
+				return
+			}
+			cluster := &synv1alpha1.Cluster{}
+			require.NoError(t, c.Get(t.Context(), client.ObjectKeyFromObject(clusterB), cluster))
+			assert.Equal(t, tc.aliases, api.ClusterAliases(*cluster))
+			assert.Equal(t, tenantB.Name, cluster.Labels[synv1alpha1.LabelNameTenant])
+		})
+	}
+}
+
+func TestCreateClusterAliasTaken(t *testing.T) {
+	e, _ := setupTest(t, aliasCluster)
+
+	result := testutil.NewRequest().
+		Post("/clusters").
+		WithJsonBody(api.Cluster{
+			ClusterProperties: api.ClusterProperties{Aliases: &[]string{"acme-prod"}},
+			ClusterTenant:     api.ClusterTenant{Tenant: tenantA.Name},
+		}).
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusConflict, result)
+}
+
+func TestListClustersByGitRepoURL(t *testing.T) {
+	e, _ := setupTest(t)
+
+	result := testutil.NewRequest().
+		Get("/clusters?gitRepoUrl="+url.QueryEscape(clusterA.Spec.GitRepoURL)).
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+	clusters := []api.Cluster{}
+	require.NoError(t, result.UnmarshalJsonToObject(&clusters))
+	require.Len(t, clusters, 1)
+	assert.Equal(t, clusterA.Name, clusters[0].Id.String())
+}
+
+func TestCreateClusterIDIsAlias(t *testing.T) {
+	cluster := aliasCluster.DeepCopy()
+	cluster.Labels[api.ClusterAliasLabelPrefix+"c-legacy"] = ""
+	e, _ := setupTest(t, cluster)
+
+	// Both the ID and the ID without prefix would hide the alias
+	for _, id := range []string{"c-acme-prod", "c-legacy"} {
+		t.Run(id, func(t *testing.T) {
+			result := testutil.NewRequest().
+				Post("/clusters").
+				WithJsonBody(api.Cluster{
+					ClusterId:     api.ClusterId{Id: pointer.To(api.Id(id))},
+					ClusterTenant: api.ClusterTenant{Tenant: tenantA.Name},
+				}).
+				WithHeader(echo.HeaderAuthorization, bearerToken).
+				GoWithHTTPHandler(t, e)
+			requireHTTPCode(t, http.StatusConflict, result)
+		})
+	}
+
+	result := testutil.NewRequest().
+		Put("/clusters/c-acme-prod").
+		WithJsonBody(api.Cluster{ClusterTenant: api.ClusterTenant{Tenant: tenantA.Name}}).
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusConflict, result)
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	synv1alpha1 "github.com/projectsyn/lieutenant-operator/api/v1alpha1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/projectsyn/lieutenant-api/pkg/api"
+)
+
+var errStatusFieldsInUpdate = echo.NewHTTPError(http.StatusBadRequest,
+	"dynamicFacts and compileMeta can't be updated with the cluster, use /clusters/{clusterId}/dynamicFacts and /clusters/{clusterId}/compileMeta instead")
+
+// patchLegacyDynamicFacts merges dynamic facts submitted with PATCH /clusters/{clusterId} into the status of the cluster.
+// Deployed Steward agents still submit their facts this way, so they're accepted from the cluster's own ServiceAccount
+// until Steward uses PUT /clusters/{clusterId}/dynamicFacts.
+func (s *APIImpl) patchLegacyDynamicFacts(ctx *APIContext, clusterID string, body api.DynamicClusterFacts) error {
+	if err := s.authorizeClusterAgent(ctx, clusterID, "dynamic facts"); err != nil {
+		return errStatusFieldsInUpdate
+	}
+	ctx.Logger().Warnf("cluster %s submitted dynamic facts with the deprecated PATCH /clusters/{clusterId}, use PUT /clusters/{clusterId}/dynamicFacts instead", clusterID)
+
+	facts, err := s.encodeDynamicFacts(ctx, body)
+	if err != nil {
+		return err
+	}
+	existing := &synv1alpha1.Cluster{}
+	if err := ctx.client.Get(ctx.Request().Context(), client.ObjectKey{Name: clusterID, Namespace: s.namespace}, existing); err != nil {
+		return err
+	}
+	merged := make(synv1alpha1.Facts, len(existing.Status.Facts)+len(facts))
+	maps.Copy(merged, existing.Status.Facts)
+	maps.Copy(merged, facts)
+	return s.writeDynamicFacts(ctx, existing, merged)
+}
+
+// authorizeStatus checks that the user making the request is allowed to patch the status subresource of the cluster
+func (s *APIImpl) authorizeStatus(ctx *APIContext, clusterID, field string) error {
+	allowed, err := ctx.accessAllowed(authorizationv1.ResourceAttributes{
+		Namespace:   s.namespace,
+		Verb:        "patch",
+		Group:       synv1alpha1.GroupVersion.Group,
+		Resource:    "clusters",
+		Subresource: "status",
+		Name:        clusterID,
+	})
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return echo.NewHTTPError(http.StatusForbidden,
+			fmt.Sprintf("Submitting %s requires the permission to patch clusters/status", field))
+	}
+	return nil
+}
@@ -173,7 +173,8 @@ func TestCreateCluster(t *testing.T) {
 	assert.Contains(t, cluster.Id.String(), api.ClusterIDPrefix)
 	assert.Equal(t, cluster.DisplayName, newCluster.DisplayName)
 	assert.Equal(t, newCluster.Facts, cluster.Facts)
-	assert.Equal(t, newCluster.DynamicFacts, cluster.DynamicFacts)
+	// Dynamic facts can only be set by the cluster itself
+	assert.Empty(t, cluster.DynamicFacts)
 	assert.Equal(t, newCluster.Tenant, cluster.Tenant)
 	assert.Equal(t, *newCluster.Annotations, *cluster.Annotations)
 }
@@ -457,6 +458,55 @@ func TestClusterUpdateIllegalDeployKey(t *testing.T) {
 	assert.Contains(t, reason.Reason, "Illegal deploy key format")
 }
 
+func TestClusterUpdateNonStringFacts(t *testing.T) {
+	e, c := setupTest(t)
+
+	result := testutil.NewRequest().
+		Patch("/clusters/"+clusterB.Name).
+		WithJsonBody(map[string]any{"facts": map[string]any{"nodes": 3, "zones": []string{"a"}}}).
+		WithContentType(api.ContentJSONPatch).
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+	cluster := &synv1alpha1.Cluster{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(clusterB), cluster))
+	assert.Equal(t, "3", cluster.Spec.Facts["nodes"])
+	assert.Equal(t, `["a"]`, cluster.Spec.Facts["zones"])
+	// Facts are returned with the types they were submitted with
+	apiCluster := &api.Cluster{}
+	require.NoError(t, result.UnmarshalJsonToObject(apiCluster))
+	assert.Equal(t, 3.0, (*apiCluster.Facts)["nodes"])
+	assert.Equal(t, []any{"a"}, (*apiCluster.Facts)["zones"])
+
+	result = testutil.NewRequest().
+		Patch("/clusters/"+clusterB.Name).
+		WithJsonBody(map[string]any{"facts": map[string]any{"cloud": nil}}).
+		WithContentType(api.ContentJSONPatch).
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusBadRequest, result)
+	reason := &api.Reason{}
+	require.NoError(t, result.UnmarshalJsonToObject(reason))
+	assert.Contains(t, reason.Reason, "'cloud'")
+}
+
+func TestCreateClusterNonStringAnnotations(t *testing.T) {
+	e, _ := setupTest(t)
+
+	result := testutil.NewRequest().
+		Post("/clusters").
+		WithJsonBody(map[string]any{
+			"tenant":      tenantA.Name,
+			"annotations": map[string]any{"monitoring": map[string]any{"sla": "247"}, "replicas": 2, "owner": "team-a"},
+		}).
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusBadRequest, result)
+	reason := &api.Reason{}
+	require.NoError(t, result.UnmarshalJsonToObject(reason))
+	assert.Contains(t, reason.Reason, "'monitoring', 'replicas'")
+}
+
 func TestClusterUpdateNotManagedDeployKey(t *testing.T) {
 	e, _ := setupTest(t)
 
@@ -492,10 +542,6 @@ func TestClusterUpdate(t *testing.T) {
 		Facts: &api.ClusterFacts{
 			"some": "fact",
 		},
-		DynamicFacts: &api.DynamicClusterFacts{
-			"dynamic": "fact",
-			"complex": struct{ name string }{name: "fact"},
-		},
 		Annotations: &api.Annotations{
 			"existing":   "",
 			"additional": "value",
@@ -522,10 +568,6 @@ func TestClusterUpdate(t *testing.T) {
 	assert.Len(t, *cluster.Annotations, 2)
 	assert.Equal(t, "my-global-revision", pointer.GetString(cluster.GlobalGitRepoRevision))
 	assert.Equal(t, "my-tenant-revision", pointer.GetString(cluster.TenantGitRepoRevision))
-
-	require.NotNil(t, cluster.DynamicFacts)
-	assert.Contains(t, *cluster.DynamicFacts, "dynamic")
-	assert.Contains(t, *cluster.DynamicFacts, "complex")
 }
 
 func TestClusterUpdateDisplayName(t *testing.T) {
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	synv1alpha1 "github.com/projectsyn/lieutenant-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/projectsyn/lieutenant-api/pkg/api"
+	"github.com/projectsyn/lieutenant-api/pkg/inventory"
+)
+
+const (
+	// CompileMetaHistorySizeEnvVar is the env var name that's used to get the number of compile meta reports kept per cluster
+	CompileMetaHistorySizeEnvVar = "COMPILE_META_HISTORY_SIZE"
+
+	defaultCompileMetaHistorySize = 100
+)
+
+var errCompileMetaOutOfOrder = echo.NewHTTPError(http.StatusConflict, "Reported compilation is older than the stored one")
+
+// PostClusterCompileMeta stores the meta data of the last compilation of a cluster and records it in the history
+func (s *APIImpl) PostClusterCompileMeta(c echo.Context, clusterID api.ClusterIdParameter) error {
+	ctx := c.(*APIContext)
+
+	body := &api.ClusterCompileMeta{}
+	if err := ctx.Bind(body); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+	compileMeta, err := api.APICompileMetaToCRDCompileMeta(body)
+	if err != nil {
+		return err
+	}
+	if err := s.authorizeStatus(ctx, string(clusterID), "compilation metadata"); err != nil {
+		return err
+	}
+
+	cluster := &synv1alpha1.Cluster{}
+	if err := ctx.client.Get(ctx.Request().Context(), client.ObjectKey{Name: string(clusterID), Namespace: s.namespace}, cluster); err != nil {
+		return err
+	}
+
+	store := func() error {
+		if !compileMetaInOrder(cluster.Status.CompileMeta, compileMeta) {
+			return errCompileMetaOutOfOrder
+		}
+		orig := cluster.DeepCopy()
+		cluster.Status.CompileMeta = compileMeta
+		return ctx.client.Status().Patch(ctx.Request().Context(), cluster, client.MergeFromWithOptions(orig, client.MergeFromWithOptimisticLock{}))
+	}
+
+	err = store()
+	if errors.IsConflict(err) {
+		if err := ctx.client.Get(ctx.Request().Context(), client.ObjectKeyFromObject(cluster), cluster); err != nil {
+			return err
+		}
+		err = store()
+	}
+	if err != nil {
+		return err
+	}
+
+	s.recordCompileMeta(ctx, string(clusterID), body)
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// GetClusterCompileMetaHistory returns the compile meta data reported for the cluster
+func (s *APIImpl) GetClusterCompileMetaHistory(c echo.Context, clusterID api.ClusterIdParameter) error {
+	ctx := c.(*APIContext)
+	if s.inventory == nil {
+		return errInventoryNotConfigured
+	}
+
+	// The caller must be able to see the cluster to see its history
+	cluster := &synv1alpha1.Cluster{}
+	if err := s.getCluster(ctx, string(clusterID), cluster); err != nil {
+		return err
+	}
+
+	reports, err := s.inventory.CompileMetaReports(ctx.Request().Context(), string(clusterID), compileMetaHistorySize())
+	if err != nil {
+		return err
+	}
+	history := make([]api.ClusterCompileMeta, 0, len(reports))
+	for _, report := range reports {
+		meta := api.ClusterCompileMeta{}
+		if err := json.Unmarshal([]byte(report.Meta), &meta); err != nil {
+			return err
+		}
+		history = append(history, meta)
+	}
+	return ctx.JSON(http.StatusOK, history)
+}
+
+// recordCompileMeta adds the reported compile meta data to the history of the cluster.
+// The compile meta data is already written at this point, so errors are only logged.
+func (s *APIImpl) recordCompileMeta(ctx *APIContext, cluster string, compileMeta *api.ClusterCompileMeta) {
+	if s.inventory == nil {
+		return
+	}
+	raw, err := json.Marshal(compileMeta)
+	if err != nil {
+		ctx.Logger().Errorf("failed to marshal compile meta of cluster %s: %v", cluster, err)
+		return
+	}
+	report := inventory.CompileMetaReport{
+		Cluster:     cluster,
+		LastCompile: time.Now().UTC(),
+		Meta:        string(raw),
+	}
+	if compileMeta.LastCompile != nil {
+		report.LastCompile = compileMeta.LastCompile.UTC()
+	}
+	if err := s.inventory.RecordCompileMeta(ctx.Request().Context(), report, compileMetaHistorySize()); err != nil {
+		ctx.Logger().Errorf("failed to record compile meta of cluster %s: %v", cluster, err)
+	}
+}
+
+// compileMetaInOrder returns false if the reported compilation is older than the stored one.
+// Reports without a compile time are only accepted if the stored compile meta data doesn't have one either.
+func compileMetaInOrder(stored, reported synv1alpha1.CompileMeta) bool {
+	if stored.LastCompile.IsZero() {
+		return true
+	}
+	return !reported.LastCompile.IsZero() && !reported.LastCompile.Before(&stored.LastCompile)
+}
+
+func compileMetaHistorySize() int {
+	size, err := strconv.Atoi(os.Getenv(CompileMetaHistorySizeEnvVar))
+	if err != nil || size <= 0 {
+		return defaultCompileMetaHistorySize
+	}
+	return size
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/testutil"
+	synv1alpha1 "github.com/projectsyn/lieutenant-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/projectsyn/lieutenant-api/pkg/api"
+)
+
+var lastCompile = time.Date(2024, time.April, 14, 21, 5, 56, 0, time.UTC)
+
+func postCompileMeta(t *testing.T, e *echo.Echo, cluster string, compiled time.Time, version string) *testutil.CompletedRequest {
+	return testutil.NewRequest().
+		Post("/"+path.Join("clusters", cluster, "compileMeta")).
+		WithJsonBody(map[string]any{
+			"commodoreBuildInfo": map[string]any{"version": version},
+			"lastCompile":        compiled.Format(time.RFC3339),
+		}).
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+}
+
+func getCompileMetaHistory(t *testing.T, e *echo.Echo, cluster string) []api.ClusterCompileMeta {
+	result := testutil.NewRequest().
+		Get("/"+path.Join("clusters", cluster, "compileMeta", "history")).
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+	history := []api.ClusterCompileMeta{}
+	require.NoError(t, result.UnmarshalJsonToObject(&history))
+	return history
+}
+
+func buildVersions(history []api.ClusterCompileMeta) []string {
+	versions := make([]string, 0, len(history))
+	for _, meta := range history {
+		versions = append(versions, (*meta.CommodoreBuildInfo)["version"])
+	}
+	return versions
+}
+
+func TestGetClusterCompileMetaHistory(t *testing.T) {
+	e, _ := setupTest(t)
+
+	requireHTTPCode(t, http.StatusNoContent, postCompileMeta(t, e, clusterA.Name, lastCompile, "1.0.0"))
+	requireHTTPCode(t, http.StatusNoContent, postCompileMeta(t, e, clusterA.Name, lastCompile.Add(time.Hour), "1.1.0"))
+	// Reporting the same compilation again is accepted
+	requireHTTPCode(t, http.StatusNoContent, postCompileMeta(t, e, clusterA.Name, lastCompile.Add(time.Hour), "1.1.0"))
+
+	history := getCompileMetaHistory(t, e, clusterA.Name)
+	assert.Equal(t, []string{"1.0.0", "1.1.0", "1.1.0"}, buildVersions(history))
+	require.NotNil(t, history[0].LastCompile)
+	assert.True(t, lastCompile.Equal(*history[0].LastCompile))
+
+	assert.Empty(t, getCompileMetaHistory(t, e, clusterB.Name))
+}
+
+func TestGetClusterCompileMetaHistoryBounded(t *testing.T) {
+	t.Setenv(CompileMetaHistorySizeEnvVar, "2")
+	e, _ := setupTest(t)
+
+	for i, version := range []string{"1.0.0", "1.1.0", "1.2.0"} {
+		requireHTTPCode(t, http.StatusNoContent, postCompileMeta(t, e, clusterA.Name, lastCompile.Add(time.Duration(i)*time.Hour), version))
+	}
+	assert.Equal(t, []string{"1.1.0", "1.2.0"}, buildVersions(getCompileMetaHistory(t, e, clusterA.Name)))
+}
+
+func TestGetClusterCompileMetaHistoryNotFound(t *testing.T) {
+	e, _ := setupTest(t)
+
+	result := testutil.NewRequest().
+		Get("/clusters/c-not-existing/compileMeta/history").
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusNotFound, result)
+}
+
+func TestClusterPostCompileMetaOutOfOrder(t *testing.T) {
+	cluster := &synv1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "c-compile-meta",
+			Namespace: "default",
+		},
+		Status: synv1alpha1.ClusterStatus{
+			CompileMeta: synv1alpha1.CompileMeta{
+				CommodoreBuildInfo: map[string]string{"version": "1.1.0"},
+				LastCompile:        metav1.NewTime(lastCompile),
+			},
+		},
+	}
+	e, c := setupTest(t, cluster)
+
+	result := postCompileMeta(t, e, cluster.Name, lastCompile.Add(-time.Hour), "1.0.0")
+	requireHTTPCode(t, http.StatusConflict, result)
+	reason := &api.Reason{}
+	require.NoError(t, result.UnmarshalJsonToObject(reason))
+	assert.Contains(t, reason.Reason, "older than the stored one")
+
+	// Reports without compile time can't be ordered
+	result = testutil.NewRequest().
+		Post("/"+path.Join("clusters", cluster.Name, "compileMeta")).
+		WithJsonBody(map[string]any{"commodoreBuildInfo": map[string]any{"version": "1.2.0"}}).
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusConflict, result)
+
+	require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(cluster), cluster))
+	assert.Equal(t, "1.1.0", cluster.Status.CompileMeta.CommodoreBuildInfo["version"])
+	assert.Empty(t, getCompileMetaHistory(t, e, cluster.Name))
+}
+
+func TestCompileMetaInOrder(t *testing.T) {
+	older := synv1alpha1.CompileMeta{LastCompile: metav1.NewTime(lastCompile)}
+	newer := synv1alpha1.CompileMeta{LastCompile: metav1.NewTime(lastCompile.Add(time.Minute))}
+
+	assert.True(t, compileMetaInOrder(older, newer))
+	assert.True(t, compileMetaInOrder(older, older))
+	assert.False(t, compileMetaInOrder(newer, older))
+	assert.True(t, compileMetaInOrder(synv1alpha1.CompileMeta{}, older))
+	assert.True(t, compileMetaInOrder(synv1alpha1.CompileMeta{}, synv1alpha1.CompileMeta{}))
+	assert.False(t, compileMetaInOrder(older, synv1alpha1.CompileMeta{}))
+}
+
+func TestPostClusterCompileMetaForbidden(t *testing.T) {
+	e, c := setupTest(t)
+
+	for _, token := range []string{unprivilegedBearerToken, serviceAccountBearerToken(clusterA.Name)} {
+		result := testutil.NewRequest().
+			Post("/"+path.Join("clusters", clusterA.Name, "compileMeta")).
+			WithJsonBody(map[string]any{
+				"commodoreBuildInfo": map[string]any{"version": "forged"},
+				"lastCompile":        lastCompile.Format(time.RFC3339),
+			}).
+			WithHeader(echo.HeaderAuthorization, token).
+			GoWithHTTPHandler(t, e)
+		requireHTTPCode(t, http.StatusForbidden, result)
+	}
+
+	cluster := &synv1alpha1.Cluster{}
+	require.NoError(t, c.Get(t.Context(), client.ObjectKeyFromObject(clusterA), cluster))
+	assert.Equal(t, clusterA.Status.CompileMeta, cluster.Status.CompileMeta)
+	assert.Empty(t, getCompileMetaHistory(t, e, clusterA.Name))
+}
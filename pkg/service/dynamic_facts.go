@@ -0,0 +1,125 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/labstack/echo/v4"
+	synv1alpha1 "github.com/projectsyn/lieutenant-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/projectsyn/lieutenant-api/pkg/api"
+)
+
+const (
+	// DynamicFactsSchemaConfigMapEnvVar is the env var name that's used to get the ConfigMap containing the schema for dynamic facts
+	DynamicFactsSchemaConfigMapEnvVar = "DYNAMIC_FACTS_SCHEMA_CONFIGMAP"
+
+	dynamicFactsSchemaConfigMapDefault = "dynamic-facts-schema"
+)
+
+// PutClusterDynamicFacts replaces the dynamic facts of a cluster
+func (s *APIImpl) PutClusterDynamicFacts(c echo.Context, clusterID api.ClusterIdParameter) error {
+	ctx := c.(*APIContext)
+
+	// Bind would add the path parameters to the facts
+	body := api.DynamicClusterFacts{}
+	if err := json.NewDecoder(ctx.Request().Body).Decode(&body); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+
+	if err := s.authorizeClusterAgent(ctx, string(clusterID), "dynamic facts"); err != nil {
+		return err
+	}
+	facts, err := s.encodeDynamicFacts(ctx, body)
+	if err != nil {
+		return err
+	}
+
+	existing := &synv1alpha1.Cluster{}
+	if err := ctx.client.Get(ctx.Request().Context(), client.ObjectKey{Name: string(clusterID), Namespace: s.namespace}, existing); err != nil {
+		return err
+	}
+	if err := s.writeDynamicFacts(ctx, existing, facts); err != nil {
+		return err
+	}
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// encodeDynamicFacts validates the dynamic facts and encodes them the way they're stored in the status of the cluster
+func (s *APIImpl) encodeDynamicFacts(ctx *APIContext, body api.DynamicClusterFacts) (synv1alpha1.Facts, error) {
+	if err := s.validateDynamicFacts(ctx, body); err != nil {
+		return nil, err
+	}
+	facts := synv1alpha1.Facts{}
+	for key, value := range body {
+		encodedFact, err := json.Marshal(value)
+		if err != nil {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, err)
+		}
+		facts[key] = string(encodedFact)
+	}
+	return facts, nil
+}
+
+// writeDynamicFacts replaces the dynamic facts in the status of the cluster and records the changes
+func (s *APIImpl) writeDynamicFacts(ctx *APIContext, existing *synv1alpha1.Cluster, facts synv1alpha1.Facts) error {
+	toPatch := &synv1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      existing.Name,
+			Namespace: s.namespace,
+		},
+	}
+	patch := []map[string]any{
+		{"op": "replace", "path": "/status/facts", "value": facts},
+	}
+	rawPatch, err := json.Marshal(patch)
+	if err != nil {
+		return fmt.Errorf("failed to marshal patch: %w", err)
+	}
+
+	if err := retryOnConflict(func() error {
+		return ctx.client.Status().Patch(ctx.Request().Context(), toPatch, client.RawPatch(types.JSONPatchType, rawPatch))
+	}); err != nil {
+		return err
+	}
+	s.recordFactChanges(ctx, existing.Name, existing.Status.Facts, facts)
+	return nil
+}
+
+// authorizeClusterAgent checks that the request is made with the token of the cluster's own ServiceAccount.
+// The permission to patch the status of the cluster isn't enough, the operator grants it to all ServiceAccounts of the tenant.
+func (s *APIImpl) authorizeClusterAgent(ctx *APIContext, clusterID, field string) error {
+	user, err := ctx.userInfo()
+	if err != nil {
+		return err
+	}
+	if user.Username != serviceAccountUsername(s.namespace, clusterID) {
+		return echo.NewHTTPError(http.StatusForbidden,
+			fmt.Sprintf("Only the ServiceAccount of cluster '%s' may submit %s", clusterID, field))
+	}
+	return nil
+}
+
+// validateDynamicFacts validates the facts against the schema configured in the API's namespace.
+// Facts aren't validated if the default ConfigMap doesn't exist.
+func (s *APIImpl) validateDynamicFacts(ctx *APIContext, facts api.DynamicClusterFacts) error {
+	name, optional := os.Getenv(DynamicFactsSchemaConfigMapEnvVar), false
+	if name == "" {
+		name, optional = dynamicFactsSchemaConfigMapDefault, true
+	}
+	schema, err := s.schemaFromConfigMap(ctx, name, optional)
+	if err != nil || schema == nil {
+		return err
+	}
+	return validateSchema(schema, facts, "Dynamic facts")
+}
+
+// serviceAccountUsername returns the username Kubernetes assigns to tokens of the ServiceAccount
+func serviceAccountUsername(namespace, name string) string {
+	return fmt.Sprintf("system:serviceaccount:%s:%s", namespace, name)
+}
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/testutil"
+	synv1alpha1 "github.com/projectsyn/lieutenant-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/projectsyn/lieutenant-api/pkg/api"
+)
+
+var dynamicFactsSchema = &corev1.ConfigMap{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      dynamicFactsSchemaConfigMapDefault,
+		Namespace: "default",
+	},
+	Data: map[string]string{
+		schemaKey: `
+type: object
+properties:
+  kubernetesVersion:
+    type: object
+    required: [major, minor]
+    properties:
+      major:
+        type: string
+      minor:
+        type: string
+`,
+	},
+}
+
+func TestPutClusterDynamicFacts(t *testing.T) {
+	e, c := setupTest(t)
+
+	result := testutil.NewRequest().
+		Put("/clusters/"+clusterA.Name+"/dynamicFacts").
+		WithJsonBody(api.DynamicClusterFacts{
+			"kubernetesVersion": map[string]any{"major": "1", "minor": "29"},
+		}).
+		WithHeader(echo.HeaderAuthorization, serviceAccountBearerToken(clusterA.Name)).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusNoContent, result)
+
+	cluster := &synv1alpha1.Cluster{}
+	require.NoError(t, c.Get(t.Context(), client.ObjectKeyFromObject(clusterA), cluster))
+	assert.Equal(t, synv1alpha1.Facts{
+		"kubernetesVersion": `{"major":"1","minor":"29"}`,
+	}, cluster.Status.Facts)
+}
+
+func TestPutClusterDynamicFactsForbidden(t *testing.T) {
+	tcs := map[string]string{
+		"unprivileged user": unprivilegedBearerToken,
+		"status writer":     bearerToken,
+		"other cluster":     serviceAccountBearerToken(clusterB.Name),
+	}
+	for name, token := range tcs {
+		t.Run(name, func(t *testing.T) {
+			e, c := setupTest(t)
+
+			result := testutil.NewRequest().
+				Put("/clusters/"+clusterA.Name+"/dynamicFacts").
+				WithJsonBody(api.DynamicClusterFacts{"kubernetesVersion": "forged"}).
+				WithHeader(echo.HeaderAuthorization, token).
+				GoWithHTTPHandler(t, e)
+			requireHTTPCode(t, http.StatusForbidden, result)
+
+			cluster := &synv1alpha1.Cluster{}
+			require.NoError(t, c.Get(t.Context(), client.ObjectKeyFromObject(clusterA), cluster))
+			assert.NotContains(t, cluster.Status.Facts, "kubernetesVersion")
+		})
+	}
+}
+
+func TestPutClusterDynamicFactsSchema(t *testing.T) {
+	tcs := map[string]struct {
+		facts  api.DynamicClusterFacts
+		code   int
+		reason string
+	}{
+		"valid": {
+			facts: api.DynamicClusterFacts{
+				"kubernetesVersion": map[string]any{"major": "1", "minor": "29"},
+				"unknown":           "allowed",
+			},
+			code: http.StatusNoContent,
+		},
+		"wrong type": {
+			facts: api.DynamicClusterFacts{
+				"kubernetesVersion": "1.29",
+			},
+			code:   http.StatusUnprocessableEntity,
+			reason: "kubernetesVersion",
+		},
+		"missing property": {
+			facts: api.DynamicClusterFacts{
+				"kubernetesVersion": map[string]any{"major": "1"},
+			},
+			code:   http.StatusUnprocessableEntity,
+			reason: "minor",
+		},
+	}
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			e, _ := setupTest(t, dynamicFactsSchema)
+
+			result := testutil.NewRequest().
+				Put("/clusters/"+clusterA.Name+"/dynamicFacts").
+				WithJsonBody(tc.facts).
+				WithHeader(echo.HeaderAuthorization, serviceAccountBearerToken(clusterA.Name)).
+				GoWithHTTPHandler(t, e)
+			requireHTTPCode(t, tc.code, result)
+			if tc.reason == "" {
+				return
+			}
+			reason := &api.Reason{}
+			require.NoError(t, result.UnmarshalJsonToObject(reason))
+			assert.Contains(t, reason.Reason, tc.reason)
+		})
+	}
+}
+
+func TestUpdateClusterStatusFieldsRejected(t *testing.T) {
+	tcs := map[string]struct {
+		properties api.ClusterProperties
+		token      string
+	}{
+		"dynamic facts": {
+			properties: api.ClusterProperties{
+				DisplayName:  pointer.ToString("Forged"),
+				DynamicFacts: &api.DynamicClusterFacts{"kubernetesVersion": "forged"},
+			},
+			token: bearerToken,
+		},
+		"compile meta": {
+			properties: api.ClusterProperties{
+				DisplayName: pointer.ToString("Forged"),
+				CompileMeta: &api.ClusterCompileMeta{LastCompile: pointer.ToTime(time.Now())},
+			},
+			token: serviceAccountBearerToken(clusterA.Name),
+		},
+	}
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			e, c := setupTest(t)
+
+			result := testutil.NewRequest().
+				Patch("/clusters/"+clusterA.Name).
+				WithJsonBody(tc.properties).
+				WithContentType(api.ContentJSONPatch).
+				WithHeader(echo.HeaderAuthorization, tc.token).
+				GoWithHTTPHandler(t, e)
+			requireHTTPCode(t, http.StatusBadRequest, result)
+
+			cluster := &synv1alpha1.Cluster{}
+			require.NoError(t, c.Get(t.Context(), client.ObjectKeyFromObject(clusterA), cluster))
+			assert.Equal(t, clusterA.Spec.DisplayName, cluster.Spec.DisplayName)
+			assert.Equal(t, clusterA.Status.Facts, cluster.Status.Facts)
+			assert.Equal(t, clusterA.Status.CompileMeta, cluster.Status.CompileMeta)
+		})
+	}
+}
+
+func TestUpdateClusterDynamicFactsFromAgent(t *testing.T) {
+	e, c := setupTest(t)
+	existing := &synv1alpha1.Cluster{}
+	require.NoError(t, c.Get(t.Context(), client.ObjectKeyFromObject(clusterA), existing))
+	existing.Status.Facts = synv1alpha1.Facts{"distribution": `"k3s"`}
+	require.NoError(t, c.Status().Update(t.Context(), existing))
+
+	// Deployed Steward agents still submit their dynamic facts this way
+	result := testutil.NewRequest().
+		Patch("/clusters/"+clusterA.Name).
+		WithJsonBody(api.ClusterProperties{
+			DynamicFacts: &api.DynamicClusterFacts{"kubernetesVersion": map[string]any{"major": "1", "minor": "29"}},
+		}).
+		WithContentType(api.ContentJSONPatch).
+		WithHeader(echo.HeaderAuthorization, serviceAccountBearerToken(clusterA.Name)).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+	apiCluster := &api.Cluster{}
+	require.NoError(t, result.UnmarshalJsonToObject(apiCluster))
+	require.NotNil(t, apiCluster.DynamicFacts)
+	assert.Contains(t, *apiCluster.DynamicFacts, "kubernetesVersion")
+
+	cluster := &synv1alpha1.Cluster{}
+	require.NoError(t, c.Get(t.Context(), client.ObjectKeyFromObject(clusterA), cluster))
+	assert.Equal(t, synv1alpha1.Facts{
+		"distribution":      `"k3s"`,
+		"kubernetesVersion": `{"major":"1","minor":"29"}`,
+	}, cluster.Status.Facts, "Submitted facts must be merged into the existing ones")
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/labstack/echo/v4"
+	synv1alpha1 "github.com/projectsyn/lieutenant-operator/api/v1alpha1"
+
+	"github.com/projectsyn/lieutenant-api/pkg/api"
+	"github.com/projectsyn/lieutenant-api/pkg/inventory"
+)
+
+// GetClusterFactsHistory returns the changes of the cluster's dynamic facts
+func (s *APIImpl) GetClusterFactsHistory(c echo.Context, clusterID api.ClusterIdParameter, params api.GetClusterFactsHistoryParams) error {
+	ctx := c.(*APIContext)
+	if s.inventory == nil {
+		return errInventoryNotConfigured
+	}
+
+	query := inventory.FactChangeQuery{
+		Cluster: string(clusterID),
+		Key:     pointer.GetString(params.Key),
+		From:    pointer.GetTime(params.From),
+		To:      pointer.GetTime(params.To),
+	}
+	if !query.From.IsZero() && !query.To.IsZero() && query.To.Before(query.From) {
+		return echo.NewHTTPError(http.StatusBadRequest, "Parameter 'to' must not be before 'from'")
+	}
+
+	// The caller must be able to see the cluster to see its history
+	cluster := &synv1alpha1.Cluster{}
+	if err := s.getCluster(ctx, string(clusterID), cluster); err != nil {
+		return err
+	}
+
+	changes, err := s.inventory.FactChanges(ctx.Request().Context(), query)
+	if err != nil {
+		return err
+	}
+	history := make([]api.FactChange, 0, len(changes))
+	for _, change := range changes {
+		history = append(history, api.FactChange{
+			Key:       change.Key,
+			OldValue:  factValue(change.Old),
+			NewValue:  factValue(change.New),
+			Timestamp: change.Timestamp,
+			Writer:    change.Writer,
+		})
+	}
+	return ctx.JSON(http.StatusOK, history)
+}
+
+// recordFactChanges stores the differences between the old and new dynamic facts of the cluster.
+// The facts are already written at this point, so errors are only logged.
+func (s *APIImpl) recordFactChanges(ctx *APIContext, cluster string, oldFacts, newFacts synv1alpha1.Facts) {
+	if s.inventory == nil {
+		return
+	}
+	changes := diffFacts(oldFacts, newFacts)
+	if len(changes) == 0 {
+		return
+	}
+
+	writer := ""
+	if user, err := ctx.userInfo(); err == nil {
+		writer = user.Username
+	}
+	now := time.Now().UTC()
+	for i := range changes {
+		changes[i].Cluster = cluster
+		changes[i].Timestamp = now
+		changes[i].Writer = writer
+	}
+	if err := s.inventory.RecordFactChanges(ctx.Request().Context(), changes); err != nil {
+		ctx.Logger().Errorf("failed to record fact changes of cluster %s: %v", cluster, err)
+	}
+}
+
+// diffFacts returns the added, changed and removed facts ordered by key
+func diffFacts(oldFacts, newFacts synv1alpha1.Facts) []inventory.FactChange {
+	changes := []inventory.FactChange{}
+	for key, newValue := range newFacts {
+		oldValue, ok := oldFacts[key]
+		if ok && oldValue == newValue {
+			continue
+		}
+		change := inventory.FactChange{Key: key, New: pointer.ToString(newValue)}
+		if ok {
+			change.Old = pointer.ToString(oldValue)
+		}
+		changes = append(changes, change)
+	}
+	for key, oldValue := range oldFacts {
+		if _, ok := newFacts[key]; !ok {
+			changes = append(changes, inventory.FactChange{Key: key, Old: pointer.ToString(oldValue)})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Key < changes[j].Key
+	})
+	return changes
+}
+
+// factValue decodes the recorded value of a dynamic fact, nil if the fact didn't exist
+func factValue(raw *string) any {
+	if raw == nil {
+		return nil
+	}
+	return api.UnmarshalFact(*raw)
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/testutil"
+	synv1alpha1 "github.com/projectsyn/lieutenant-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/projectsyn/lieutenant-api/pkg/api"
+	"github.com/projectsyn/lieutenant-api/pkg/inventory"
+)
+
+func putDynamicFacts(t *testing.T, e *echo.Echo, facts api.DynamicClusterFacts) {
+	result := testutil.NewRequest().
+		Put("/clusters/"+clusterA.Name+"/dynamicFacts").
+		WithJsonBody(facts).
+		WithHeader(echo.HeaderAuthorization, serviceAccountBearerToken(clusterA.Name)).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusNoContent, result)
+}
+
+func getFactsHistory(t *testing.T, e *echo.Echo, query string) []api.FactChange {
+	result := testutil.NewRequest().
+		Get("/clusters/"+clusterA.Name+"/facts/history?"+query).
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+	history := []api.FactChange{}
+	require.NoError(t, result.UnmarshalJsonToObject(&history))
+	return history
+}
+
+func TestGetClusterFactsHistory(t *testing.T) {
+	e, _ := setupTest(t)
+
+	putDynamicFacts(t, e, api.DynamicClusterFacts{
+		"escaped":           "fact",
+		"kubernetesVersion": map[string]any{"minor": "28"},
+	})
+	between := time.Now()
+	time.Sleep(time.Millisecond)
+	putDynamicFacts(t, e, api.DynamicClusterFacts{
+		"kubernetesVersion": map[string]any{"minor": "29"},
+	})
+
+	history := getFactsHistory(t, e, "")
+	require.Len(t, history, 3)
+	writer := serviceAccountUsername("default", clusterA.Name)
+	assert.Equal(t, api.FactChange{
+		Key:       "kubernetesVersion",
+		NewValue:  map[string]any{"minor": "28"},
+		Timestamp: history[0].Timestamp,
+		Writer:    writer,
+	}, history[0])
+	assert.Equal(t, api.FactChange{
+		Key:       "escaped",
+		OldValue:  "fact",
+		Timestamp: history[1].Timestamp,
+		Writer:    writer,
+	}, history[1])
+	assert.Equal(t, api.FactChange{
+		Key:       "kubernetesVersion",
+		OldValue:  map[string]any{"minor": "28"},
+		NewValue:  map[string]any{"minor": "29"},
+		Timestamp: history[2].Timestamp,
+		Writer:    writer,
+	}, history[2])
+
+	history = getFactsHistory(t, e, "key=kubernetesVersion")
+	assert.Len(t, history, 2)
+	history = getFactsHistory(t, e, "from="+url.QueryEscape(between.Format(time.RFC3339Nano)))
+	assert.Len(t, history, 2)
+	history = getFactsHistory(t, e, "to="+url.QueryEscape(between.Format(time.RFC3339Nano)))
+	assert.Len(t, history, 1)
+}
+
+func TestGetClusterFactsHistoryIgnoresPut(t *testing.T) {
+	e, c := setupTest(t)
+
+	result := testutil.NewRequest().
+		Put("/clusters/"+clusterA.Name).
+		WithJsonBody(api.Cluster{
+			ClusterProperties: api.ClusterProperties{
+				DisplayName:  pointer.ToString("New name"),
+				DynamicFacts: &api.DynamicClusterFacts{"nodes": 3},
+				CompileMeta:  &api.ClusterCompileMeta{LastCompile: pointer.ToTime(time.Now())},
+			},
+			ClusterTenant: api.ClusterTenant{Tenant: tenantA.Name},
+		}).
+		WithHeader(echo.HeaderAuthorization, serviceAccountBearerToken(clusterA.Name)).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+
+	// The status can only be changed through the dedicated endpoints
+	cluster := &synv1alpha1.Cluster{}
+	require.NoError(t, c.Get(t.Context(), client.ObjectKeyFromObject(clusterA), cluster))
+	assert.Equal(t, "New name", cluster.Spec.DisplayName)
+	assert.Equal(t, clusterA.Status.Facts, cluster.Status.Facts)
+	assert.Equal(t, clusterA.Status.CompileMeta, cluster.Status.CompileMeta)
+	assert.Empty(t, getFactsHistory(t, e, ""))
+}
+
+func TestGetClusterFactsHistoryNotFound(t *testing.T) {
+	e, _ := setupTest(t)
+
+	result := testutil.NewRequest().
+		Get("/clusters/c-not-existing/facts/history").
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusNotFound, result)
+}
+
+func TestDiffFacts(t *testing.T) {
+	changes := diffFacts(synv1alpha1.Facts{
+		"removed":   "1",
+		"changed":   "2",
+		"unchanged": "3",
+	}, synv1alpha1.Facts{
+		"changed":   "20",
+		"unchanged": "3",
+		"added":     "4",
+	})
+	assert.Equal(t, []inventory.FactChange{
+		{Key: "added", New: pointer.ToString("4")},
+		{Key: "changed", Old: pointer.ToString("2"), New: pointer.ToString("20")},
+		{Key: "removed", Old: pointer.ToString("1")},
+	}, changes)
+	assert.Empty(t, diffFacts(nil, nil))
+}
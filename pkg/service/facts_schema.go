@@ -0,0 +1,205 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+	synv1alpha1 "github.com/projectsyn/lieutenant-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/projectsyn/lieutenant-api/pkg/api"
+)
+
+const (
+	// FactsSchemaConfigMapAnnotation selects the ConfigMap containing the schema for the facts of the tenant's clusters
+	FactsSchemaConfigMapAnnotation = "lieutenant.syn.tools/facts-schema-configmap"
+	// FactsSchemaConfigMapEnvVar is the env var name that's used to get the ConfigMap containing the schema for the facts of all clusters
+	FactsSchemaConfigMapEnvVar = "FACTS_SCHEMA_CONFIGMAP"
+
+	factsSchemaConfigMapDefault = "facts-schema"
+	schemaKey                   = "schema"
+)
+
+// GetTenantFactsSchema returns the schemas the facts of the tenant's clusters are validated against
+func (s *APIImpl) GetTenantFactsSchema(c echo.Context, tenantID api.TenantIdParameter) error {
+	ctx := c.(*APIContext)
+
+	tenant := &synv1alpha1.Tenant{}
+	if err := ctx.client.Get(ctx.Request().Context(), client.ObjectKey{Name: string(tenantID), Namespace: s.namespace}, tenant); err != nil {
+		return err
+	}
+	instanceSchema, tenantSchema, err := s.factsSchemas(ctx, tenant)
+	if err != nil {
+		return err
+	}
+	res := api.FactsSchema{}
+	if res.Instance, err = schemaObject(instanceSchema); err != nil {
+		return err
+	}
+	if res.Tenant, err = schemaObject(tenantSchema); err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, res)
+}
+
+// validateFacts validates the static facts of the cluster against the schemas of the instance and the cluster's tenant.
+// The facts are validated with the types the API returns them with. The facts managed by the API aren't validated.
+func (s *APIImpl) validateFacts(ctx *APIContext, cluster *synv1alpha1.Cluster) error {
+	tenant := &synv1alpha1.Tenant{}
+	err := ctx.client.Get(ctx.Request().Context(), client.ObjectKey{Name: cluster.Spec.TenantRef.Name, Namespace: s.namespace}, tenant)
+	if client.IgnoreNotFound(err) != nil {
+		return err
+	}
+	instanceSchema, tenantSchema, err := s.factsSchemas(ctx, tenant)
+	if err != nil {
+		return err
+	}
+
+	facts := map[string]any{}
+	for key, value := range cluster.Spec.Facts {
+		if key != LieutenantInstanceFact {
+			facts[key] = api.UnmarshalFact(value)
+		}
+	}
+	violations := []string{}
+	for _, schema := range []*openapi3.Schema{instanceSchema, tenantSchema} {
+		if schema == nil {
+			continue
+		}
+		v, err := schemaViolations(schema, facts)
+		if err != nil {
+			return err
+		}
+		violations = append(violations, v...)
+	}
+	return violationsError(violations, "Facts")
+}
+
+// factsSchemas returns the schemas configured for the instance and the tenant, nil if not configured
+func (s *APIImpl) factsSchemas(ctx *APIContext, tenant *synv1alpha1.Tenant) (*openapi3.Schema, *openapi3.Schema, error) {
+	// Only the default ConfigMap is optional, an explicitly configured one must exist
+	name, optional := os.Getenv(FactsSchemaConfigMapEnvVar), false
+	if name == "" {
+		name, optional = factsSchemaConfigMapDefault, true
+	}
+	instanceSchema, err := s.schemaFromConfigMap(ctx, name, optional)
+	if err != nil {
+		return nil, nil, err
+	}
+	var tenantSchema *openapi3.Schema
+	if name := tenant.Annotations[FactsSchemaConfigMapAnnotation]; name != "" {
+		tenantSchema, err = s.schemaFromConfigMap(ctx, name, false)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return instanceSchema, tenantSchema, nil
+}
+
+// schemaFromConfigMap reads the schema from the ConfigMap in the API's namespace with the API's own client.
+// Returns nil if an optional ConfigMap doesn't exist.
+func (s *APIImpl) schemaFromConfigMap(ctx *APIContext, name string, optional bool) (*openapi3.Schema, error) {
+	c, err := ctx.apiClient()
+	if err != nil {
+		return nil, err
+	}
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(ctx.Request().Context(), client.ObjectKey{Name: name, Namespace: s.namespace}, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			if optional {
+				return nil, nil
+			}
+			return nil, echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Schema ConfigMap '%s' doesn't exist", name))
+		}
+		return nil, err
+	}
+
+	data, ok := cm.Data[schemaKey]
+	if !ok {
+		return nil, fmt.Errorf("ConfigMap '%s' doesn't contain '%s'", name, schemaKey)
+	}
+	raw, err := yaml.YAMLToJSON([]byte(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse '%s' of ConfigMap '%s': %w", schemaKey, name, err)
+	}
+	schema := &openapi3.Schema{}
+	if err := schema.UnmarshalJSON(raw); err != nil {
+		return nil, fmt.Errorf("failed to parse '%s' of ConfigMap '%s': %w", schemaKey, name, err)
+	}
+	return schema, nil
+}
+
+// schemaObject converts the schema to its JSON object representation, nil if there's no schema
+func schemaObject(schema *openapi3.Schema) (*map[string]any, error) {
+	if schema == nil {
+		return nil, nil
+	}
+	raw, err := schema.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal schema: %w", err)
+	}
+	obj := map[string]any{}
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal schema: %w", err)
+	}
+	return &obj, nil
+}
+
+// validateSchema validates the value against the schema.
+// Returns a 422 error listing every violation.
+func validateSchema(schema *openapi3.Schema, value any, subject string) error {
+	violations, err := schemaViolations(schema, value)
+	if err != nil {
+		return err
+	}
+	return violationsError(violations, subject)
+}
+
+// schemaViolations returns the reasons why the value doesn't match the schema
+func schemaViolations(schema *openapi3.Schema, value any) ([]string, error) {
+	// Round trip the value so it only contains JSON types
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+	var decoded any
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+
+	err = schema.VisitJSON(decoded, openapi3.MultiErrors())
+	if err == nil {
+		return nil, nil
+	}
+	var multi openapi3.MultiError
+	if !errors.As(err, &multi) {
+		multi = openapi3.MultiError{err}
+	}
+	violations := make([]string, 0, len(multi))
+	for _, e := range multi {
+		var schemaErr *openapi3.SchemaError
+		if errors.As(e, &schemaErr) {
+			violations = append(violations, fmt.Sprintf("%s: %s", strings.Join(schemaErr.JSONPointer(), "."), schemaErr.Reason))
+			continue
+		}
+		violations = append(violations, e.Error())
+	}
+	return violations, nil
+}
+
+// violationsError returns a 422 error listing the violations, nil if there are none
+func violationsError(violations []string, subject string) error {
+	if len(violations) == 0 {
+		return nil
+	}
+	return echo.NewHTTPError(http.StatusUnprocessableEntity, fmt.Sprintf("%s don't match schema: %s", subject, strings.Join(violations, "; ")))
+}
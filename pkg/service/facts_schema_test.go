@@ -0,0 +1,226 @@
+package service
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/projectsyn/lieutenant-api/pkg/api"
+)
+
+var (
+	instanceFactsSchema = &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      factsSchemaConfigMapDefault,
+			Namespace: "default",
+		},
+		Data: map[string]string{
+			schemaKey: `
+type: object
+required: [cloud, distribution]
+properties:
+  cloud:
+    enum: [cloudscale, exoscale]
+  distribution:
+    enum: [openshift4, k3s]
+`,
+		},
+	}
+	tenantFactsSchema = &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tenant-a-facts-schema",
+			Namespace: "default",
+		},
+		Data: map[string]string{
+			schemaKey: `{"type": "object", "properties": {"region": {"type": "string", "pattern": "^[a-z]{3}[0-9]$"}, "nodes": {"type": "integer"}}}`,
+		},
+	}
+)
+
+// setupFactsSchemaTest sets up the API with an instance schema and a schema for tenant A
+func setupFactsSchemaTest(t *testing.T) (*echo.Echo, client.Client) {
+	tenant := tenantA.DeepCopy()
+	tenant.Annotations[FactsSchemaConfigMapAnnotation] = tenantFactsSchema.Name
+	objs := []client.Object{instanceFactsSchema, tenantFactsSchema, tenant}
+	for _, obj := range testObjects {
+		if obj.GetName() != tenantA.Name {
+			objs = append(objs, obj)
+		}
+	}
+	return rawSetupTest(t, objs...)
+}
+
+func requireFactsViolation(t *testing.T, result *testutil.CompletedRequest, violations ...string) {
+	t.Helper()
+	requireHTTPCode(t, http.StatusUnprocessableEntity, result)
+	reason := &api.Reason{}
+	require.NoError(t, result.UnmarshalJsonToObject(reason))
+	assert.Contains(t, reason.Reason, "Facts don't match schema")
+	for _, v := range violations {
+		assert.Contains(t, reason.Reason, v)
+	}
+}
+
+func TestCreateClusterFactsSchema(t *testing.T) {
+	e, _ := setupFactsSchemaTest(t)
+
+	newCluster := func(facts api.ClusterFacts) api.Cluster {
+		return api.Cluster{
+			ClusterProperties: api.ClusterProperties{
+				DisplayName: pointer.ToString("My test cluster"),
+				Facts:       &facts,
+			},
+			ClusterTenant: api.ClusterTenant{Tenant: tenantA.Name},
+		}
+	}
+
+	result := testutil.NewRequest().
+		Post("/clusters").
+		WithJsonBody(newCluster(api.ClusterFacts{"cloud": "cloudscale", "distrubution": "k3s", "region": "Lpg-2"})).
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireFactsViolation(t, result, `property "distribution" is missing`, "region", `"^[a-z]{3}[0-9]$"`)
+
+	result = testutil.NewRequest().
+		Post("/clusters").
+		WithJsonBody(newCluster(api.ClusterFacts{"cloud": "aws", "distribution": "k3s"})).
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireFactsViolation(t, result, "cloud")
+
+	// Facts are validated with their types
+	result = testutil.NewRequest().
+		Post("/clusters").
+		WithJsonBody(newCluster(api.ClusterFacts{"cloud": "cloudscale", "distribution": "k3s", "nodes": "3"})).
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireFactsViolation(t, result, "nodes")
+
+	result = testutil.NewRequest().
+		Post("/clusters").
+		WithJsonBody(newCluster(api.ClusterFacts{"cloud": "cloudscale", "distribution": "k3s", "region": "lpg2", "nodes": 3})).
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusCreated, result)
+}
+
+func TestPutClusterFactsSchema(t *testing.T) {
+	e, _ := setupFactsSchemaTest(t)
+
+	result := testutil.NewRequest().
+		Put("/clusters/"+clusterA.Name).
+		WithJsonBody(api.Cluster{
+			ClusterProperties: api.ClusterProperties{
+				Facts: &api.ClusterFacts{"cloud": "cloudscale"},
+			},
+			ClusterTenant: api.ClusterTenant{Tenant: tenantA.Name},
+		}).
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireFactsViolation(t, result, "distribution")
+}
+
+func TestUpdateClusterFactsSchema(t *testing.T) {
+	e, _ := setupFactsSchemaTest(t)
+
+	// The existing facts of cluster A don't match the schema, they're only validated if changed
+	result := testutil.NewRequest().
+		Patch("/clusters/"+clusterA.Name).
+		WithJsonBody(api.ClusterProperties{DisplayName: pointer.ToString("New name")}).
+		WithContentType(api.ContentJSONPatch).
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+
+	result = testutil.NewRequest().
+		Patch("/clusters/"+clusterA.Name).
+		WithJsonBody(api.ClusterProperties{Facts: &api.ClusterFacts{"region": "lpg2"}}).
+		WithContentType(api.ContentJSONPatch).
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireFactsViolation(t, result, "distribution")
+
+	result = testutil.NewRequest().
+		Patch("/clusters/"+clusterA.Name).
+		WithJsonBody(api.ClusterProperties{Facts: &api.ClusterFacts{"distribution": "openshift4"}}).
+		WithContentType(api.ContentJSONPatch).
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+}
+
+func TestGetTenantFactsSchema(t *testing.T) {
+	e, _ := setupFactsSchemaTest(t)
+
+	result := testutil.NewRequest().
+		Get("/tenants/"+tenantA.Name+"/factsSchema").
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+	schema := api.FactsSchema{}
+	require.NoError(t, result.UnmarshalJsonToObject(&schema))
+	require.NotNil(t, schema.Instance)
+	assert.Equal(t, []any{"cloud", "distribution"}, (*schema.Instance)["required"])
+	require.NotNil(t, schema.Tenant)
+	assert.Contains(t, (*schema.Tenant)["properties"], "region")
+
+	result = testutil.NewRequest().
+		Get("/tenants/"+tenantB.Name+"/factsSchema").
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+	schema = api.FactsSchema{}
+	require.NoError(t, result.UnmarshalJsonToObject(&schema))
+	assert.NotNil(t, schema.Instance)
+	assert.Nil(t, schema.Tenant)
+}
+
+func TestGetTenantFactsSchemaNotConfigured(t *testing.T) {
+	e, _ := setupTest(t)
+
+	result := testutil.NewRequest().
+		Get("/tenants/"+tenantA.Name+"/factsSchema").
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+	assert.JSONEq(t, `{}`, result.Recorder.Body.String())
+}
+
+func TestFactsSchemaConfigMapMissing(t *testing.T) {
+	t.Run("tenant", func(t *testing.T) {
+		tenant := tenantA.DeepCopy()
+		tenant.Annotations[FactsSchemaConfigMapAnnotation] = "not-existing"
+		e, _ := rawSetupTest(t, tenant)
+
+		result := testutil.NewRequest().
+			Post("/clusters").
+			WithJsonBody(api.Cluster{
+				ClusterProperties: api.ClusterProperties{
+					DisplayName: pointer.ToString("Unvalidated cluster"),
+				},
+				ClusterTenant: api.ClusterTenant{Tenant: tenantA.Name},
+			}).
+			WithHeader(echo.HeaderAuthorization, bearerToken).
+			GoWithHTTPHandler(t, e)
+		requireHTTPCode(t, http.StatusInternalServerError, result)
+		assert.Contains(t, result.Recorder.Body.String(), "Schema ConfigMap 'not-existing' doesn't exist")
+	})
+	t.Run("instance", func(t *testing.T) {
+		t.Setenv(FactsSchemaConfigMapEnvVar, "not-existing")
+		e, _ := setupTest(t)
+
+		result := testutil.NewRequest().
+			Get("/tenants/"+tenantA.Name+"/factsSchema").
+			WithHeader(echo.HeaderAuthorization, bearerToken).
+			GoWithHTTPHandler(t, e)
+		requireHTTPCode(t, http.StatusInternalServerError, result)
+	})
+}
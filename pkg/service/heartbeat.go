@@ -0,0 +1,142 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/labstack/echo/v4"
+	synv1alpha1 "github.com/projectsyn/lieutenant-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/projectsyn/lieutenant-api/pkg/api"
+)
+
+const (
+	// LastSeenAnnotation records the time of the last heartbeat of Steward
+	LastSeenAnnotation = "steward.syn.tools/last-seen"
+	// AgentVersionAnnotation records the Steward version reported with the last heartbeat
+	AgentVersionAnnotation = "steward.syn.tools/agent-version"
+	// AgentStateAnnotation records the JSON encoded agent state reported with the last heartbeat
+	AgentStateAnnotation = "steward.syn.tools/agent-state"
+
+	// HeartbeatStaleAfterEnvVar is the env var name that's used to get the duration after which a cluster without heartbeat is considered stale
+	HeartbeatStaleAfterEnvVar = "STEWARD_HEARTBEAT_STALE_AFTER"
+
+	defaultHeartbeatStaleAfter = 15 * time.Minute
+)
+
+// PostClusterHeartbeat records a heartbeat of the cluster's Steward agent
+func (s *APIImpl) PostClusterHeartbeat(c echo.Context, clusterID api.ClusterIdParameter) error {
+	ctx := c.(*APIContext)
+
+	body := &api.Heartbeat{}
+	if err := ctx.Bind(body); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+
+	state := ""
+	if body.State != nil {
+		encoded, err := json.Marshal(body.State)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err)
+		}
+		state = string(encoded)
+	}
+
+	if err := s.authorizeClusterAgent(ctx, string(clusterID), "heartbeats"); err != nil {
+		return err
+	}
+	cluster := &synv1alpha1.Cluster{}
+	if err := ctx.client.Get(ctx.Request().Context(), client.ObjectKey{Name: string(clusterID), Namespace: s.namespace}, cluster); err != nil {
+		return err
+	}
+	// Every write triggers a reconcile of the cluster, an unchanged agent only refreshes its last seen time now and then
+	if !heartbeatChanged(cluster, body.Version, state, time.Now()) {
+		return ctx.NoContent(http.StatusNoContent)
+	}
+
+	annotations := map[string]*string{
+		LastSeenAnnotation:     pointer.ToString(time.Now().UTC().Format(time.RFC3339)),
+		AgentVersionAnnotation: &body.Version,
+		// A nil value removes the annotation with the merge patch
+		AgentStateAnnotation: nil,
+	}
+	if state != "" {
+		annotations[AgentStateAnnotation] = &state
+	}
+	rawPatch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": annotations,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal patch: %w", err)
+	}
+
+	if err := ctx.client.Patch(ctx.Request().Context(), cluster, client.RawPatch(types.MergePatchType, rawPatch)); err != nil {
+		return err
+	}
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+// heartbeatChanged checks if the heartbeat has to be recorded on the cluster.
+// That's the case if the agent's version or state changed or if the last seen time is older than a third of the stale duration.
+func heartbeatChanged(cluster *synv1alpha1.Cluster, version, state string, now time.Time) bool {
+	if cluster.Annotations[AgentVersionAnnotation] != version || cluster.Annotations[AgentStateAnnotation] != state {
+		return true
+	}
+	lastSeen, err := time.Parse(time.RFC3339, cluster.Annotations[LastSeenAnnotation])
+	return err != nil || now.Sub(lastSeen) >= heartbeatStaleAfter()/3
+}
+
+// clusterConnectivity computes the liveness of the cluster's Steward agent from the last recorded heartbeat
+func clusterConnectivity(cluster *synv1alpha1.Cluster, now time.Time) *api.ClusterConnectivity {
+	connectivity := &api.ClusterConnectivity{
+		Status: api.ClusterConnectivityStatusNeverSeen,
+	}
+	lastSeen, err := time.Parse(time.RFC3339, cluster.Annotations[LastSeenAnnotation])
+	if err != nil {
+		return connectivity
+	}
+	connectivity.LastSeen = &lastSeen
+	connectivity.Status = api.ClusterConnectivityStatusOnline
+	if now.Sub(lastSeen) > heartbeatStaleAfter() {
+		connectivity.Status = api.ClusterConnectivityStatusStale
+	}
+	if v, ok := cluster.Annotations[AgentVersionAnnotation]; ok {
+		connectivity.Version = &v
+	}
+	if raw, ok := cluster.Annotations[AgentStateAnnotation]; ok {
+		state := map[string]any{}
+		if err := json.Unmarshal([]byte(raw), &state); err == nil {
+			connectivity.State = &state
+		}
+	}
+	return connectivity
+}
+
+// heartbeatStaleAfter returns the configured stale duration, it's validated by NewAPIServer
+func heartbeatStaleAfter() time.Duration {
+	d, err := parseHeartbeatStaleAfter()
+	if err != nil {
+		return defaultHeartbeatStaleAfter
+	}
+	return d
+}
+
+func parseHeartbeatStaleAfter() (time.Duration, error) {
+	raw := os.Getenv(HeartbeatStaleAfterEnvVar)
+	if raw == "" {
+		return defaultHeartbeatStaleAfter, nil
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid %s '%s', expected a positive duration such as '15m'", HeartbeatStaleAfterEnvVar, raw)
+	}
+	return d, nil
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/testutil"
+	synv1alpha1 "github.com/projectsyn/lieutenant-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/projectsyn/lieutenant-api/pkg/api"
+)
+
+func TestPostClusterHeartbeat(t *testing.T) {
+	e, c := setupTest(t)
+
+	before := time.Now().Add(-time.Second)
+	result := testutil.NewRequest().
+		Post("/clusters/"+clusterA.Name+"/heartbeat").
+		WithJsonBody(api.Heartbeat{
+			Version: "v0.12.0",
+			State:   &map[string]any{"argocd": "healthy"},
+		}).
+		WithHeader(echo.HeaderAuthorization, serviceAccountBearerToken(clusterA.Name)).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusNoContent, result)
+
+	cluster := &synv1alpha1.Cluster{}
+	require.NoError(t, c.Get(t.Context(), client.ObjectKeyFromObject(clusterA), cluster))
+	assert.Equal(t, clusterA.Annotations["some"], cluster.Annotations["some"])
+	assert.Equal(t, "v0.12.0", cluster.Annotations[AgentVersionAnnotation])
+
+	result = testutil.NewRequest().
+		Get("/clusters/"+clusterA.Name).
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+	apiCluster := &api.Cluster{}
+	require.NoError(t, result.UnmarshalJsonToObject(apiCluster))
+	require.NotNil(t, apiCluster.Connectivity)
+	assert.Equal(t, api.ClusterConnectivityStatusOnline, apiCluster.Connectivity.Status)
+	require.NotNil(t, apiCluster.Connectivity.LastSeen)
+	assert.True(t, apiCluster.Connectivity.LastSeen.After(before))
+	assert.Equal(t, "v0.12.0", pointer.GetString(apiCluster.Connectivity.Version))
+	require.NotNil(t, apiCluster.Connectivity.State)
+	assert.Equal(t, "healthy", (*apiCluster.Connectivity.State)["argocd"])
+
+	// A heartbeat without state clears the previous state
+	result = testutil.NewRequest().
+		Post("/clusters/"+clusterA.Name+"/heartbeat").
+		WithJsonBody(api.Heartbeat{Version: "v0.13.0"}).
+		WithHeader(echo.HeaderAuthorization, serviceAccountBearerToken(clusterA.Name)).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusNoContent, result)
+	require.NoError(t, c.Get(t.Context(), client.ObjectKeyFromObject(clusterA), cluster))
+	assert.Equal(t, "v0.13.0", cluster.Annotations[AgentVersionAnnotation])
+	assert.NotContains(t, cluster.Annotations, AgentStateAnnotation)
+}
+
+func TestPostClusterHeartbeatUnchanged(t *testing.T) {
+	e, c := setupTest(t)
+	heartbeat := api.Heartbeat{
+		Version: "v0.12.0",
+		State:   &map[string]any{"argocd": "healthy"},
+	}
+	postHeartbeat := func() *synv1alpha1.Cluster {
+		result := testutil.NewRequest().
+			Post("/clusters/"+clusterA.Name+"/heartbeat").
+			WithJsonBody(heartbeat).
+			WithHeader(echo.HeaderAuthorization, serviceAccountBearerToken(clusterA.Name)).
+			GoWithHTTPHandler(t, e)
+		requireHTTPCode(t, http.StatusNoContent, result)
+		cluster := &synv1alpha1.Cluster{}
+		require.NoError(t, c.Get(t.Context(), client.ObjectKeyFromObject(clusterA), cluster))
+		return cluster
+	}
+
+	first := postHeartbeat()
+	second := postHeartbeat()
+	assert.Equal(t, first.ResourceVersion, second.ResourceVersion, "An unchanged heartbeat must not update the cluster")
+
+	// The last seen time is refreshed once it gets old
+	lastSeen := time.Now().Add(-10 * time.Minute).UTC().Format(time.RFC3339)
+	second.Annotations[LastSeenAnnotation] = lastSeen
+	require.NoError(t, c.Update(t.Context(), second))
+	third := postHeartbeat()
+	assert.NotEqual(t, lastSeen, third.Annotations[LastSeenAnnotation])
+
+	heartbeat.State = &map[string]any{"argocd": "degraded"}
+	fourth := postHeartbeat()
+	assert.NotEqual(t, third.ResourceVersion, fourth.ResourceVersion, "A changed state must be recorded")
+	assert.Equal(t, `{"argocd":"degraded"}`, fourth.Annotations[AgentStateAnnotation])
+}
+
+func TestPostClusterHeartbeatForbidden(t *testing.T) {
+	tcs := map[string]string{
+		"status writer": bearerToken,
+		"other cluster": serviceAccountBearerToken(clusterB.Name),
+	}
+	for name, token := range tcs {
+		t.Run(name, func(t *testing.T) {
+			e, c := setupTest(t)
+
+			result := testutil.NewRequest().
+				Post("/clusters/"+clusterA.Name+"/heartbeat").
+				WithJsonBody(api.Heartbeat{Version: "v0.12.0"}).
+				WithHeader(echo.HeaderAuthorization, token).
+				GoWithHTTPHandler(t, e)
+			requireHTTPCode(t, http.StatusForbidden, result)
+
+			cluster := &synv1alpha1.Cluster{}
+			require.NoError(t, c.Get(t.Context(), client.ObjectKeyFromObject(clusterA), cluster))
+			assert.NotContains(t, cluster.Annotations, LastSeenAnnotation)
+		})
+	}
+}
+
+func TestPostClusterHeartbeatMissingVersion(t *testing.T) {
+	e, _ := setupTest(t)
+
+	result := testutil.NewRequest().
+		Post("/clusters/"+clusterA.Name+"/heartbeat").
+		WithJsonBody(map[string]any{"state": map[string]any{}}).
+		WithHeader(echo.HeaderAuthorization, serviceAccountBearerToken(clusterA.Name)).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusBadRequest, result)
+}
+
+func TestPostClusterHeartbeatNotFound(t *testing.T) {
+	e, _ := setupTest(t)
+
+	result := testutil.NewRequest().
+		Post("/clusters/c-not-existing/heartbeat").
+		WithJsonBody(api.Heartbeat{Version: "v0.12.0"}).
+		WithHeader(echo.HeaderAuthorization, serviceAccountBearerToken("c-not-existing")).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusNotFound, result)
+}
+
+func TestClusterConnectivity(t *testing.T) {
+	now := time.Date(2024, time.April, 14, 21, 5, 56, 0, time.UTC)
+	tcs := map[string]struct {
+		lastSeen string
+		status   api.ClusterConnectivityStatus
+	}{
+		"never seen": {
+			status: api.ClusterConnectivityStatusNeverSeen,
+		},
+		"invalid timestamp": {
+			lastSeen: "yesterday",
+			status:   api.ClusterConnectivityStatusNeverSeen,
+		},
+		"online": {
+			lastSeen: now.Add(-time.Minute).Format(time.RFC3339),
+			status:   api.ClusterConnectivityStatusOnline,
+		},
+		"stale": {
+			lastSeen: now.Add(-time.Hour).Format(time.RFC3339),
+			status:   api.ClusterConnectivityStatusStale,
+		},
+	}
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			cluster := clusterA.DeepCopy()
+			if tc.lastSeen != "" {
+				cluster.Annotations[LastSeenAnnotation] = tc.lastSeen
+			}
+			assert.Equal(t, tc.status, clusterConnectivity(cluster, now).Status)
+		})
+	}
+}
+
+func TestListClusterFilteredByConnectivity(t *testing.T) {
+	t.Setenv(HeartbeatStaleAfterEnvVar, "10m")
+	online := clusterA.DeepCopy()
+	online.Name = "c-online"
+	online.Annotations[LastSeenAnnotation] = time.Now().Format(time.RFC3339)
+	stale := clusterA.DeepCopy()
+	stale.Name = "c-stale"
+	stale.Annotations[LastSeenAnnotation] = time.Now().Add(-11 * time.Minute).Format(time.RFC3339)
+	e, _ := setupTest(t, online, stale)
+
+	tcs := map[api.ClusterConnectivityStatus][]string{
+		api.ClusterConnectivityStatusOnline:    {online.Name},
+		api.ClusterConnectivityStatusStale:     {stale.Name},
+		api.ClusterConnectivityStatusNeverSeen: {clusterA.Name, clusterB.Name},
+	}
+	for status, expected := range tcs {
+		t.Run(string(status), func(t *testing.T) {
+			result := testutil.NewRequest().
+				Get("/clusters?connectivity="+string(status)).
+				WithHeader(echo.HeaderAuthorization, bearerToken).
+				GoWithHTTPHandler(t, e)
+			requireHTTPCode(t, http.StatusOK, result)
+			clusters := make([]api.Cluster, 0)
+			require.NoError(t, result.UnmarshalJsonToObject(&clusters))
+			ids := make([]string, 0, len(clusters))
+			for _, c := range clusters {
+				ids = append(ids, c.Id.String())
+			}
+			assert.ElementsMatch(t, expected, ids)
+		})
+	}
+}
+
+func TestNewAPIServerInvalidHeartbeatStaleAfter(t *testing.T) {
+	for _, staleAfter := range []string{"15", "-5m", "0s"} {
+		t.Run(staleAfter, func(t *testing.T) {
+			t.Setenv(HeartbeatStaleAfterEnvVar, staleAfter)
+			_, err := NewAPIServer(APIConfig{APIVersion: "v1", Namespace: "default"})
+			assert.ErrorContains(t, err, "invalid STEWARD_HEARTBEAT_STALE_AFTER")
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"fmt"
+	"os"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/projectsyn/lieutenant-api/pkg/api"
+)
+
+const (
+	// IDGeneratorEnvVar is the env var name that's used to get the generator for new IDs
+	IDGeneratorEnvVar = "ID_GENERATOR"
+	// IDGeneratorPatternEnvVar is the env var name that's used to get the pattern of the `pattern` ID generator
+	IDGeneratorPatternEnvVar = "ID_GENERATOR_PATTERN"
+	// IDReservedWordsEnvVar is the env var name that's used to get the words which mustn't be part of generated IDs
+	IDReservedWordsEnvVar = "ID_RESERVED_WORDS"
+
+	// maxIDCollisions is the number of times a new ID is generated if the generated one is already taken
+	maxIDCollisions = 5
+)
+
+// createWithGeneratedID creates an object with a generated ID.
+// A new ID is generated if an object with the generated ID already exists.
+func createWithGeneratedID(prefix, displayName string, create func(id api.Id) error) error {
+	generator, err := newIDGenerator()
+	if err != nil {
+		return err
+	}
+	reserved := splitList(os.Getenv(IDReservedWordsEnvVar))
+
+	for attempt := 0; ; attempt++ {
+		id, err := api.GenerateID(generator, prefix, displayName, attempt, reserved)
+		if err != nil {
+			return err
+		}
+		err = create(id)
+		if !errors.IsAlreadyExists(err) || attempt >= maxIDCollisions {
+			return err
+		}
+	}
+}
+
+func newIDGenerator() (api.IDGenerator, error) {
+	generator, err := api.NewIDGenerator(os.Getenv(IDGeneratorEnvVar), os.Getenv(IDGeneratorPatternEnvVar))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", IDGeneratorEnvVar, err)
+	}
+	return generator, nil
+}
+
+// validateIDGenerator generates an ID with the configured generator, so a misconfiguration fails at startup instead of on every create
+func validateIDGenerator() error {
+	generator, err := newIDGenerator()
+	if err != nil {
+		return err
+	}
+	if _, err := api.GenerateID(generator, api.ClusterIDPrefix, "", 0, nil); err != nil {
+		return fmt.Errorf("invalid %s: %w", IDGeneratorEnvVar, err)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/testutil"
+	synv1alpha1 "github.com/projectsyn/lieutenant-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/projectsyn/lieutenant-api/pkg/api"
+)
+
+func createClusterNamed(t *testing.T, e *echo.Echo, displayName string) *testutil.CompletedRequest {
+	return testutil.NewRequest().
+		Post("/clusters").
+		WithJsonBody(api.Cluster{
+			ClusterProperties: api.ClusterProperties{DisplayName: pointer.ToString(displayName)},
+			ClusterTenant:     api.ClusterTenant{Tenant: tenantA.Name},
+		}).
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+}
+
+func TestCreateClusterSlugID(t *testing.T) {
+	t.Setenv(IDGeneratorEnvVar, api.IDGeneratorSlug)
+	e, _ := setupTest(t, &synv1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "c-my-cluster", Namespace: "default"},
+		Spec:       synv1alpha1.ClusterSpec{TenantRef: corev1.LocalObjectReference{Name: tenantA.Name}},
+	})
+
+	// The slug is taken, so a suffix is added
+	result := createClusterNamed(t, e, "My Cluster")
+	requireHTTPCode(t, http.StatusCreated, result)
+	cluster := &api.Cluster{}
+	require.NoError(t, result.UnmarshalJsonToObject(cluster))
+	assert.Regexp(t, "^c-my-cluster-[a-z0-9]{4}$", cluster.Id.String())
+
+	result = createClusterNamed(t, e, "Other Cluster")
+	requireHTTPCode(t, http.StatusCreated, result)
+	require.NoError(t, result.UnmarshalJsonToObject(cluster))
+	assert.Equal(t, "c-other-cluster", cluster.Id.String())
+}
+
+func TestCreateTenantPatternID(t *testing.T) {
+	t.Setenv(IDGeneratorEnvVar, api.IDGeneratorPattern)
+	t.Setenv(IDGeneratorPatternEnvVar, "acme")
+	e, _ := setupTest(t)
+
+	newTenant := api.Tenant{
+		TenantProperties: api.TenantProperties{
+			GitRepo: &api.RevisionedGitRepo{GitRepo: api.GitRepo{Url: pointer.ToString("ssh://git@example.com/org/tenant.git")}},
+		},
+	}
+	createTenant := func() *testutil.CompletedRequest {
+		return testutil.NewRequest().
+			Post("/tenants").
+			WithJsonBody(newTenant).
+			WithHeader(echo.HeaderAuthorization, bearerToken).
+			GoWithHTTPHandler(t, e)
+	}
+
+	result := createTenant()
+	requireHTTPCode(t, http.StatusCreated, result)
+	tenant := &api.Tenant{}
+	require.NoError(t, result.UnmarshalJsonToObject(tenant))
+	assert.Equal(t, "t-acme", tenant.Id.String())
+
+	// The pattern can't generate another ID, so the collision can't be resolved
+	requireHTTPCode(t, http.StatusConflict, createTenant())
+}
+
+func TestCreateClusterIDGeneratorConfig(t *testing.T) {
+	t.Setenv(IDGeneratorEnvVar, api.IDGeneratorPattern)
+	t.Setenv(IDGeneratorPatternEnvVar, "##")
+	t.Setenv(IDReservedWordsEnvVar, "admin")
+	e, _ := setupTest(t)
+
+	result := createClusterNamed(t, e, "Numbers")
+	requireHTTPCode(t, http.StatusCreated, result)
+	cluster := &api.Cluster{}
+	require.NoError(t, result.UnmarshalJsonToObject(cluster))
+	assert.Regexp(t, "^c-[0-9]{2}$", cluster.Id.String())
+
+	t.Setenv(IDGeneratorPatternEnvVar, "admin")
+	requireHTTPCode(t, http.StatusInternalServerError, createClusterNamed(t, e, "Reserved"))
+
+	t.Setenv(IDGeneratorEnvVar, "uuid")
+	requireHTTPCode(t, http.StatusInternalServerError, createClusterNamed(t, e, "Unknown"))
+}
+
+func TestNewAPIServerInvalidIDGenerator(t *testing.T) {
+	tests := map[string]struct {
+		generator string
+		pattern   string
+	}{
+		"unknown":         {generator: "uuid"},
+		"missing pattern": {generator: api.IDGeneratorPattern},
+		"invalid pattern": {generator: api.IDGeneratorPattern, pattern: "Prod_##"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(IDGeneratorEnvVar, tt.generator)
+			t.Setenv(IDGeneratorPatternEnvVar, tt.pattern)
+			_, err := NewAPIServer(APIConfig{APIVersion: "v1", Namespace: "default"})
+			assert.ErrorContains(t, err, "invalid ID_GENERATOR")
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/transport"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+)
+
+const (
+	// K8sAuthModeEnvKey is the env var name that's used to get how requests are authenticated to Kubernetes
+	K8sAuthModeEnvKey = "K8S_AUTH_MODE"
+	// K8sAuthModeToken creates a Kubernetes client for each bearer token
+	K8sAuthModeToken = "token"
+	// K8sAuthModeImpersonation reviews the bearer token and impersonates its user with the API's own client
+	K8sAuthModeImpersonation = "impersonation"
+)
+
+var errNoImpersonatedUser = errors.New("request to Kubernetes without user to impersonate")
+
+type impersonatedUserKey struct{}
+
+// withImpersonatedUser returns a context which makes the impersonating client act as the user
+func withImpersonatedUser(ctx context.Context, user authenticationv1.UserInfo) context.Context {
+	return context.WithValue(ctx, impersonatedUserKey{}, user)
+}
+
+// impersonatingRoundTripper adds the impersonation headers for the user in the request's context.
+// Requests without user are rejected, so they're never made with the identity of the API itself.
+type impersonatingRoundTripper struct {
+	delegate http.RoundTripper
+}
+
+func (rt *impersonatingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	user, ok := req.Context().Value(impersonatedUserKey{}).(authenticationv1.UserInfo)
+	if !ok || user.Username == "" {
+		return nil, errNoImpersonatedUser
+	}
+	extra := make(map[string][]string, len(user.Extra))
+	for key, value := range user.Extra {
+		extra[key] = value
+	}
+	return transport.NewImpersonatingRoundTripper(transport.ImpersonationConfig{
+		UserName: user.Username,
+		UID:      user.UID,
+		Groups:   user.Groups,
+		Extra:    extra,
+	}, rt.delegate).RoundTrip(req)
+}
+
+// newImpersonatingClient creates a client with the API's own credentials which impersonates the user in the context of each request
+func newImpersonatingClient() (client.Client, error) {
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	cfg = rest.CopyConfig(cfg)
+	cfg.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return &impersonatingRoundTripper{delegate: rt}
+	})
+	return client.New(cfg, client.Options{
+		Scheme: scheme,
+	})
+}
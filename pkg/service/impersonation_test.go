@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/projectsyn/lieutenant-api/pkg/api"
+)
+
+func TestImpersonatingRoundTripper(t *testing.T) {
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+	}))
+	defer server.Close()
+	c := &http.Client{Transport: &impersonatingRoundTripper{delegate: http.DefaultTransport}}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	_, err = c.Do(req)
+	assert.ErrorIs(t, err, errNoImpersonatedUser)
+	assert.Nil(t, header)
+
+	ctx := withImpersonatedUser(context.Background(), authenticationv1.UserInfo{
+		Username: "user",
+		UID:      "1234",
+		Groups:   []string{"admins", "system:authenticated"},
+		Extra:    map[string]authenticationv1.ExtraValue{"scopes": {"openid"}},
+	})
+	req, err = http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	res, err := c.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, "user", header.Get("Impersonate-User"))
+	assert.Equal(t, "1234", header.Get("Impersonate-Uid"))
+	assert.Equal(t, []string{"admins", "system:authenticated"}, header.Values("Impersonate-Group"))
+	assert.Equal(t, "openid", header.Get("Impersonate-Extra-Scopes"))
+}
+
+func TestImpersonationAuth(t *testing.T) {
+	f := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(tenantA, clusterA).
+		Build()
+	users := []string{}
+	impersonating := interceptor.NewClient(f, interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			user, ok := ctx.Value(impersonatedUserKey{}).(authenticationv1.UserInfo)
+			require.True(t, ok, "request without impersonated user")
+			users = append(users, user.Username)
+			return c.Get(ctx, key, obj, opts...)
+		},
+	})
+	auth := KubernetesAuth{
+		Impersonate: true,
+		CreateClientFunc: func(token string) (client.Client, error) {
+			assert.Empty(t, token, "client created for user token")
+			return f, nil
+		},
+		CreateImpersonatingClientFunc: func() (client.Client, error) {
+			return impersonating, nil
+		},
+		ReviewTokenFunc:  reviewTestToken,
+		ReviewAccessFunc: reviewTestAccess,
+		cache:            createCache(),
+	}
+	e, err := NewAPIServer(APIConfig{APIVersion: "v1", Namespace: "default"}, auth)
+	require.NoError(t, err)
+
+	result := testutil.NewRequest().
+		Get("/tenants/"+tenantA.Name).
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+	tenant := &api.Tenant{}
+	require.NoError(t, result.UnmarshalJsonToObject(tenant))
+	assert.Equal(t, tenantA.Name, tenant.Id.String())
+
+	result = testutil.NewRequest().
+		Get("/clusters/"+clusterA.Name).
+		WithHeader(echo.HeaderAuthorization, unprivilegedBearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+	// The cluster is read once to resolve the cluster ID and once by the handler
+	assert.Equal(t, []string{"user", "unprivileged", "unprivileged"}, users)
+	assert.Equal(t, 0, auth.cache.Len())
+}
+
+func TestImpersonationAuthMissingToken(t *testing.T) {
+	auth := KubernetesAuth{
+		Impersonate: true,
+		CreateImpersonatingClientFunc: func() (client.Client, error) {
+			return fake.NewClientBuilder().WithScheme(scheme).Build(), nil
+		},
+		ReviewTokenFunc: reviewTestToken,
+		cache:           createCache(),
+	}
+	e, err := NewAPIServer(APIConfig{APIVersion: "v1", Namespace: "default"}, auth)
+	require.NoError(t, err)
+
+	result := testutil.NewRequest().
+		Get("/tenants/"+tenantA.Name).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusBadRequest, result)
+}
+
+func TestNewAPIServerUnknownAuthMode(t *testing.T) {
+	t.Setenv(K8sAuthModeEnvKey, "magic")
+	_, err := NewAPIServer(APIConfig{APIVersion: "v1", Namespace: "default"})
+	assert.ErrorContains(t, err, "unknown K8S_AUTH_MODE 'magic'")
+}
+
+func TestImpersonationAuthCachesReviews(t *testing.T) {
+	f := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(tenantA).
+		Build()
+	reviews := 0
+	auth := KubernetesAuth{
+		Impersonate: true,
+		CreateClientFunc: func(string) (client.Client, error) {
+			return f, nil
+		},
+		CreateImpersonatingClientFunc: func() (client.Client, error) {
+			return f, nil
+		},
+		ReviewTokenFunc: func(ctx context.Context, token string) (authenticationv1.UserInfo, error) {
+			reviews++
+			return reviewTestToken(ctx, token)
+		},
+		ReviewAccessFunc: reviewTestAccess,
+		cache:            createCache(),
+		reviews:          createReviewCache(),
+	}
+	e, err := NewAPIServer(APIConfig{APIVersion: "v1", Namespace: "default"}, auth)
+	require.NoError(t, err)
+
+	getTenant := func(token string) {
+		result := testutil.NewRequest().
+			Get("/tenants/"+tenantA.Name).
+			WithHeader(echo.HeaderAuthorization, token).
+			GoWithHTTPHandler(t, e)
+		requireHTTPCode(t, http.StatusOK, result)
+	}
+	getTenant(bearerToken)
+	getTenant(bearerToken)
+	assert.Equal(t, 1, reviews)
+	assert.Equal(t, []string{hashToken(strings.TrimPrefix(bearerToken, AuthScheme+" "))}, auth.reviews.lru.Keys(), "Tokens must only be kept hashed")
+	getTenant(unprivilegedBearerToken)
+	assert.Equal(t, 2, reviews)
+
+	// Flushing the client cache forgets the reviewed tokens
+	result := testutil.NewRequest().
+		Delete("/admin/clientCache").
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusNoContent, result)
+	getTenant(bearerToken)
+	assert.Equal(t, 3, reviews)
+}
@@ -1,19 +1,239 @@
 package service
 
 import (
+	"encoding/json"
+	"io"
 	"net/http"
+	"time"
 
+	"github.com/AlekSi/pointer"
 	"github.com/labstack/echo/v4"
+	synv1alpha1 "github.com/projectsyn/lieutenant-operator/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/projectsyn/lieutenant-api/pkg/api"
+	"github.com/projectsyn/lieutenant-api/pkg/inventory"
 )
 
+var errInventoryNotConfigured = echo.NewHTTPError(http.StatusServiceUnavailable, "Inventory isn't configured")
+
 // QueryInventory queries the inventory
-func (s *APIImpl) QueryInventory(ctx echo.Context, params api.QueryInventoryParams) error {
-	return echo.NewHTTPError(http.StatusInternalServerError, "Not implemented")
+func (s *APIImpl) QueryInventory(c echo.Context, params api.QueryInventoryParams) error {
+	ctx := c.(*APIContext)
+	if s.inventory == nil {
+		return errInventoryNotConfigured
+	}
+	query := inventory.Query{
+		Cluster: pointer.GetString(params.Cluster),
+		From:    pointer.GetTime(params.From),
+		To:      pointer.GetTime(params.To),
+		Latest:  pointer.GetBool(params.Latest),
+	}
+	if !query.From.IsZero() && !query.To.IsZero() && query.To.Before(query.From) {
+		return echo.NewHTTPError(http.StatusBadRequest, "Parameter 'to' must not be before 'from'")
+	}
+
+	// Only return the inventory of clusters visible to the caller
+	var clusters []string
+	if query.Cluster != "" {
+		clusters = []string{query.Cluster}
+	}
+	visible, err := s.visibleClusters(ctx, clusters, "")
+	if err != nil {
+		return err
+	}
+
+	// The entries are streamed to the client instead of collecting them first
+	res := &inventoryStream{ctx: ctx}
+	err = s.inventory.Query(ctx.Request().Context(), query, func(entry inventory.Entry) error {
+		if _, ok := visible[entry.Cluster]; !ok {
+			return nil
+		}
+		return res.write(api.Inventory{
+			Cluster:   entry.Cluster,
+			Timestamp: pointer.ToTime(entry.Timestamp),
+			Inventory: &entry.Inventory,
+		})
+	})
+	if err != nil {
+		if res.started {
+			// The status was already sent, the truncated array tells the client the response is incomplete
+			ctx.Logger().Errorf("failed to stream inventory: %v", err)
+			return nil
+		}
+		return err
+	}
+	return res.close()
+}
+
+// inventoryStream writes the entries as JSON array.
+// The response is only started with the first entry, so errors before it are still returned with their status.
+type inventoryStream struct {
+	ctx     *APIContext
+	started bool
+}
+
+func (w *inventoryStream) write(entry api.Inventory) error {
+	res := w.ctx.Response()
+	sep := ","
+	if !w.started {
+		res.Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		res.WriteHeader(http.StatusOK)
+		w.started = true
+		sep = "["
+	}
+	if _, err := io.WriteString(res, sep); err != nil {
+		return err
+	}
+	return json.NewEncoder(res).Encode(entry)
+}
+
+func (w *inventoryStream) close() error {
+	if !w.started {
+		return w.ctx.JSON(http.StatusOK, []api.Inventory{})
+	}
+	_, err := io.WriteString(w.ctx.Response(), "]")
+	return err
 }
 
 // UpdateInventory updates an inventory entry
-func (s *APIImpl) UpdateInventory(ctx echo.Context) error {
-	return echo.NewHTTPError(http.StatusInternalServerError, "Not implemented")
+func (s *APIImpl) UpdateInventory(c echo.Context) error {
+	ctx := c.(*APIContext)
+	if s.inventory == nil {
+		return errInventoryNotConfigured
+	}
+
+	body := &api.Inventory{}
+	if err := ctx.Bind(body); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+
+	// Only the cluster itself may store its inventory
+	if err := s.authorizeClusterAgent(ctx, body.Cluster, "inventory"); err != nil {
+		return err
+	}
+	cluster := &synv1alpha1.Cluster{}
+	if err := ctx.client.Get(ctx.Request().Context(), client.ObjectKey{Name: body.Cluster, Namespace: s.namespace}, cluster); err != nil {
+		return err
+	}
+
+	entry := inventory.Entry{
+		Cluster:   cluster.Name,
+		Timestamp: time.Now().UTC(),
+		Inventory: map[string]any{},
+	}
+	if body.Inventory != nil {
+		entry.Inventory = *body.Inventory
+	}
+	if err := s.inventory.Update(ctx.Request().Context(), entry); err != nil {
+		return err
+	}
+	return ctx.NoContent(http.StatusCreated)
+}
+
+// SearchInventory selects, aggregates and groups inventory data
+func (s *APIImpl) SearchInventory(c echo.Context) error {
+	ctx := c.(*APIContext)
+	if s.inventory == nil {
+		return errInventoryNotConfigured
+	}
+
+	body := &api.InventoryQuery{}
+	if err := ctx.Bind(body); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err)
+	}
+
+	query := inventory.Query{
+		From: pointer.GetTime(body.From),
+		To:   pointer.GetTime(body.To),
+	}
+	if !query.From.IsZero() && !query.To.IsZero() && query.To.Before(query.From) {
+		return echo.NewHTTPError(http.StatusBadRequest, "Property 'to' must not be before 'from'")
+	}
+	sel := inventory.Selection{
+		LatestPerCluster: pointer.GetBool(body.Latest),
+	}
+	if body.Fields != nil {
+		sel.Fields = *body.Fields
+	}
+	if body.GroupBy != nil {
+		sel.GroupBy = *body.GroupBy
+	}
+	if body.Aggregation != nil {
+		sel.Aggregation = inventory.Aggregation(*body.Aggregation)
+	}
+	if err := sel.Validate(); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	var clusters []string
+	if body.Clusters != nil {
+		clusters = *body.Clusters
+	}
+	visible, err := s.visibleClusters(ctx, clusters, pointer.GetString(body.Tenant))
+	if err != nil {
+		return err
+	}
+	if len(clusters) == 1 {
+		query.Cluster = clusters[0]
+	}
+
+	// Only the aggregated values are kept while the entries are read
+	ev := inventory.NewEvaluator(visible, sel)
+	err = s.inventory.Query(ctx.Request().Context(), query, func(entry inventory.Entry) error {
+		if _, ok := visible[entry.Cluster]; ok {
+			ev.Add(entry)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	rows := ev.Rows()
+	result := make([]api.InventoryQueryRow, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, api.InventoryQueryRow{
+			Group:   row.Group,
+			Values:  row.Values,
+			Entries: row.Entries,
+		})
+	}
+	return ctx.JSON(http.StatusOK, result)
+}
+
+// visibleClusters returns the tenants of the clusters visible to the caller.
+// The result is restricted to the given clusters and tenant if they're not empty.
+// Requesting a single cluster which isn't visible returns its error.
+func (s *APIImpl) visibleClusters(ctx *APIContext, clusters []string, tenant string) (map[string]string, error) {
+	visible := map[string]string{}
+	if len(clusters) == 1 {
+		cluster := &synv1alpha1.Cluster{}
+		if err := s.getCluster(ctx, clusters[0], cluster); err != nil {
+			return nil, err
+		}
+		if tenant == "" || cluster.Spec.TenantRef.Name == tenant {
+			visible[cluster.Name] = cluster.Spec.TenantRef.Name
+		}
+		return visible, nil
+	}
+
+	filterOptions := []client.ListOption{client.InNamespace(s.namespace)}
+	if tenant != "" {
+		filterOptions = append(filterOptions, client.MatchingLabels{synv1alpha1.LabelNameTenant: tenant})
+	}
+	clusterList := &synv1alpha1.ClusterList{}
+	if err := s.listClusters(ctx, clusterList, filterOptions...); err != nil {
+		return nil, err
+	}
+	selected := map[string]bool{}
+	for _, c := range clusters {
+		selected[c] = true
+	}
+	for _, cluster := range clusterList.Items {
+		if len(selected) == 0 || selected[cluster.Name] {
+			visible[cluster.Name] = cluster.Spec.TenantRef.Name
+		}
+	}
+	return visible, nil
 }
@@ -3,39 +3,253 @@ package service
 import (
 	"net/http"
 	"net/url"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/AlekSi/pointer"
 	"github.com/labstack/echo/v4"
 	"github.com/oapi-codegen/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/projectsyn/lieutenant-api/pkg/api"
+	"github.com/projectsyn/lieutenant-api/pkg/inventory"
 )
 
-func TestQueryInventory(t *testing.T) {
-	e, _ := setupTest(t)
+func newTestInventory(t *testing.T) inventory.Store {
+	store, err := inventory.NewBoltStore(filepath.Join(t.TempDir(), "inventory.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func updateInventory(t *testing.T, e *echo.Echo, cluster string, inv map[string]any) {
+	result := testutil.NewRequest().
+		Post("/inventory").
+		WithJsonBody(api.Inventory{
+			Cluster:   cluster,
+			Inventory: &inv,
+		}).
+		WithHeader(echo.HeaderAuthorization, serviceAccountBearerToken(cluster)).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusCreated, result)
+}
 
-	query := "SELECT LAST(version,cloud) FROM mycluster"
+func queryInventory(t *testing.T, e *echo.Echo, query string) []api.Inventory {
 	result := testutil.NewRequest().
+		Get("/inventory?"+query).
 		WithHeader(echo.HeaderAuthorization, bearerToken).
-		Get("/inventory?q="+url.QueryEscape(query)).
 		GoWithHTTPHandler(t, e)
-	requireHTTPCode(t, http.StatusInternalServerError, result)
+	requireHTTPCode(t, http.StatusOK, result)
+	entries := []api.Inventory{}
+	require.NoError(t, result.UnmarshalJsonToObject(&entries))
+	return entries
 }
 
 func TestUpdateInventory(t *testing.T) {
 	e, _ := setupTest(t)
 
-	updateInventory := api.Inventory{
-		Cluster: "cluster-a",
-		Inventory: &map[string]interface{}{
-			"fact":    "one",
-			"another": "fact",
+	before := time.Now().Add(-time.Second)
+	updateInventory(t, e, clusterA.Name, map[string]any{
+		"fact":    "one",
+		"another": "fact",
+	})
+
+	entries := queryInventory(t, e, "")
+	require.Len(t, entries, 1)
+	assert.Equal(t, clusterA.Name, entries[0].Cluster)
+	require.NotNil(t, entries[0].Timestamp)
+	assert.True(t, entries[0].Timestamp.After(before))
+	require.NotNil(t, entries[0].Inventory)
+	assert.Equal(t, "one", (*entries[0].Inventory)["fact"])
+}
+
+func TestUpdateInventoryClusterNotFound(t *testing.T) {
+	e, _ := setupTest(t)
+
+	result := testutil.NewRequest().
+		Post("/inventory").
+		WithJsonBody(api.Inventory{Cluster: "c-not-existing"}).
+		WithHeader(echo.HeaderAuthorization, serviceAccountBearerToken("c-not-existing")).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusNotFound, result)
+}
+
+func TestUpdateInventoryForbidden(t *testing.T) {
+	tcs := map[string]struct {
+		token   string
+		cluster string
+		code    int
+	}{
+		"reader": {
+			token:   unprivilegedBearerToken,
+			cluster: clusterA.Name,
+			code:    http.StatusForbidden,
+		},
+		"status writer": {
+			token:   bearerToken,
+			cluster: clusterA.Name,
+			code:    http.StatusForbidden,
+		},
+		"own cluster": {
+			token:   serviceAccountBearerToken(clusterA.Name),
+			cluster: clusterA.Name,
+			code:    http.StatusCreated,
 		},
+		"other cluster": {
+			token:   serviceAccountBearerToken(clusterA.Name),
+			cluster: clusterB.Name,
+			code:    http.StatusForbidden,
+		},
+	}
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			e, _ := setupTest(t)
+
+			result := testutil.NewRequest().
+				Post("/inventory").
+				WithJsonBody(api.Inventory{Cluster: tc.cluster}).
+				WithHeader(echo.HeaderAuthorization, tc.token).
+				GoWithHTTPHandler(t, e)
+			requireHTTPCode(t, tc.code, result)
+		})
 	}
+}
+
+func TestQueryInventory(t *testing.T) {
+	e, _ := setupTest(t)
+	assert.Empty(t, queryInventory(t, e, ""))
+
+	updateInventory(t, e, clusterA.Name, map[string]any{"version": "1"})
+	between := time.Now()
+	time.Sleep(time.Millisecond)
+	updateInventory(t, e, clusterA.Name, map[string]any{"version": "2"})
+	updateInventory(t, e, clusterB.Name, map[string]any{"version": "3"})
+
+	versions := func(entries []api.Inventory) []any {
+		v := make([]any, 0, len(entries))
+		for _, entry := range entries {
+			v = append(v, (*entry.Inventory)["version"])
+		}
+		return v
+	}
+
+	assert.Equal(t, []any{"1", "2", "3"}, versions(queryInventory(t, e, "")))
+	assert.Equal(t, []any{"1", "2"}, versions(queryInventory(t, e, "cluster="+clusterA.Name)))
+	assert.Equal(t, []any{"2", "3"}, versions(queryInventory(t, e, "latest=true")))
+	assert.Equal(t, []any{"2", "3"}, versions(queryInventory(t, e, "from="+url.QueryEscape(between.Format(time.RFC3339Nano)))))
+	assert.Equal(t, []any{"1"}, versions(queryInventory(t, e, "latest=true&to="+url.QueryEscape(between.Format(time.RFC3339Nano)))))
+}
+
+func TestQueryInventoryInvalidRange(t *testing.T) {
+	e, _ := setupTest(t)
+
+	now := time.Now()
 	result := testutil.NewRequest().
-		Post("/inventory").
-		WithJsonBody(updateInventory).
+		Get("/inventory?from="+url.QueryEscape(now.Format(time.RFC3339))+"&to="+url.QueryEscape(now.Add(-time.Hour).Format(time.RFC3339))).
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusBadRequest, result)
+}
+
+func TestQueryInventoryClusterNotFound(t *testing.T) {
+	e, _ := setupTest(t)
+
+	result := testutil.NewRequest().
+		Get("/inventory?cluster=c-not-existing").
 		WithHeader(echo.HeaderAuthorization, bearerToken).
 		GoWithHTTPHandler(t, e)
-	requireHTTPCode(t, http.StatusInternalServerError, result)
+	requireHTTPCode(t, http.StatusNotFound, result)
+}
+
+func searchInventory(t *testing.T, e *echo.Echo, query api.InventoryQuery) []api.InventoryQueryRow {
+	result := testutil.NewRequest().
+		Post("/inventory/query").
+		WithJsonBody(query).
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+	rows := []api.InventoryQueryRow{}
+	require.NoError(t, result.UnmarshalJsonToObject(&rows))
+	return rows
+}
+
+func TestSearchInventory(t *testing.T) {
+	e, _ := setupTest(t)
+
+	updateInventory(t, e, clusterA.Name, map[string]any{"version": "1", "cloud": "cloudscale"})
+	updateInventory(t, e, clusterA.Name, map[string]any{"version": "2", "cloud": "cloudscale"})
+	updateInventory(t, e, clusterB.Name, map[string]any{"version": "2", "cloud": "exoscale"})
+
+	t.Run("fields", func(t *testing.T) {
+		rows := searchInventory(t, e, api.InventoryQuery{
+			Clusters: &[]string{clusterA.Name},
+			Fields:   &[]string{"cluster", "inventory.version"},
+		})
+		require.Len(t, rows, 2)
+		assert.Equal(t, map[string]any{"cluster": clusterA.Name, "inventory.version": "1"}, rows[0].Values)
+		assert.Equal(t, map[string]any{"cluster": clusterA.Name, "inventory.version": "2"}, rows[1].Values)
+	})
+	t.Run("last by cluster", func(t *testing.T) {
+		rows := searchInventory(t, e, api.InventoryQuery{
+			Fields:      &[]string{"inventory.version", "inventory.cloud"},
+			Aggregation: pointer.To(api.InventoryQueryAggregationLast),
+			GroupBy:     &[]string{"cluster"},
+		})
+		require.Len(t, rows, 2)
+		assert.Equal(t, map[string]any{"cluster": clusterA.Name}, rows[0].Group)
+		assert.Equal(t, map[string]any{"inventory.version": "2", "inventory.cloud": "cloudscale"}, rows[0].Values)
+		assert.Equal(t, 2, rows[0].Entries)
+		assert.Equal(t, map[string]any{"inventory.version": "2", "inventory.cloud": "exoscale"}, rows[1].Values)
+	})
+	t.Run("count latest by version", func(t *testing.T) {
+		rows := searchInventory(t, e, api.InventoryQuery{
+			Latest:      pointer.ToBool(true),
+			Fields:      &[]string{"cluster"},
+			Aggregation: pointer.To(api.InventoryQueryAggregationCount),
+			GroupBy:     &[]string{"inventory.version"},
+		})
+		require.Len(t, rows, 1)
+		assert.Equal(t, map[string]any{"inventory.version": "2"}, rows[0].Group)
+		assert.Equal(t, map[string]any{"cluster": float64(2)}, rows[0].Values)
+	})
+	t.Run("distinct by tenant", func(t *testing.T) {
+		rows := searchInventory(t, e, api.InventoryQuery{
+			Tenant:      pointer.ToString(tenantA.Name),
+			Fields:      &[]string{"inventory.version"},
+			Aggregation: pointer.To(api.InventoryQueryAggregationDistinct),
+			GroupBy:     &[]string{"tenant"},
+		})
+		require.Len(t, rows, 1)
+		assert.Equal(t, map[string]any{"tenant": tenantA.Name}, rows[0].Group)
+		assert.Equal(t, map[string]any{"inventory.version": []any{"1", "2"}}, rows[0].Values)
+	})
+}
+
+func TestSearchInventoryInvalid(t *testing.T) {
+	e, _ := setupTest(t)
+
+	tcs := map[string]any{
+		"invalid path": api.InventoryQuery{
+			Fields: &[]string{"version"},
+		},
+		"unknown aggregation": map[string]any{
+			"aggregation": "sum",
+		},
+		"invalid range": api.InventoryQuery{
+			From: pointer.ToTime(time.Now()),
+			To:   pointer.ToTime(time.Now().Add(-time.Hour)),
+		},
+	}
+	for name, query := range tcs {
+		t.Run(name, func(t *testing.T) {
+			result := testutil.NewRequest().
+				Post("/inventory/query").
+				WithJsonBody(query).
+				WithHeader(echo.HeaderAuthorization, bearerToken).
+				GoWithHTTPHandler(t, e)
+			requireHTTPCode(t, http.StatusBadRequest, result)
+		})
+	}
 }
@@ -1,14 +1,18 @@
 package service
 
 import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
 	"net/http"
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 
-	lruCache "github.com/hashicorp/golang-lru/v2"
 	"github.com/labstack/echo/v4"
-	"k8s.io/apimachinery/pkg/util/runtime"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 )
@@ -24,6 +28,7 @@ var (
 	noAuth = map[string]bool{
 		"/":             true,
 		"/healthz":      true,
+		"/metrics":      true,
 		"/openapi.json": true,
 		"/docs":         true,
 	}
@@ -42,26 +47,44 @@ func getCacheSizeOrDefault(def int) int {
 	return parsed
 }
 
-func createCache() *lruCache.Cache[string, client.Client] {
-	cache, err := lruCache.NewWithEvict[string, client.Client](getCacheSizeOrDefault(128), nil)
-	runtime.Must(err)
-	return cache
-}
-
 // KubernetesAuth provides middleware to authenticate with Kubernetes JWT tokens
 type KubernetesAuth struct {
 	CreateClientFunc func(string) (client.Client, error)
-	cache            *lruCache.Cache[string, client.Client]
+	// Impersonate reviews the bearer token and uses a single client which impersonates the token's user,
+	// instead of creating a client for each token.
+	Impersonate bool
+	// CreateImpersonatingClientFunc creates the client used if Impersonate is set.
+	// The client must impersonate the user in the context of each request, see withImpersonatedUser.
+	CreateImpersonatingClientFunc func() (client.Client, error)
+	// OIDC validates tokens issued by the OIDC identity provider, if set.
+	// Other tokens are reviewed with ReviewTokenFunc.
+	OIDC *OIDCAuth
+	// ReviewTokenFunc returns the user a token belongs to.
+	// Defaults to a TokenReview with the API's own client.
+	ReviewTokenFunc func(ctx context.Context, token string) (authenticationv1.UserInfo, error)
+	// ReviewAccessFunc returns true if the user is allowed to access the resource.
+	// Defaults to a SubjectAccessReview with the API's own client.
+	ReviewAccessFunc func(ctx context.Context, user authenticationv1.UserInfo, attrs authorizationv1.ResourceAttributes) (bool, error)
+	cache            *clientCache
+	// reviews caches the users of reviewed tokens, tokens are reviewed on every request if it's nil
+	reviews *tokenCache[authenticationv1.UserInfo]
 }
 
 // DefaultKubernetesAuth uses the JWT bearer token to authenticate
 var DefaultKubernetesAuth = &KubernetesAuth{
-	CreateClientFunc: getClientFromToken,
-	cache:            createCache(),
+	CreateClientFunc:              getClientFromToken,
+	Impersonate:                   os.Getenv(K8sAuthModeEnvKey) == K8sAuthModeImpersonation,
+	CreateImpersonatingClientFunc: newImpersonatingClient,
+	cache:                         createCache(),
+	reviews:                       createReviewCache(),
 }
 
 // JWTAuth makes sure a JWT bearer token is provided and creates a Kubernetes client
 func (k *KubernetesAuth) JWTAuth(next echo.HandlerFunc) echo.HandlerFunc {
+	// The impersonating client is shared by all requests and created on first use
+	impersonatingClient := sync.OnceValues(func() (client.Client, error) {
+		return k.CreateImpersonatingClientFunc()
+	})
 	return func(c echo.Context) error {
 		var token string
 
@@ -81,24 +104,145 @@ func (k *KubernetesAuth) JWTAuth(next echo.HandlerFunc) echo.HandlerFunc {
 			token = t
 		}
 
-		cachedClient, exists := k.cache.Get(token)
-		if !exists {
-			var err error
-			cachedClient, err = k.CreateClientFunc(token)
-			if err != nil {
-				return err
-			}
-			k.cache.Add(token, cachedClient)
+		if k.Impersonate && token != "" {
+			return k.impersonate(c, token, impersonatingClient, next)
+		}
+
+		cachedClient, err := k.getClient(token)
+		if err != nil {
+			return err
 		}
 
 		apiContext := &APIContext{
 			Context: c,
-			client:  cachedClient.(client.Client),
+			client:  cachedClient,
+			token:   token,
+			auth:    k,
 		}
 		return next(apiContext)
 	}
 }
 
+// impersonate reviews the token and calls the handler with the client impersonating the token's user
+func (k *KubernetesAuth) impersonate(c echo.Context, token string, getClient func() (client.Client, error), next echo.HandlerFunc) error {
+	user, err := k.reviewToken(c.Request().Context(), token)
+	if err != nil {
+		return err
+	}
+	impersonatingClient, err := getClient()
+	if err != nil {
+		return err
+	}
+	c.SetRequest(c.Request().WithContext(withImpersonatedUser(c.Request().Context(), user)))
+
+	apiContext := &APIContext{
+		Context: c,
+		client:  impersonatingClient,
+		token:   token,
+		auth:    k,
+		user:    &user,
+	}
+	return next(apiContext)
+}
+
+// getClient returns the cached client for the token or creates a new one
+func (k *KubernetesAuth) getClient(token string) (client.Client, error) {
+	cachedClient, exists := k.cache.Get(token)
+	if !exists {
+		var err error
+		cachedClient, err = k.CreateClientFunc(token)
+		if err != nil {
+			return nil, err
+		}
+		k.cache.Add(token, cachedClient)
+	}
+	return cachedClient, nil
+}
+
+// reviewToken returns the user the token belongs to.
+// Successful reviews are cached until the token expires or the TTL is reached.
+func (k *KubernetesAuth) reviewToken(ctx context.Context, token string) (authenticationv1.UserInfo, error) {
+	if k.reviews == nil {
+		return k.reviewUncachedToken(ctx, token)
+	}
+	if user, ok := k.reviews.Get(token); ok {
+		return user, nil
+	}
+	user, err := k.reviewUncachedToken(ctx, token)
+	if err != nil {
+		return user, err
+	}
+	k.reviews.Add(token, user)
+	return user, nil
+}
+
+func (k *KubernetesAuth) reviewUncachedToken(ctx context.Context, token string) (authenticationv1.UserInfo, error) {
+	if k.OIDC == nil {
+		return k.reviewKubernetesToken(ctx, token)
+	}
+	user, issued, err := k.OIDC.Review(ctx, token)
+	if issued {
+		return user, err
+	}
+	user, reviewErr := k.reviewKubernetesToken(ctx, token)
+	if reviewErr != nil && err != nil {
+		// The token might have been issued by the identity provider which couldn't be reached
+		return user, err
+	}
+	return user, reviewErr
+}
+
+// reviewKubernetesToken returns the user of a token known to Kubernetes, like the token of a ServiceAccount
+func (k *KubernetesAuth) reviewKubernetesToken(ctx context.Context, token string) (authenticationv1.UserInfo, error) {
+	if k.ReviewTokenFunc != nil {
+		return k.ReviewTokenFunc(ctx, token)
+	}
+	c, err := k.getClient("")
+	if err != nil {
+		return authenticationv1.UserInfo{}, err
+	}
+	review := &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{
+			Token: token,
+		},
+	}
+	if err := c.Create(ctx, review); err != nil {
+		return authenticationv1.UserInfo{}, err
+	}
+	if !review.Status.Authenticated {
+		return authenticationv1.UserInfo{}, echo.NewHTTPError(http.StatusUnauthorized, "Token not authenticated")
+	}
+	return review.Status.User, nil
+}
+
+// reviewAccess returns true if the user is allowed to access the resource
+func (k *KubernetesAuth) reviewAccess(ctx context.Context, user authenticationv1.UserInfo, attrs authorizationv1.ResourceAttributes) (bool, error) {
+	if k.ReviewAccessFunc != nil {
+		return k.ReviewAccessFunc(ctx, user, attrs)
+	}
+	c, err := k.getClient("")
+	if err != nil {
+		return false, err
+	}
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for key, value := range user.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &attrs,
+			User:               user.Username,
+			Groups:             user.Groups,
+			UID:                user.UID,
+			Extra:              extra,
+		},
+	}
+	if err := c.Create(ctx, review); err != nil {
+		return false, err
+	}
+	return review.Status.Allowed, nil
+}
+
 func getClientFromToken(token string) (client.Client, error) {
 	cfg, err := config.GetConfig()
 	if err != nil {
@@ -128,3 +272,27 @@ func extractToken(c echo.Context) (string, error) {
 	}
 	return "", ErrJWTMissing
 }
+
+// jwtClaims are the claims of a JWT used before the token is verified
+type jwtClaims struct {
+	Issuer string `json:"iss"`
+	Expiry int64  `json:"exp"`
+}
+
+// parseUnverifiedClaims returns the claims of a JWT without verifying it.
+// The second return value is false if the token isn't a JWT.
+func parseUnverifiedClaims(token string) (jwtClaims, bool) {
+	claims := jwtClaims{}
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return claims, false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return claims, false
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return claims, false
+	}
+	return claims, true
+}
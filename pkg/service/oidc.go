@@ -0,0 +1,243 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/labstack/echo/v4"
+	authenticationv1 "k8s.io/api/authentication/v1"
+)
+
+const (
+	// K8sAuthModeOIDC validates OIDC tokens with the keys of the identity provider and impersonates the token's user.
+	// Other tokens are reviewed with TokenReview.
+	K8sAuthModeOIDC = "oidc"
+
+	// OIDCUsernameClaimEnvKey is the env var name that's used to get the claim which contains the username
+	OIDCUsernameClaimEnvKey = "OIDC_USERNAME_CLAIM"
+	// OIDCUsernamePrefixEnvKey is the env var name that's used to get the prefix added to usernames
+	OIDCUsernamePrefixEnvKey = "OIDC_USERNAME_PREFIX"
+	// OIDCGroupsClaimEnvKey is the env var name that's used to get the claim which contains the groups
+	OIDCGroupsClaimEnvKey = "OIDC_GROUPS_CLAIM"
+	// OIDCGroupsPrefixEnvKey is the env var name that's used to get the prefix added to groups
+	OIDCGroupsPrefixEnvKey = "OIDC_GROUPS_PREFIX"
+
+	defaultOIDCUsernameClaim = "sub"
+	defaultOIDCGroupsClaim   = "groups"
+	defaultOIDCPrefix        = "oidc:"
+	// noOIDCPrefix disables the prefix
+	noOIDCPrefix = "-"
+
+	// systemPrefix is reserved for identities of Kubernetes itself
+	systemPrefix = "system:"
+	// authenticatedGroup is the group Kubernetes adds to all authenticated users
+	authenticatedGroup = "system:authenticated"
+
+	// oidcDiscoveryBackoff is how long the discovery document isn't fetched again after it couldn't be fetched
+	oidcDiscoveryBackoff = 10 * time.Second
+)
+
+// OIDCAuth validates OIDC tokens against the discovery document and keys of the identity provider
+// and maps their claims to a Kubernetes user.
+type OIDCAuth struct {
+	DiscoveryURL string
+	ClientID     string
+
+	UsernameClaim  string
+	UsernamePrefix string
+	GroupsClaim    string
+	GroupsPrefix   string
+
+	// HTTPClient is used to get the discovery document and the keys
+	HTTPClient *http.Client
+
+	mutex    sync.Mutex
+	issuer   string
+	verifier *oidc.IDTokenVerifier
+	// discoveryErr is returned without fetching the discovery document until oidcDiscoveryBackoff passed since discoveryFailedAt
+	discoveryErr      error
+	discoveryFailedAt time.Time
+}
+
+// NewOIDCAuth creates an OIDCAuth for the identity provider and reads the claim mapping from the environment
+func NewOIDCAuth(discoveryURL, clientID string) (*OIDCAuth, error) {
+	if discoveryURL == "" || clientID == "" {
+		return nil, fmt.Errorf("the %s auth mode requires an OIDC discovery URL and client ID", K8sAuthModeOIDC)
+	}
+	return &OIDCAuth{
+		DiscoveryURL:   discoveryURL,
+		ClientID:       clientID,
+		UsernameClaim:  getEnvOrDefault(OIDCUsernameClaimEnvKey, defaultOIDCUsernameClaim),
+		UsernamePrefix: getEnvOrDefault(OIDCUsernamePrefixEnvKey, defaultOIDCPrefix),
+		GroupsClaim:    getEnvOrDefault(OIDCGroupsClaimEnvKey, defaultOIDCGroupsClaim),
+		GroupsPrefix:   getEnvOrDefault(OIDCGroupsPrefixEnvKey, defaultOIDCPrefix),
+	}, nil
+}
+
+func getEnvOrDefault(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// Review validates the token and returns the user it identifies.
+// The second return value is false if the token wasn't issued by the identity provider.
+func (o *OIDCAuth) Review(ctx context.Context, token string) (authenticationv1.UserInfo, bool, error) {
+	issuer, verifier, err := o.getVerifier(ctx)
+	if err != nil {
+		return authenticationv1.UserInfo{}, false, err
+	}
+	if claims, _ := parseUnverifiedClaims(token); claims.Issuer != issuer {
+		return authenticationv1.UserInfo{}, false, nil
+	}
+
+	idToken, err := verifier.Verify(ctx, token)
+	if err != nil {
+		return authenticationv1.UserInfo{}, true, echo.NewHTTPError(http.StatusUnauthorized, fmt.Sprintf("Invalid OIDC token: %s", err))
+	}
+	claims := map[string]any{}
+	if err := idToken.Claims(&claims); err != nil {
+		return authenticationv1.UserInfo{}, true, echo.NewHTTPError(http.StatusUnauthorized, fmt.Sprintf("Invalid OIDC token: %s", err))
+	}
+	user, err := o.userInfo(claims)
+	if err != nil {
+		return authenticationv1.UserInfo{}, true, echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+	}
+	return user, true, nil
+}
+
+// userInfo maps the claims of a token to a Kubernetes user
+func (o *OIDCAuth) userInfo(claims map[string]any) (authenticationv1.UserInfo, error) {
+	username, ok := claims[o.UsernameClaim].(string)
+	if !ok || username == "" {
+		return authenticationv1.UserInfo{}, fmt.Errorf("OIDC token has no username claim '%s'", o.UsernameClaim)
+	}
+	if o.UsernameClaim == "email" {
+		if verified, _ := claims["email_verified"].(bool); !verified {
+			return authenticationv1.UserInfo{}, fmt.Errorf("email '%s' of OIDC token isn't verified", username)
+		}
+	}
+	user := authenticationv1.UserInfo{
+		Username: withOIDCPrefix(o.UsernamePrefix, username),
+		Groups:   []string{},
+	}
+	if strings.HasPrefix(user.Username, systemPrefix) {
+		return authenticationv1.UserInfo{}, fmt.Errorf("OIDC username '%s' is reserved", user.Username)
+	}
+
+	var groups []string
+	switch value := claims[o.GroupsClaim].(type) {
+	case string:
+		groups = []string{value}
+	case []any:
+		for _, g := range value {
+			if group, ok := g.(string); ok {
+				groups = append(groups, group)
+			}
+		}
+	}
+	for _, group := range groups {
+		group = withOIDCPrefix(o.GroupsPrefix, group)
+		// Groups of Kubernetes itself are never granted through OIDC
+		if !strings.HasPrefix(group, systemPrefix) {
+			user.Groups = append(user.Groups, group)
+		}
+	}
+	user.Groups = append(user.Groups, authenticatedGroup)
+	return user, nil
+}
+
+func withOIDCPrefix(prefix, value string) string {
+	if prefix == noOIDCPrefix {
+		return value
+	}
+	return prefix + value
+}
+
+// getVerifier returns the verifier for tokens of the identity provider.
+// The discovery document is fetched on first use and again after it couldn't be fetched,
+// but at most once per oidcDiscoveryBackoff after a failure.
+// It's fetched without holding the lock, so a slow identity provider doesn't block other requests.
+func (o *OIDCAuth) getVerifier(ctx context.Context) (string, *oidc.IDTokenVerifier, error) {
+	o.mutex.Lock()
+	issuer, verifier := o.issuer, o.verifier
+	discoveryErr, failedAt := o.discoveryErr, o.discoveryFailedAt
+	o.mutex.Unlock()
+	if verifier != nil {
+		return issuer, verifier, nil
+	}
+	if discoveryErr != nil && time.Since(failedAt) < oidcDiscoveryBackoff {
+		return "", nil, discoveryErr
+	}
+
+	providerConfig, err := o.discover(ctx)
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	if o.verifier != nil {
+		// Another request fetched the discovery document in the meantime
+		return o.issuer, o.verifier, nil
+	}
+	if err != nil {
+		err = fmt.Errorf("failed to get OIDC discovery document: %w", err)
+		// A canceled request says nothing about the identity provider
+		if ctx.Err() == nil {
+			o.discoveryErr = err
+			o.discoveryFailedAt = time.Now()
+		}
+		return "", nil, err
+	}
+	providerCtx := context.Background()
+	if o.HTTPClient != nil {
+		providerCtx = oidc.ClientContext(providerCtx, o.HTTPClient)
+	}
+	provider := providerConfig.NewProvider(providerCtx)
+	o.issuer = providerConfig.IssuerURL
+	o.verifier = provider.Verifier(&oidc.Config{ClientID: o.ClientID})
+	o.discoveryErr = nil
+	return o.issuer, o.verifier, nil
+}
+
+func (o *OIDCAuth) discover(ctx context.Context) (*oidc.ProviderConfig, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, o.DiscoveryURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	httpClient := o.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", res.Status, body)
+	}
+	providerConfig := &oidc.ProviderConfig{}
+	if err := json.Unmarshal(body, providerConfig); err != nil {
+		return nil, err
+	}
+	if providerConfig.IssuerURL == "" || providerConfig.JWKSURL == "" {
+		return nil, fmt.Errorf("discovery document of '%s' has no issuer or JWKS URI", o.DiscoveryURL)
+	}
+	// Same check as oidc.NewProvider, otherwise the discovery document could name any issuer
+	if issuer := strings.TrimSuffix(o.DiscoveryURL, "/.well-known/openid-configuration"); providerConfig.IssuerURL != issuer {
+		return nil, fmt.Errorf("discovery document of '%s' has issuer '%s', expected '%s'", o.DiscoveryURL, providerConfig.IssuerURL, issuer)
+	}
+	return providerConfig, nil
+}
@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/coreos/go-oidc/v3/oidc/oidctest"
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+const (
+	testOIDCKeyID    = "test-key"
+	testOIDCClientID = "lieutenant"
+)
+
+// testIssuer is a local OIDC identity provider
+type testIssuer struct {
+	URL string
+	key *rsa.PrivateKey
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	s := &oidctest.Server{
+		PublicKeys: []oidctest.PublicKey{{
+			PublicKey: key.Public(),
+			KeyID:     testOIDCKeyID,
+			Algorithm: oidc.RS256,
+		}},
+	}
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+	s.SetIssuer(srv.URL)
+	return &testIssuer{URL: srv.URL, key: key}
+}
+
+// token returns a token for the API with the given subject and groups, signed by the issuer
+func (i *testIssuer) token(subject string, groups ...string) string {
+	return i.sign(i.key, i.URL, testOIDCClientID, subject, time.Now().Add(time.Hour), groups...)
+}
+
+func (i *testIssuer) sign(key *rsa.PrivateKey, issuer, audience, subject string, expiry time.Time, groups ...string) string {
+	claims, err := json.Marshal(map[string]any{
+		"iss":    issuer,
+		"aud":    audience,
+		"sub":    subject,
+		"exp":    expiry.Unix(),
+		"groups": groups,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return oidctest.SignIDToken(key, testOIDCKeyID, oidc.RS256, string(claims))
+}
+
+// setupOIDCTest sets up the API server in the OIDC auth mode.
+// The returned users are the users impersonated by the API.
+func setupOIDCTest(t *testing.T, issuer *testIssuer) (*echo.Echo, *[]authenticationv1.UserInfo) {
+	f := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(tenantA).
+		Build()
+	users := &[]authenticationv1.UserInfo{}
+	impersonating := interceptor.NewClient(f, interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			user, ok := ctx.Value(impersonatedUserKey{}).(authenticationv1.UserInfo)
+			require.True(t, ok, "request without impersonated user")
+			*users = append(*users, user)
+			return c.Get(ctx, key, obj, opts...)
+		},
+	})
+
+	oidcAuth, err := NewOIDCAuth(issuer.URL+"/.well-known/openid-configuration", testOIDCClientID)
+	require.NoError(t, err)
+	auth := KubernetesAuth{
+		Impersonate: true,
+		OIDC:        oidcAuth,
+		CreateClientFunc: func(string) (client.Client, error) {
+			return f, nil
+		},
+		CreateImpersonatingClientFunc: func() (client.Client, error) {
+			return impersonating, nil
+		},
+		ReviewTokenFunc: func(ctx context.Context, token string) (authenticationv1.UserInfo, error) {
+			if token == "invalid" {
+				return authenticationv1.UserInfo{}, echo.NewHTTPError(http.StatusUnauthorized, "Token not authenticated")
+			}
+			return reviewTestToken(ctx, token)
+		},
+		ReviewAccessFunc: reviewTestAccess,
+		cache:            createCache(),
+	}
+	e, err := NewAPIServer(APIConfig{APIVersion: "v1", Namespace: "default"}, auth)
+	require.NoError(t, err)
+	return e, users
+}
+
+func getTenantWithToken(t *testing.T, e *echo.Echo, token string) *testutil.CompletedRequest {
+	return testutil.NewRequest().
+		Get("/tenants/"+tenantA.Name).
+		WithHeader(echo.HeaderAuthorization, "Bearer "+token).
+		GoWithHTTPHandler(t, e)
+}
+
+func TestOIDCAuth(t *testing.T) {
+	issuer := newTestIssuer(t)
+	e, users := setupOIDCTest(t, issuer)
+
+	result := getTenantWithToken(t, e, issuer.token("alice", "admins", "system:masters"))
+	requireHTTPCode(t, http.StatusOK, result)
+	require.Len(t, *users, 1)
+	assert.Equal(t, authenticationv1.UserInfo{
+		Username: "oidc:alice",
+		// The prefix keeps groups out of the reserved system: namespace
+		Groups: []string{"oidc:admins", "oidc:system:masters", "system:authenticated"},
+	}, (*users)[0])
+}
+
+func TestOIDCAuthServiceAccount(t *testing.T) {
+	issuer := newTestIssuer(t)
+	e, users := setupOIDCTest(t, issuer)
+
+	result := getTenantWithToken(t, e, serviceAccountTokenPrefix+"steward")
+	requireHTTPCode(t, http.StatusOK, result)
+	require.Len(t, *users, 1)
+	assert.Equal(t, serviceAccountUsername("default", "steward"), (*users)[0].Username)
+
+	result = getTenantWithToken(t, e, "invalid")
+	requireHTTPCode(t, http.StatusUnauthorized, result)
+}
+
+func TestOIDCAuthInvalidToken(t *testing.T) {
+	issuer := newTestIssuer(t)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	inOneHour := time.Now().Add(time.Hour)
+
+	tests := map[string]string{
+		"expired":        issuer.sign(issuer.key, issuer.URL, testOIDCClientID, "alice", time.Now().Add(-time.Minute)),
+		"other audience": issuer.sign(issuer.key, issuer.URL, "other", "alice", inOneHour),
+		"other key":      issuer.sign(otherKey, issuer.URL, testOIDCClientID, "alice", inOneHour),
+		"no subject":     issuer.sign(issuer.key, issuer.URL, testOIDCClientID, "", inOneHour),
+	}
+	for name, token := range tests {
+		t.Run(name, func(t *testing.T) {
+			e, users := setupOIDCTest(t, issuer)
+			result := getTenantWithToken(t, e, token)
+			requireHTTPCode(t, http.StatusUnauthorized, result)
+			assert.Empty(t, *users)
+		})
+	}
+}
+
+func TestOIDCAuthIssuerUnavailable(t *testing.T) {
+	issuer := newTestIssuer(t)
+	e, _ := setupOIDCTest(t, &testIssuer{URL: "http://127.0.0.1:1", key: issuer.key})
+
+	// Service account tokens are still reviewed
+	result := getTenantWithToken(t, e, serviceAccountTokenPrefix+"steward")
+	requireHTTPCode(t, http.StatusOK, result)
+
+	result = getTenantWithToken(t, e, "invalid")
+	requireHTTPCode(t, http.StatusInternalServerError, result)
+}
+
+func TestOIDCAuthIssuerMismatch(t *testing.T) {
+	issuer := newTestIssuer(t)
+	s := &oidctest.Server{
+		PublicKeys: []oidctest.PublicKey{{
+			PublicKey: issuer.key.Public(),
+			KeyID:     testOIDCKeyID,
+			Algorithm: oidc.RS256,
+		}},
+	}
+	s.SetIssuer(issuer.URL)
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+	o, err := NewOIDCAuth(srv.URL+"/.well-known/openid-configuration", testOIDCClientID)
+	require.NoError(t, err)
+
+	// The token is valid for the issuer named in the discovery document, which isn't the one configured
+	_, _, err = o.Review(context.Background(), issuer.token("alice"))
+	assert.ErrorContains(t, err, "has issuer '"+issuer.URL+"', expected '"+srv.URL+"'")
+}
+
+func TestOIDCAuthDiscoveryBackoff(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(srv.Close)
+	o, err := NewOIDCAuth(srv.URL, testOIDCClientID)
+	require.NoError(t, err)
+
+	_, _, err = o.Review(context.Background(), "token")
+	assert.ErrorContains(t, err, "503 Service Unavailable")
+	_, _, err = o.Review(context.Background(), "token")
+	assert.ErrorContains(t, err, "503 Service Unavailable")
+	assert.Equal(t, 1, requests, "discovery document must not be fetched again during the backoff")
+
+	o.discoveryFailedAt = time.Now().Add(-oidcDiscoveryBackoff)
+	_, _, err = o.Review(context.Background(), "token")
+	assert.Error(t, err)
+	assert.Equal(t, 2, requests)
+}
+
+func TestOIDCAuthUserInfo(t *testing.T) {
+	o := &OIDCAuth{
+		UsernameClaim:  "email",
+		UsernamePrefix: noOIDCPrefix,
+		GroupsClaim:    "roles",
+		GroupsPrefix:   "idp:",
+	}
+
+	user, err := o.userInfo(map[string]any{
+		"email":          "alice@example.com",
+		"email_verified": true,
+		"roles":          "admin",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", user.Username)
+	assert.Equal(t, []string{"idp:admin", "system:authenticated"}, user.Groups)
+
+	_, err = o.userInfo(map[string]any{"email": "alice@example.com", "email_verified": false})
+	assert.ErrorContains(t, err, "isn't verified")
+
+	_, err = o.userInfo(map[string]any{"email": "alice@example.com"})
+	assert.ErrorContains(t, err, "isn't verified", "a missing email_verified claim must be rejected")
+
+	_, err = o.userInfo(map[string]any{"email": "system:admin", "email_verified": true})
+	assert.ErrorContains(t, err, "is reserved")
+
+	_, err = o.userInfo(map[string]any{"sub": "alice"})
+	assert.ErrorContains(t, err, "no username claim 'email'")
+}
+
+func TestOIDCAuthModeRequiresConfig(t *testing.T) {
+	t.Setenv(K8sAuthModeEnvKey, K8sAuthModeOIDC)
+	_, err := NewAPIServer(APIConfig{APIVersion: "v1", Namespace: "default"})
+	assert.ErrorContains(t, err, "requires an OIDC discovery URL and client ID")
+}
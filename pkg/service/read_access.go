@@ -0,0 +1,222 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	synv1alpha1 "github.com/projectsyn/lieutenant-operator/api/v1alpha1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TenantClustersSubresource is the subresource of tenants which grants access to all clusters of the tenant.
+// A user allowed to `get` `tenants/clusters` of a tenant can read all clusters of the tenant through the API.
+const TenantClustersSubresource = "clusters"
+
+// getCluster gets the cluster from the read cache or with the client of the user.
+// If the user isn't allowed to get the cluster, the cluster is returned if the user is allowed to read it through its tenant.
+func (s *APIImpl) getCluster(ctx *APIContext, name string, cluster *synv1alpha1.Cluster) error {
+	key := client.ObjectKey{Name: name, Namespace: s.namespace}
+	if s.readCache != nil {
+		found := &synv1alpha1.Cluster{}
+		err := s.readCache.Get(ctx.Request().Context(), key, found)
+		if err == nil {
+			return s.readableCluster(ctx, found, cluster, errors.NewForbidden(
+				schema.GroupResource{Group: synv1alpha1.GroupVersion.Group, Resource: "clusters"}, name,
+				fmt.Errorf("user isn't allowed to read the cluster")))
+		}
+		// Clusters created moments ago might not be cached yet
+		if !errors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	err := ctx.client.Get(ctx.Request().Context(), key, cluster)
+	if !errors.IsForbidden(err) {
+		return err
+	}
+
+	c, cErr := ctx.apiClient()
+	if cErr != nil {
+		return cErr
+	}
+	found := &synv1alpha1.Cluster{}
+	if getErr := c.Get(ctx.Request().Context(), key, found); getErr != nil {
+		// Don't reveal whether the cluster exists
+		return err
+	}
+	return s.readableCluster(ctx, found, cluster, err)
+}
+
+// readableCluster copies the found cluster into the cluster if the user is allowed to read it, otherwise the forbidden error is returned
+func (s *APIImpl) readableCluster(ctx *APIContext, found, cluster *synv1alpha1.Cluster, forbidden error) error {
+	allowed, err := s.newReadAccess(ctx).cluster(*found)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return forbidden
+	}
+	found.DeepCopyInto(cluster)
+	return nil
+}
+
+// listClusters lists the clusters from the read cache or with the client of the user.
+// If the user isn't allowed to list clusters, all clusters the user is allowed to read are returned.
+func (s *APIImpl) listClusters(ctx *APIContext, list *synv1alpha1.ClusterList, opts ...client.ListOption) error {
+	access := s.newReadAccess(ctx)
+	all, err := s.list(ctx, access, "clusters", list, opts...)
+	if err != nil || all {
+		return err
+	}
+	readable := list.Items[:0]
+	for _, cluster := range list.Items {
+		allowed, err := access.cluster(cluster)
+		if err != nil {
+			return err
+		}
+		if allowed {
+			readable = append(readable, cluster)
+		}
+	}
+	list.Items = readable
+	return nil
+}
+
+// listTenants lists the tenants from the read cache or with the client of the user.
+// If the user isn't allowed to list tenants, all tenants the user is allowed to get are returned.
+func (s *APIImpl) listTenants(ctx *APIContext, list *synv1alpha1.TenantList, opts ...client.ListOption) error {
+	access := s.newReadAccess(ctx)
+	all, err := s.list(ctx, access, "tenants", list, opts...)
+	if err != nil || all {
+		return err
+	}
+	readable := list.Items[:0]
+	for _, tenant := range list.Items {
+		allowed, err := access.review("tenants", "", tenant.Name)
+		if err != nil {
+			return err
+		}
+		if allowed {
+			readable = append(readable, tenant)
+		}
+	}
+	list.Items = readable
+	return nil
+}
+
+// list lists the resource from the read cache or with the client of the user.
+// Returns true if the user is allowed to list the resource, otherwise the list contains all objects and must be filtered.
+func (s *APIImpl) list(ctx *APIContext, access *readAccess, resource string, list client.ObjectList, opts ...client.ListOption) (bool, error) {
+	if s.readCache != nil {
+		if err := s.readCache.List(ctx.Request().Context(), list, opts...); err != nil {
+			return false, err
+		}
+		return access.allowed(authorizationv1.ResourceAttributes{
+			Namespace: s.namespace,
+			Verb:      "list",
+			Group:     synv1alpha1.GroupVersion.Group,
+			Resource:  resource,
+		})
+	}
+
+	err := ctx.client.List(ctx.Request().Context(), list, opts...)
+	if !errors.IsForbidden(err) {
+		return err == nil, err
+	}
+	c, err := ctx.apiClient()
+	if err != nil {
+		return false, err
+	}
+	return false, c.List(ctx.Request().Context(), list, opts...)
+}
+
+// apiReader returns the read cache, or the client of the API itself if there's none.
+// It's used for reads which mustn't depend on the permissions of the user, the results must not be returned as is.
+func (s *APIImpl) apiReader(ctx *APIContext) (client.Reader, error) {
+	if s.readCache != nil {
+		return s.readCache, nil
+	}
+	return ctx.apiClient()
+}
+
+// readAccess reviews the read access of the user and remembers the result per tenant.
+// The results are also kept in the access review cache.
+type readAccess struct {
+	ctx       *APIContext
+	namespace string
+	tenants   map[string]bool
+	// clusters is true if the user can get all clusters of the namespace, it's nil until reviewed
+	clusters *bool
+	reviews  *expirable.LRU[string, bool]
+}
+
+func (s *APIImpl) newReadAccess(ctx *APIContext) *readAccess {
+	return &readAccess{
+		ctx:       ctx,
+		namespace: s.namespace,
+		tenants:   map[string]bool{},
+		reviews:   s.accessReviews,
+	}
+}
+
+// cluster returns true if the user can get the cluster itself or the clusters of its tenant.
+// The cluster itself is only reviewed if neither the tenant nor a grant on all clusters allows reading it.
+func (a *readAccess) cluster(cluster synv1alpha1.Cluster) (bool, error) {
+	allowed, err := a.tenantClusters(cluster.Spec.TenantRef.Name)
+	if err != nil || allowed {
+		return allowed, err
+	}
+	if a.clusters == nil {
+		all, err := a.review("clusters", "", "")
+		if err != nil {
+			return false, err
+		}
+		a.clusters = &all
+	}
+	if *a.clusters {
+		return true, nil
+	}
+	return a.review("clusters", "", cluster.Name)
+}
+
+func (a *readAccess) tenantClusters(tenant string) (bool, error) {
+	if allowed, ok := a.tenants[tenant]; ok {
+		return allowed, nil
+	}
+	allowed, err := a.review("tenants", TenantClustersSubresource, tenant)
+	if err != nil {
+		return false, err
+	}
+	a.tenants[tenant] = allowed
+	return allowed, nil
+}
+
+func (a *readAccess) review(resource, subresource, name string) (bool, error) {
+	return a.allowed(authorizationv1.ResourceAttributes{
+		Namespace:   a.namespace,
+		Verb:        "get",
+		Group:       synv1alpha1.GroupVersion.Group,
+		Resource:    resource,
+		Subresource: subresource,
+		Name:        name,
+	})
+}
+
+func (a *readAccess) allowed(attrs authorizationv1.ResourceAttributes) (bool, error) {
+	if a.reviews == nil {
+		return a.ctx.accessAllowed(attrs)
+	}
+	key := accessReviewKey(a.ctx.token, attrs)
+	if allowed, ok := a.reviews.Get(key); ok {
+		return allowed, nil
+	}
+	allowed, err := a.ctx.accessAllowed(attrs)
+	if err != nil {
+		return false, err
+	}
+	a.reviews.Add(key, allowed)
+	return allowed, nil
+}
@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/testutil"
+	synv1alpha1 "github.com/projectsyn/lieutenant-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/yaml"
+
+	"github.com/projectsyn/lieutenant-api/pkg/api"
+)
+
+var hiddenCluster = &synv1alpha1.Cluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "c-hidden",
+		Namespace: "default",
+		Labels:    map[string]string{synv1alpha1.LabelNameTenant: tenantB.Name},
+	},
+	Spec: synv1alpha1.ClusterSpec{
+		DisplayName: "Hidden",
+		TenantRef:   corev1.LocalObjectReference{Name: tenantB.Name},
+	},
+}
+
+func clusterIDs(clusters []api.Cluster) []string {
+	ids := make([]string, 0, len(clusters))
+	for _, cluster := range clusters {
+		ids = append(ids, cluster.Id.String())
+	}
+	return ids
+}
+
+func TestListClustersCustomer(t *testing.T) {
+	e, _ := setupTest(t, hiddenCluster)
+
+	result := testutil.NewRequest().
+		Get("/clusters").
+		WithHeader(echo.HeaderAuthorization, customerBearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+	clusters := []api.Cluster{}
+	require.NoError(t, result.UnmarshalJsonToObject(&clusters))
+	assert.Equal(t, []string{clusterA.Name, clusterB.Name}, clusterIDs(clusters))
+
+	result = testutil.NewRequest().
+		Get("/clusters?tenant="+tenantB.Name).
+		WithHeader(echo.HeaderAuthorization, customerBearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+	require.NoError(t, result.UnmarshalJsonToObject(&clusters))
+	assert.Equal(t, []string{clusterB.Name}, clusterIDs(clusters))
+}
+
+func TestGetClusterCustomer(t *testing.T) {
+	e, _ := setupTest(t, hiddenCluster)
+
+	for id, code := range map[string]int{
+		clusterA.Name:      http.StatusOK,
+		clusterB.Name:      http.StatusOK,
+		hiddenCluster.Name: http.StatusForbidden,
+		"c-missing":        http.StatusForbidden,
+	} {
+		t.Run(id, func(t *testing.T) {
+			result := testutil.NewRequest().
+				Get("/clusters/"+id).
+				WithHeader(echo.HeaderAuthorization, customerBearerToken).
+				GoWithHTTPHandler(t, e)
+			requireHTTPCode(t, code, result)
+		})
+	}
+}
+
+func TestUpdateClusterCustomer(t *testing.T) {
+	e, _ := setupTest(t)
+
+	// Read access through the tenant doesn't allow to change the cluster
+	result := testutil.NewRequest().
+		Patch("/clusters/"+clusterA.Name).
+		WithJsonBody(api.ClusterProperties{DisplayName: pointer.ToString("Changed")}).
+		WithContentType(api.ContentJSONPatch).
+		WithHeader(echo.HeaderAuthorization, customerBearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusForbidden, result)
+}
+
+func TestListTenantsCustomer(t *testing.T) {
+	e, _ := setupTest(t)
+
+	result := testutil.NewRequest().
+		Get("/tenants").
+		WithHeader(echo.HeaderAuthorization, customerBearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+	tenants := []api.Tenant{}
+	require.NoError(t, result.UnmarshalJsonToObject(&tenants))
+	require.Len(t, tenants, 1)
+	assert.Equal(t, tenantA.Name, tenants[0].Id.String())
+}
+
+// shippedRoleClient returns a client which rejects every request the Role in deploy/role.yaml doesn't allow
+func shippedRoleClient(t *testing.T, c client.WithWatch) client.Client {
+	raw, err := os.ReadFile("../../deploy/role.yaml")
+	require.NoError(t, err)
+	role := &rbacv1.Role{}
+	require.NoError(t, yaml.Unmarshal(raw, role))
+
+	authorize := func(obj runtime.Object, verb string) error {
+		gvk, err := apiutil.GVKForObject(obj, scheme)
+		require.NoError(t, err)
+		// All kinds used by the API have regular plurals
+		resource := schema.GroupResource{Group: gvk.Group, Resource: strings.ToLower(strings.TrimSuffix(gvk.Kind, "List")) + "s"}
+		for _, rule := range role.Rules {
+			if slices.Contains(rule.APIGroups, resource.Group) && slices.Contains(rule.Resources, resource.Resource) && slices.Contains(rule.Verbs, verb) {
+				return nil
+			}
+		}
+		return apierrors.NewForbidden(resource, "", errors.New("not allowed by deploy/role.yaml"))
+	}
+	return interceptor.NewClient(c, interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			if err := authorize(obj, "get"); err != nil {
+				return err
+			}
+			return c.Get(ctx, key, obj, opts...)
+		},
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			if err := authorize(list, "list"); err != nil {
+				return err
+			}
+			return c.List(ctx, list, opts...)
+		},
+	})
+}
+
+func TestGetClusterCustomerWithShippedRole(t *testing.T) {
+	f := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(tenantA, tenantB, clusterA, clusterB, hiddenCluster).
+		Build()
+	auth := KubernetesAuth{
+		CreateClientFunc: func(token string) (client.Client, error) {
+			if token == "" {
+				return shippedRoleClient(t, f), nil
+			}
+			return interceptor.NewClient(f, customerClientFuncs), nil
+		},
+		ReviewTokenFunc:  reviewTestToken,
+		ReviewAccessFunc: reviewTestAccess,
+		cache:            createCache(),
+	}
+	e, err := NewAPIServer(APIConfig{APIVersion: "v1", Namespace: "default"}, auth)
+	require.NoError(t, err)
+
+	// The customer can't get cluster B itself, the API gets it with its own service account
+	for id, code := range map[string]int{
+		clusterA.Name:      http.StatusOK,
+		clusterB.Name:      http.StatusOK,
+		hiddenCluster.Name: http.StatusForbidden,
+	} {
+		t.Run(id, func(t *testing.T) {
+			result := testutil.NewRequest().
+				Get("/clusters/"+id).
+				WithHeader(echo.HeaderAuthorization, customerBearerToken).
+				GoWithHTTPHandler(t, e)
+			requireHTTPCode(t, code, result)
+		})
+	}
+}
+
+func TestListClustersCustomerReviews(t *testing.T) {
+	f := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(tenantA, tenantB, clusterA, clusterB, hiddenCluster).
+		Build()
+	reviews := []authorizationv1.ResourceAttributes{}
+	auth := KubernetesAuth{
+		CreateClientFunc: func(token string) (client.Client, error) {
+			if token == "" {
+				return f, nil
+			}
+			return interceptor.NewClient(f, customerClientFuncs), nil
+		},
+		ReviewTokenFunc: reviewTestToken,
+		ReviewAccessFunc: func(ctx context.Context, user authenticationv1.UserInfo, attrs authorizationv1.ResourceAttributes) (bool, error) {
+			reviews = append(reviews, attrs)
+			return reviewTestAccess(ctx, user, attrs)
+		},
+		cache: createCache(),
+	}
+	e, err := NewAPIServer(APIConfig{APIVersion: "v1", Namespace: "default"}, auth)
+	require.NoError(t, err)
+
+	for range 2 {
+		result := testutil.NewRequest().
+			Get("/clusters").
+			WithHeader(echo.HeaderAuthorization, customerBearerToken).
+			GoWithHTTPHandler(t, e)
+		requireHTTPCode(t, http.StatusOK, result)
+		clusters := []api.Cluster{}
+		require.NoError(t, result.UnmarshalJsonToObject(&clusters))
+		assert.Equal(t, []string{clusterA.Name, clusterB.Name}, clusterIDs(clusters))
+	}
+
+	reviewed := []string{}
+	for _, attrs := range reviews {
+		reviewed = append(reviewed, strings.Trim(attrs.Resource+"/"+attrs.Subresource+"/"+attrs.Name, "/"))
+	}
+	// Cluster A is readable through its tenant, the second list is served from the access review cache
+	assert.ElementsMatch(t, []string{
+		"tenants/clusters/" + tenantA.Name,
+		"tenants/clusters/" + tenantB.Name,
+		"clusters",
+		"clusters//" + clusterB.Name,
+		"clusters//" + hiddenCluster.Name,
+	}, reviewed)
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	synv1alpha1 "github.com/projectsyn/lieutenant-operator/api/v1alpha1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+	crlog "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// ReadCacheInformer serves reads of tenants and clusters from informers
+	ReadCacheInformer = "informer"
+
+	// AccessReviewCacheTTLEnvVar is the env var name that's used to get how long access reviews are cached
+	AccessReviewCacheTTLEnvVar = "ACCESS_REVIEW_CACHE_TTL"
+
+	defaultAccessReviewCacheTTL = 10 * time.Second
+	accessReviewCacheSize       = 4096
+	readCacheSyncTimeout        = 2 * time.Minute
+)
+
+// healthChecker is implemented by read caches which can fail after they were started
+type healthChecker interface {
+	// Healthy returns the error which made the read cache fail
+	Healthy() error
+}
+
+// NewReadCache returns the reader which serves reads of tenants and clusters in the namespace.
+// Returns nil if no read cache is configured, tenants and clusters are then read from Kubernetes with the client of the user.
+// The read cache is stopped once the context is done.
+func NewReadCache(ctx context.Context, backend, namespace string) (client.Reader, error) {
+	switch backend {
+	case "":
+		return nil, nil
+	case ReadCacheInformer:
+		return newInformerCache(ctx, namespace)
+	default:
+		return nil, fmt.Errorf("unknown read cache '%s'", backend)
+	}
+}
+
+// informerCache is a cache which remembers why it stopped
+type informerCache struct {
+	cache.Cache
+
+	mutex sync.Mutex
+	err   error
+}
+
+// Healthy returns the error the informers stopped with, if any
+func (c *informerCache) Healthy() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.err
+}
+
+func (c *informerCache) fail(err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.err = err
+}
+
+// newInformerCache starts informers for tenants and clusters and waits until they're synced.
+// The informers run until the context is done, an error stopping them is logged and reported by Healthy.
+func newInformerCache(ctx context.Context, namespace string) (client.Reader, error) {
+	if namespace == "" {
+		namespace = "default"
+	}
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	c, err := cache.New(cfg, cache.Options{
+		Scheme:                      scheme,
+		DefaultNamespaces:           map[string]cache.Config{namespace: {}},
+		ReaderFailOnMissingInformer: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	ic := &informerCache{Cache: c}
+
+	for _, obj := range []client.Object{&synv1alpha1.Tenant{}, &synv1alpha1.Cluster{}} {
+		if _, err := c.GetInformer(ctx, obj); err != nil {
+			return nil, err
+		}
+	}
+
+	syncCtx, cancel := context.WithTimeout(ctx, readCacheSyncTimeout)
+	defer cancel()
+	go func() {
+		if err := c.Start(ctx); err != nil {
+			crlog.FromContext(ctx).WithName("read-cache").Error(err, "read cache stopped")
+			ic.fail(fmt.Errorf("read cache stopped: %w", err))
+			// Don't wait for the sync of a cache which won't ever sync
+			cancel()
+		}
+	}()
+
+	if !c.WaitForCacheSync(syncCtx) {
+		if err := ic.Healthy(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("read cache didn't sync within %s", readCacheSyncTimeout)
+	}
+	return ic, nil
+}
+
+// accessReviewCacheTTL returns how long access reviews are cached, invalid durations are rejected instead of using the default
+func accessReviewCacheTTL() (time.Duration, error) {
+	raw := os.Getenv(AccessReviewCacheTTLEnvVar)
+	if raw == "" {
+		return defaultAccessReviewCacheTTL, nil
+	}
+	ttl, err := time.ParseDuration(raw)
+	if err != nil || ttl <= 0 {
+		return 0, fmt.Errorf("invalid %s '%s', expected a positive duration such as '30s'", AccessReviewCacheTTLEnvVar, raw)
+	}
+	return ttl, nil
+}
+
+func newAccessReviewCache() (*expirable.LRU[string, bool], error) {
+	ttl, err := accessReviewCacheTTL()
+	if err != nil {
+		return nil, err
+	}
+	return expirable.NewLRU[string, bool](accessReviewCacheSize, nil, ttl), nil
+}
+
+// accessReviewKey identifies the result of an access review of the token's user
+func accessReviewKey(token string, attrs authorizationv1.ResourceAttributes) string {
+	return fmt.Sprintf("%s|%s|%s|%s|%s|%s|%s", hashToken(token),
+		attrs.Namespace, attrs.Verb, attrs.Group, attrs.Resource, attrs.Subresource, attrs.Name)
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/testutil"
+	synv1alpha1 "github.com/projectsyn/lieutenant-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/projectsyn/lieutenant-api/pkg/api"
+)
+
+// setupReadCacheTest sets up the API server with a read cache containing the cached objects
+func setupReadCacheTest(t *testing.T, cached []client.Object, obj ...client.Object) (*echo.Echo, client.Client) {
+	readCache := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(cached...).
+		Build()
+	return setupTestWithConfig(t, interceptor.Funcs{}, func(conf *APIConfig) {
+		conf.ReadCache = readCache
+	}, obj...)
+}
+
+func TestReadCacheGetCluster(t *testing.T) {
+	cachedClusterA := clusterA.DeepCopy()
+	cachedClusterA.Spec.DisplayName = "Cached"
+	e, _ := setupReadCacheTest(t, []client.Object{cachedClusterA}, testObjects...)
+
+	result := testutil.NewRequest().
+		Get("/clusters/"+clusterA.Name).
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+	cluster := &api.Cluster{}
+	require.NoError(t, result.UnmarshalJsonToObject(cluster))
+	assert.Equal(t, "Cached", *cluster.DisplayName)
+
+	// Clusters which aren't cached yet are read from Kubernetes
+	result = testutil.NewRequest().
+		Get("/clusters/"+clusterB.Name).
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+	require.NoError(t, result.UnmarshalJsonToObject(cluster))
+	assert.Equal(t, clusterB.Name, cluster.Id.String())
+}
+
+func TestReadCacheCustomer(t *testing.T) {
+	cached := []client.Object{tenantA, tenantB, clusterA, clusterB, hiddenCluster}
+	e, _ := setupReadCacheTest(t, cached, append(testObjects, hiddenCluster)...)
+
+	result := testutil.NewRequest().
+		Get("/clusters").
+		WithHeader(echo.HeaderAuthorization, customerBearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+	clusters := []api.Cluster{}
+	require.NoError(t, result.UnmarshalJsonToObject(&clusters))
+	assert.Equal(t, []string{clusterA.Name, clusterB.Name}, clusterIDs(clusters))
+
+	result = testutil.NewRequest().
+		Get("/clusters/"+hiddenCluster.Name).
+		WithHeader(echo.HeaderAuthorization, customerBearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusForbidden, result)
+
+	result = testutil.NewRequest().
+		Get("/tenants").
+		WithHeader(echo.HeaderAuthorization, customerBearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+	tenants := []api.Tenant{}
+	require.NoError(t, result.UnmarshalJsonToObject(&tenants))
+	require.Len(t, tenants, 1)
+	assert.Equal(t, tenantA.Name, tenants[0].Id.String())
+}
+
+func TestReadCacheInstallSteward(t *testing.T) {
+	// The bootstrap token of cluster A isn't cached yet
+	staleClusterA := clusterA.DeepCopy()
+	staleClusterA.Status.BootstrapToken = nil
+	e, f := setupReadCacheTest(t, []client.Object{staleClusterA}, testObjects...)
+
+	result := testutil.NewRequest().
+		Get("/install/steward.json?token="+clusterA.Status.BootstrapToken.Token).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+
+	cluster := &synv1alpha1.Cluster{}
+	require.NoError(t, f.Get(context.TODO(), client.ObjectKeyFromObject(clusterA), cluster))
+	assert.False(t, cluster.Status.BootstrapToken.TokenValid)
+}
+
+func TestReadAccessCachesReviews(t *testing.T) {
+	reviews := 0
+	ctx := &APIContext{
+		Context: echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder()),
+		token:   "customer",
+		auth: &KubernetesAuth{
+			ReviewTokenFunc: reviewTestToken,
+			ReviewAccessFunc: func(ctx context.Context, user authenticationv1.UserInfo, attrs authorizationv1.ResourceAttributes) (bool, error) {
+				reviews++
+				return reviewTestAccess(ctx, user, attrs)
+			},
+		},
+	}
+	reviewCache, err := newAccessReviewCache()
+	require.NoError(t, err)
+	s := &APIImpl{namespace: "default", accessReviews: reviewCache}
+
+	for range 2 {
+		allowed, err := s.newReadAccess(ctx).cluster(*clusterA)
+		require.NoError(t, err)
+		assert.True(t, allowed)
+		allowed, err = s.newReadAccess(ctx).cluster(*hiddenCluster)
+		require.NoError(t, err)
+		assert.False(t, allowed)
+	}
+	// tenants/clusters of tenant A, tenants/clusters of tenant B, all clusters and the hidden cluster
+	assert.Equal(t, 4, reviews)
+
+	// Other users aren't affected by the cached reviews
+	ctx.token = "admin"
+	ctx.user = nil
+	allowed, err := s.newReadAccess(ctx).cluster(*hiddenCluster)
+	require.NoError(t, err)
+	assert.True(t, allowed)
+	assert.Equal(t, 5, reviews)
+}
+
+func TestNewReadCacheUnknown(t *testing.T) {
+	_, err := NewReadCache(context.Background(), "redis", "default")
+	assert.ErrorContains(t, err, "unknown read cache 'redis'")
+
+	readCache, err := NewReadCache(context.Background(), "", "default")
+	assert.NoError(t, err)
+	assert.Nil(t, readCache)
+}
+
+func TestHealthzReadCacheStopped(t *testing.T) {
+	readCache := &informerCache{}
+	e, _ := setupTestWithConfig(t, interceptor.Funcs{}, func(conf *APIConfig) {
+		conf.ReadCache = readCache
+	})
+
+	result := testutil.NewRequest().Get("/healthz").GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+
+	readCache.fail(errors.New("read cache stopped: watch failed"))
+	result = testutil.NewRequest().Get("/healthz").GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusServiceUnavailable, result)
+	reason := &api.Reason{}
+	require.NoError(t, result.UnmarshalJsonToObject(reason))
+	assert.Contains(t, reason.Reason, "watch failed")
+}
+
+func TestNewAPIServerInvalidAccessReviewCacheTTL(t *testing.T) {
+	for _, ttl := range []string{"10", "-1s", "0s"} {
+		t.Run(ttl, func(t *testing.T) {
+			t.Setenv(AccessReviewCacheTTLEnvVar, ttl)
+			_, err := NewAPIServer(APIConfig{APIVersion: "v1", Namespace: "default"})
+			assert.ErrorContains(t, err, "invalid ACCESS_REVIEW_CACHE_TTL")
+		})
+	}
+}
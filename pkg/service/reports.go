@@ -0,0 +1,284 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/labstack/echo/v4"
+	synv1alpha1 "github.com/projectsyn/lieutenant-operator/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/projectsyn/lieutenant-api/pkg/api"
+)
+
+const (
+	// CompileStaleAfterEnvVar is the env var name that's used to get the age after which the last compilation of a cluster is considered stale
+	CompileStaleAfterEnvVar = "COMPILE_STALE_AFTER"
+
+	defaultCompileStaleAfter = 7 * 24 * time.Hour
+)
+
+// GetComponentReport returns the versions of the components and packages used by the clusters
+func (s *APIImpl) GetComponentReport(c echo.Context, p api.GetComponentReportParams) error {
+	ctx := c.(*APIContext)
+
+	facts, err := parseFactFilter(pointer.Get(p.Fact))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	clusters, err := s.reportClusters(ctx, pointer.GetString(p.Tenant), facts)
+	if err != nil {
+		return err
+	}
+
+	components := versionReportBuilder{}
+	packages := versionReportBuilder{}
+	for _, cluster := range clusters {
+		tenant := cluster.Spec.TenantRef.Name
+		for instance, info := range cluster.Status.CompileMeta.Instances {
+			name := info.Component
+			if name == "" {
+				name = instance
+			}
+			components.add(name, info.CompileMetaVersionInfo, cluster.Name, tenant, instance)
+		}
+		for name, info := range cluster.Status.CompileMeta.Packages {
+			packages.add(name, info, cluster.Name, tenant, "")
+		}
+	}
+
+	// Name filters narrow down the report to the asked for components and packages
+	componentNames, packageNames := pointer.Get(p.Component), pointer.Get(p.Package)
+	if len(componentNames) > 0 || len(packageNames) > 0 {
+		components.keep(componentNames)
+		packages.keep(packageNames)
+	}
+
+	return ctx.JSON(http.StatusOK, api.ComponentReport{
+		Components: components.build(),
+		Packages:   packages.build(),
+	})
+}
+
+// GetDriftReport returns the clusters whose last compilation doesn't match the desired revisions or is stale
+func (s *APIImpl) GetDriftReport(c echo.Context, p api.GetDriftReportParams) error {
+	ctx := c.(*APIContext)
+
+	facts, err := parseFactFilter(pointer.Get(p.Fact))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	staleAfter := compileStaleAfter()
+	if p.StaleAfter != nil {
+		staleAfter, err = time.ParseDuration(*p.StaleAfter)
+		if err != nil || staleAfter <= 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid age '%s', expected a positive duration such as '168h'", *p.StaleAfter))
+		}
+	}
+
+	clusters, err := s.reportClusters(ctx, pointer.GetString(p.Tenant), facts)
+	if err != nil {
+		return err
+	}
+	// The revisions inherited from the tenants are needed even if the user can only read the clusters
+	reader, err := s.apiReader(ctx)
+	if err != nil {
+		return err
+	}
+	tenantList := &synv1alpha1.TenantList{}
+	if err := reader.List(ctx.Request().Context(), tenantList, client.InNamespace(s.namespace)); err != nil {
+		return err
+	}
+	tenants := make(map[string]synv1alpha1.Tenant, len(tenantList.Items))
+	for _, tenant := range tenantList.Items {
+		tenants[tenant.Name] = tenant
+	}
+
+	staleBefore := time.Now().Add(-staleAfter)
+	drifts := []api.ClusterDrift{}
+	for _, cluster := range clusters {
+		if drift, ok := clusterDrift(cluster, tenants[cluster.Spec.TenantRef.Name], staleBefore); ok {
+			drifts = append(drifts, drift)
+		}
+	}
+	slices.SortFunc(drifts, func(a, b api.ClusterDrift) int {
+		return strings.Compare(a.Id, b.Id)
+	})
+	return ctx.JSON(http.StatusOK, drifts)
+}
+
+// clusterDrift compares the last compilation of the cluster with the effective desired revisions.
+// Returns false if the cluster is in sync and was compiled after staleBefore.
+func clusterDrift(cluster synv1alpha1.Cluster, tenant synv1alpha1.Tenant, staleBefore time.Time) (api.ClusterDrift, bool) {
+	compileMeta := cluster.Status.CompileMeta
+	drift := api.ClusterDrift{
+		Id:     cluster.Name,
+		Tenant: cluster.Spec.TenantRef.Name,
+		Global: revisionDrift(
+			effectiveRevision(cluster.Spec.GlobalGitRepoRevision, tenant.Spec.GlobalGitRepoRevision),
+			compileMeta.Global.Version,
+		),
+		TenantRevision: revisionDrift(
+			effectiveRevision(cluster.Spec.TenantGitRepoRevision, tenant.Spec.GitRepoRevision),
+			compileMeta.Tenant.Version,
+		),
+		Stale: compileMeta.LastCompile.Time.Before(staleBefore),
+	}
+	if !compileMeta.LastCompile.IsZero() {
+		drift.LastCompile = pointer.ToTime(compileMeta.LastCompile.Time)
+	}
+	return drift, drift.Stale || drift.Global != nil || drift.TenantRevision != nil
+}
+
+// effectiveRevision returns the revision of the cluster, or the one inherited from the tenant if the cluster has none
+func effectiveRevision(cluster, tenant string) string {
+	if cluster != "" {
+		return cluster
+	}
+	return tenant
+}
+
+// revisionDrift returns nil if there's no desired revision or it matches the compiled version
+func revisionDrift(desired, compiled string) *api.RevisionDrift {
+	if desired == "" || desired == compiled {
+		return nil
+	}
+	return &api.RevisionDrift{Desired: desired, Compiled: compiled}
+}
+
+func compileStaleAfter() time.Duration {
+	d, err := time.ParseDuration(os.Getenv(CompileStaleAfterEnvVar))
+	if err != nil || d <= 0 {
+		return defaultCompileStaleAfter
+	}
+	return d
+}
+
+// reportClusters lists the clusters visible to the caller, filtered by tenant and static facts
+func (s *APIImpl) reportClusters(ctx *APIContext, tenant string, facts map[string]string) ([]synv1alpha1.Cluster, error) {
+	filterOptions := []client.ListOption{client.InNamespace(s.namespace)}
+	if tenant != "" {
+		filterOptions = append(filterOptions, client.MatchingLabels{synv1alpha1.LabelNameTenant: tenant})
+	}
+	clusterList := &synv1alpha1.ClusterList{}
+	if err := s.listClusters(ctx, clusterList, filterOptions...); err != nil {
+		return nil, err
+	}
+	clusters := make([]synv1alpha1.Cluster, 0, len(clusterList.Items))
+	for _, cluster := range clusterList.Items {
+		if matchesFacts(cluster.Spec.Facts, facts) {
+			clusters = append(clusters, cluster)
+		}
+	}
+	return clusters, nil
+}
+
+// parseFactFilter parses the `key=value` fact filters
+func parseFactFilter(filters []string) (map[string]string, error) {
+	facts := make(map[string]string, len(filters))
+	for _, f := range filters {
+		key, value, ok := strings.Cut(f, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid fact filter '%s', expected 'key=value'", f)
+		}
+		facts[key] = value
+	}
+	return facts, nil
+}
+
+func matchesFacts(clusterFacts synv1alpha1.Facts, facts map[string]string) bool {
+	for key, value := range facts {
+		// String facts which look like other values are stored JSON encoded
+		if v, ok := clusterFacts[key]; !ok || (v != value && api.UnmarshalFact(v) != value) {
+			return false
+		}
+	}
+	return true
+}
+
+type versionKey struct {
+	version, gitSHA, url string
+}
+
+// versionReportBuilder collects the clusters per name and version
+type versionReportBuilder map[string]map[versionKey]map[string]*api.VersionUsageCluster
+
+func (b versionReportBuilder) add(name string, info synv1alpha1.CompileMetaVersionInfo, cluster, tenant, instance string) {
+	versions, ok := b[name]
+	if !ok {
+		versions = map[versionKey]map[string]*api.VersionUsageCluster{}
+		b[name] = versions
+	}
+	key := versionKey{version: info.Version, gitSHA: info.GitSHA, url: info.URL}
+	clusters, ok := versions[key]
+	if !ok {
+		clusters = map[string]*api.VersionUsageCluster{}
+		versions[key] = clusters
+	}
+	usage, ok := clusters[cluster]
+	if !ok {
+		usage = &api.VersionUsageCluster{Id: cluster, Tenant: tenant}
+		clusters[cluster] = usage
+	}
+	if instance != "" {
+		usage.Instances = pointer.To(append(pointer.Get(usage.Instances), instance))
+	}
+}
+
+// keep removes all names not in the list
+func (b versionReportBuilder) keep(names []string) {
+	for name := range b {
+		if !slices.Contains(names, name) {
+			delete(b, name)
+		}
+	}
+}
+
+// build returns the report ordered by name, version, git SHA, URL and cluster
+func (b versionReportBuilder) build() []api.VersionReport {
+	reports := make([]api.VersionReport, 0, len(b))
+	for name, versions := range b {
+		report := api.VersionReport{Name: name, Versions: make([]api.VersionUsage, 0, len(versions))}
+		for key, clusters := range versions {
+			usage := api.VersionUsage{
+				Version:  key.version,
+				Clusters: make([]api.VersionUsageCluster, 0, len(clusters)),
+			}
+			if key.gitSHA != "" {
+				usage.GitSha = pointer.ToString(key.gitSHA)
+			}
+			if key.url != "" {
+				usage.Url = pointer.ToString(key.url)
+			}
+			for _, cluster := range clusters {
+				if cluster.Instances != nil {
+					slices.Sort(*cluster.Instances)
+				}
+				usage.Clusters = append(usage.Clusters, *cluster)
+			}
+			slices.SortFunc(usage.Clusters, func(a, b api.VersionUsageCluster) int {
+				return strings.Compare(a.Id, b.Id)
+			})
+			report.Versions = append(report.Versions, usage)
+		}
+		slices.SortFunc(report.Versions, func(a, b api.VersionUsage) int {
+			if c := strings.Compare(a.Version, b.Version); c != 0 {
+				return c
+			}
+			if c := strings.Compare(pointer.GetString(a.GitSha), pointer.GetString(b.GitSha)); c != 0 {
+				return c
+			}
+			return strings.Compare(pointer.GetString(a.Url), pointer.GetString(b.Url))
+		})
+		reports = append(reports, report)
+	}
+	slices.SortFunc(reports, func(a, b api.VersionReport) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return reports
+}
@@ -0,0 +1,269 @@
+package service
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/AlekSi/pointer"
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/testutil"
+	synv1alpha1 "github.com/projectsyn/lieutenant-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/projectsyn/lieutenant-api/pkg/api"
+)
+
+func reportCluster(name, tenant, distribution string, compileMeta synv1alpha1.CompileMeta) *synv1alpha1.Cluster {
+	return &synv1alpha1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    map[string]string{synv1alpha1.LabelNameTenant: tenant},
+		},
+		Spec: synv1alpha1.ClusterSpec{
+			TenantRef: corev1.LocalObjectReference{Name: tenant},
+			Facts:     synv1alpha1.Facts{"distribution": distribution},
+		},
+		Status: synv1alpha1.ClusterStatus{CompileMeta: compileMeta},
+	}
+}
+
+func instance(component, version string) synv1alpha1.CompileMetaInstanceVersionInfo {
+	return synv1alpha1.CompileMetaInstanceVersionInfo{
+		Component: component,
+		CompileMetaVersionInfo: synv1alpha1.CompileMetaVersionInfo{
+			Version: version,
+			URL:     "https://github.com/projectsyn/component-" + component + ".git",
+		},
+	}
+}
+
+var reportClusters = []*synv1alpha1.Cluster{
+	reportCluster("c-report-1", tenantA.Name, "openshift4", synv1alpha1.CompileMeta{
+		Instances: map[string]synv1alpha1.CompileMetaInstanceVersionInfo{
+			"argocd":            instance("argocd", "v1.0.0"),
+			"backup-k8up":       instance("backup-k8up", "v2.0.0"),
+			"backup-k8up-extra": instance("backup-k8up", "v2.0.0"),
+		},
+		Packages: map[string]synv1alpha1.CompileMetaVersionInfo{
+			"monitoring": {Version: "v1.0.0", GitSHA: "aaa"},
+		},
+	}),
+	reportCluster("c-report-2", tenantB.Name, "k3s", synv1alpha1.CompileMeta{
+		Instances: map[string]synv1alpha1.CompileMetaInstanceVersionInfo{
+			"argocd": instance("argocd", "v1.1.0"),
+		},
+		Packages: map[string]synv1alpha1.CompileMetaVersionInfo{
+			"monitoring": {Version: "v1.0.0", GitSHA: "bbb"},
+		},
+	}),
+	reportCluster("c-report-3", tenantA.Name, "openshift4", synv1alpha1.CompileMeta{
+		Instances: map[string]synv1alpha1.CompileMetaInstanceVersionInfo{
+			"argocd": instance("argocd", "v1.0.0"),
+		},
+	}),
+}
+
+func getComponentReport(t *testing.T, e *echo.Echo, query string) api.ComponentReport {
+	result := testutil.NewRequest().
+		Get("/reports/components?"+query).
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+	report := api.ComponentReport{}
+	require.NoError(t, result.UnmarshalJsonToObject(&report))
+	return report
+}
+
+func TestGetComponentReport(t *testing.T) {
+	e, _ := rawSetupTest(t, reportClusters[0], reportClusters[1], reportClusters[2])
+
+	report := getComponentReport(t, e, "")
+	require.Len(t, report.Components, 2)
+	assert.Equal(t, api.VersionReport{
+		Name: "argocd",
+		Versions: []api.VersionUsage{{
+			Version: "v1.0.0",
+			Url:     pointer.ToString("https://github.com/projectsyn/component-argocd.git"),
+			Clusters: []api.VersionUsageCluster{
+				{Id: "c-report-1", Tenant: tenantA.Name, Instances: &[]string{"argocd"}},
+				{Id: "c-report-3", Tenant: tenantA.Name, Instances: &[]string{"argocd"}},
+			},
+		}, {
+			Version: "v1.1.0",
+			Url:     pointer.ToString("https://github.com/projectsyn/component-argocd.git"),
+			Clusters: []api.VersionUsageCluster{
+				{Id: "c-report-2", Tenant: tenantB.Name, Instances: &[]string{"argocd"}},
+			},
+		}},
+	}, report.Components[0])
+	assert.Equal(t, "backup-k8up", report.Components[1].Name)
+	assert.Equal(t, []api.VersionUsageCluster{
+		{Id: "c-report-1", Tenant: tenantA.Name, Instances: &[]string{"backup-k8up", "backup-k8up-extra"}},
+	}, report.Components[1].Versions[0].Clusters)
+
+	require.Len(t, report.Packages, 1)
+	assert.Equal(t, api.VersionReport{
+		Name: "monitoring",
+		Versions: []api.VersionUsage{{
+			Version:  "v1.0.0",
+			GitSha:   pointer.ToString("aaa"),
+			Clusters: []api.VersionUsageCluster{{Id: "c-report-1", Tenant: tenantA.Name}},
+		}, {
+			Version:  "v1.0.0",
+			GitSha:   pointer.ToString("bbb"),
+			Clusters: []api.VersionUsageCluster{{Id: "c-report-2", Tenant: tenantB.Name}},
+		}},
+	}, report.Packages[0])
+}
+
+func TestGetComponentReportFilter(t *testing.T) {
+	e, _ := rawSetupTest(t, reportClusters[0], reportClusters[1], reportClusters[2])
+
+	report := getComponentReport(t, e, "component=argocd")
+	require.Len(t, report.Components, 1)
+	assert.Equal(t, "argocd", report.Components[0].Name)
+	assert.Empty(t, report.Packages)
+
+	report = getComponentReport(t, e, "package=monitoring&component=backup-k8up")
+	require.Len(t, report.Components, 1)
+	assert.Equal(t, "backup-k8up", report.Components[0].Name)
+	require.Len(t, report.Packages, 1)
+
+	report = getComponentReport(t, e, "tenant="+tenantB.Name)
+	require.Len(t, report.Components, 1)
+	assert.Equal(t, "v1.1.0", report.Components[0].Versions[0].Version)
+
+	report = getComponentReport(t, e, "fact=distribution%3Dopenshift4&component=argocd")
+	require.Len(t, report.Components, 1)
+	require.Len(t, report.Components[0].Versions, 1)
+	assert.Len(t, report.Components[0].Versions[0].Clusters, 2)
+
+	report = getComponentReport(t, e, "fact=distribution%3Dopenshift4&fact=cloud%3Dcloudscale")
+	assert.Empty(t, report.Components)
+}
+
+func TestGetComponentReportInvalidFact(t *testing.T) {
+	e, _ := setupTest(t)
+
+	result := testutil.NewRequest().
+		Get("/reports/components?fact=distribution").
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusBadRequest, result)
+}
+
+func getDriftReport(t *testing.T, e *echo.Echo, query string) []api.ClusterDrift {
+	result := testutil.NewRequest().
+		Get("/reports/drift?"+query).
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+	report := []api.ClusterDrift{}
+	require.NoError(t, result.UnmarshalJsonToObject(&report))
+	return report
+}
+
+func driftCluster(name, globalRevision, globalVersion, tenantVersion string, lastCompile time.Time) *synv1alpha1.Cluster {
+	cluster := reportCluster(name, tenantA.Name, "openshift4", synv1alpha1.CompileMeta{
+		LastCompile: metav1.NewTime(lastCompile),
+		Global:      synv1alpha1.CompileMetaVersionInfo{Version: globalVersion},
+		Tenant:      synv1alpha1.CompileMetaVersionInfo{Version: tenantVersion},
+	})
+	cluster.Spec.GlobalGitRepoRevision = globalRevision
+	return cluster
+}
+
+func TestGetDriftReport(t *testing.T) {
+	tenant := tenantA.DeepCopy()
+	tenant.Spec.GlobalGitRepoRevision = "v2"
+	tenant.Spec.GitRepoRevision = "main"
+	recent := time.Now().UTC().Add(-time.Hour).Truncate(time.Second)
+	old := time.Now().UTC().Add(-30 * 24 * time.Hour).Truncate(time.Second)
+
+	e, _ := rawSetupTest(t,
+		tenant,
+		driftCluster("c-drift-in-sync", "", "v2", "main", recent),
+		driftCluster("c-drift-cluster-revision", "v3", "v2", "main", recent),
+		driftCluster("c-drift-tenant-revision", "", "v1", "develop", recent),
+		driftCluster("c-drift-stale", "", "v2", "main", old),
+		reportCluster("c-drift-never-compiled", tenantA.Name, "openshift4", synv1alpha1.CompileMeta{}),
+	)
+
+	report := getDriftReport(t, e, "")
+	assert.Equal(t, []api.ClusterDrift{{
+		Id:          "c-drift-cluster-revision",
+		Tenant:      tenantA.Name,
+		Global:      &api.RevisionDrift{Desired: "v3", Compiled: "v2"},
+		LastCompile: &recent,
+	}, {
+		Id:             "c-drift-never-compiled",
+		Tenant:         tenantA.Name,
+		Global:         &api.RevisionDrift{Desired: "v2"},
+		TenantRevision: &api.RevisionDrift{Desired: "main"},
+		Stale:          true,
+	}, {
+		Id:          "c-drift-stale",
+		Tenant:      tenantA.Name,
+		LastCompile: &old,
+		Stale:       true,
+	}, {
+		Id:             "c-drift-tenant-revision",
+		Tenant:         tenantA.Name,
+		Global:         &api.RevisionDrift{Desired: "v2", Compiled: "v1"},
+		TenantRevision: &api.RevisionDrift{Desired: "main", Compiled: "develop"},
+		LastCompile:    &recent,
+	}}, report)
+
+	report = getDriftReport(t, e, "staleAfter=1000h")
+	assert.Len(t, report, 3)
+
+	t.Setenv(CompileStaleAfterEnvVar, "30m")
+	report = getDriftReport(t, e, "")
+	assert.Len(t, report, 5)
+}
+
+func TestGetDriftReportInvalidAge(t *testing.T) {
+	e, _ := setupTest(t)
+
+	result := testutil.NewRequest().
+		Get("/reports/drift?staleAfter=week").
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusBadRequest, result)
+}
+
+func TestGetDriftReportTenantNotReadable(t *testing.T) {
+	tenant := tenantB.DeepCopy()
+	tenant.Spec.GlobalGitRepoRevision = "v2"
+	recent := time.Now().UTC().Add(-time.Hour).Truncate(time.Second)
+	cluster := clusterB.DeepCopy()
+	cluster.Status.CompileMeta = synv1alpha1.CompileMeta{
+		LastCompile: metav1.NewTime(recent),
+		Global:      synv1alpha1.CompileMetaVersionInfo{Version: "v1"},
+	}
+	objs := []client.Object{tenant, cluster}
+	for _, obj := range testObjects {
+		if obj.GetName() != tenantB.Name && obj.GetName() != clusterB.Name {
+			objs = append(objs, obj)
+		}
+	}
+	e, _ := rawSetupTest(t, objs...)
+
+	// The customer can read cluster B but not its tenant, the inherited revision must still be compared
+	result := testutil.NewRequest().
+		Get("/reports/drift").
+		WithHeader(echo.HeaderAuthorization, customerBearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+	report := []api.ClusterDrift{}
+	require.NoError(t, result.UnmarshalJsonToObject(&report))
+	require.Len(t, report, 2)
+	assert.Equal(t, clusterB.Name, report[1].Id)
+	assert.Equal(t, &api.RevisionDrift{Desired: "v2", Compiled: "v1"}, report[1].Global)
+}
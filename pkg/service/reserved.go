@@ -0,0 +1,135 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	synv1alpha1 "github.com/projectsyn/lieutenant-operator/api/v1alpha1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+)
+
+const (
+	// ReservedFactsEnvVar is the env var name that's used to get the comma separated list of reserved fact keys
+	ReservedFactsEnvVar = "RESERVED_FACTS"
+	// ReservedAnnotationPrefixesEnvVar is the env var name that's used to get the comma separated list of reserved annotation prefixes
+	ReservedAnnotationPrefixesEnvVar = "RESERVED_ANNOTATION_PREFIXES"
+
+	// ManageReservedVerb is the RBAC verb on clusters and tenants which allows changing reserved facts and annotations
+	ManageReservedVerb = "manage-reserved"
+
+	defaultReservedAnnotationPrefixes = "syn.tools/,steward.syn.tools/,lieutenant.syn.tools/"
+)
+
+// reservedKeys decides which facts and annotations are reserved
+type reservedKeys struct {
+	facts              []string
+	annotationPrefixes []string
+}
+
+// configuredReservedKeys returns the reserved facts and annotation prefixes configured by the env vars
+func configuredReservedKeys() reservedKeys {
+	facts, ok := os.LookupEnv(ReservedFactsEnvVar)
+	if !ok {
+		facts = LieutenantInstanceFact
+	}
+	prefixes, ok := os.LookupEnv(ReservedAnnotationPrefixesEnvVar)
+	if !ok {
+		prefixes = defaultReservedAnnotationPrefixes
+	}
+	return reservedKeys{
+		facts:              splitList(facts),
+		annotationPrefixes: splitList(prefixes),
+	}
+}
+
+func (r reservedKeys) isFact(key string) bool {
+	return slices.Contains(r.facts, key)
+}
+
+func (r reservedKeys) isAnnotation(key string) bool {
+	return slices.ContainsFunc(r.annotationPrefixes, func(prefix string) bool {
+		return strings.HasPrefix(key, prefix)
+	})
+}
+
+// keepReserved copies the reserved entries missing in the replacement from the existing map.
+// A replaced object therefore doesn't drop reserved entries the client didn't know about.
+func keepReserved(existing, replacement map[string]string, reserved func(string) bool) map[string]string {
+	for key, value := range existing {
+		if _, ok := replacement[key]; ok || !reserved(key) {
+			continue
+		}
+		if replacement == nil {
+			replacement = map[string]string{}
+		}
+		replacement[key] = value
+	}
+	return replacement
+}
+
+// changedReserved returns the reserved keys which were added, changed or removed, prefixed with the field name
+func changedReserved(field string, old, updated map[string]string, reserved func(string) bool) []string {
+	changed := []string{}
+	for key, value := range updated {
+		if oldValue, ok := old[key]; reserved(key) && (!ok || oldValue != value) {
+			changed = append(changed, fmt.Sprintf("%s '%s'", field, key))
+		}
+	}
+	for key := range old {
+		if _, ok := updated[key]; !ok && reserved(key) {
+			changed = append(changed, fmt.Sprintf("%s '%s'", field, key))
+		}
+	}
+	slices.Sort(changed)
+	return changed
+}
+
+// changedReservedOfCluster returns the reserved facts and annotations which differ between the clusters
+func changedReservedOfCluster(old, updated *synv1alpha1.Cluster) []string {
+	r := configuredReservedKeys()
+	return append(
+		changedReserved("fact", old.Spec.Facts, updated.Spec.Facts, r.isFact),
+		changedReserved("annotation", old.Annotations, updated.Annotations, r.isAnnotation)...,
+	)
+}
+
+// changedReservedOfTenant returns the reserved annotations which differ between the tenants
+func changedReservedOfTenant(old, updated *synv1alpha1.Tenant) []string {
+	return changedReserved("annotation", old.Annotations, updated.Annotations, configuredReservedKeys().isAnnotation)
+}
+
+// authorizeReserved checks that the caller may change the reserved facts and annotations of the object
+func (s *APIImpl) authorizeReserved(ctx *APIContext, resource, name string, changed []string) error {
+	if len(changed) == 0 {
+		return nil
+	}
+	allowed, err := ctx.accessAllowed(authorizationv1.ResourceAttributes{
+		Namespace: s.namespace,
+		Verb:      ManageReservedVerb,
+		Group:     synv1alpha1.GroupVersion.Group,
+		Resource:  resource,
+		Name:      name,
+	})
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return echo.NewHTTPError(http.StatusForbidden,
+			fmt.Sprintf("Changing reserved %s requires the permission to %s %s", strings.Join(changed, ", "), ManageReservedVerb, resource))
+	}
+	return nil
+}
+
+func splitList(list string) []string {
+	items := []string{}
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/AlekSi/pointer"
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/testutil"
+	synv1alpha1 "github.com/projectsyn/lieutenant-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/projectsyn/lieutenant-api/pkg/api"
+)
+
+var reservedCluster = &synv1alpha1.Cluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "c-reserved",
+		Namespace: "default",
+		Labels:    map[string]string{synv1alpha1.LabelNameTenant: tenantA.Name},
+		Annotations: map[string]string{
+			"syn.tools/owner":                "team-a",
+			api.StewardInstalledAtAnnotation: "2024-04-14T21:05:56Z",
+			"monitoring.syn.tools/sla":       "247",
+		},
+	},
+	Spec: synv1alpha1.ClusterSpec{
+		DisplayName: "Reserved",
+		TenantRef:   corev1.LocalObjectReference{Name: tenantA.Name},
+		Facts: synv1alpha1.Facts{
+			LieutenantInstanceFact: "lieutenant-prod",
+			"cloud":                "cloudscale",
+		},
+	},
+}
+
+func patchCluster(t *testing.T, e *echo.Echo, token string, body any) *testutil.CompletedRequest {
+	return testutil.NewRequest().
+		Patch("/clusters/"+reservedCluster.Name).
+		WithJsonBody(body).
+		WithContentType(api.ContentJSONPatch).
+		WithHeader(echo.HeaderAuthorization, token).
+		GoWithHTTPHandler(t, e)
+}
+
+func requireReservedForbidden(t *testing.T, result *testutil.CompletedRequest, keys ...string) {
+	t.Helper()
+	requireHTTPCode(t, http.StatusForbidden, result)
+	reason := &api.Reason{}
+	require.NoError(t, result.UnmarshalJsonToObject(reason))
+	assert.Contains(t, reason.Reason, ManageReservedVerb)
+	for _, key := range keys {
+		assert.Contains(t, reason.Reason, key)
+	}
+}
+
+func TestUpdateClusterReservedFacts(t *testing.T) {
+	e, c := setupTest(t, reservedCluster)
+
+	result := patchCluster(t, e, unprivilegedBearerToken, map[string]any{"facts": map[string]any{LieutenantInstanceFact: "lieutenant-dev"}})
+	requireReservedForbidden(t, result, "fact 'lieutenant-instance'")
+
+	result = patchCluster(t, e, unprivilegedBearerToken, map[string]any{"facts": map[string]any{LieutenantInstanceFact: ""}})
+	requireReservedForbidden(t, result, "fact 'lieutenant-instance'")
+
+	result = patchCluster(t, e, unprivilegedBearerToken, map[string]any{"annotations": map[string]any{"syn.tools/owner": "team-b", "steward.syn.tools/installed-at": "2026-01-01T00:00:00Z"}})
+	requireReservedForbidden(t, result, "annotation 'syn.tools/owner'", "annotation 'steward.syn.tools/installed-at'")
+
+	cluster := &synv1alpha1.Cluster{}
+	require.NoError(t, c.Get(t.Context(), client.ObjectKeyFromObject(reservedCluster), cluster))
+	assert.Equal(t, reservedCluster.Spec.Facts, cluster.Spec.Facts)
+	assert.Equal(t, reservedCluster.Annotations, cluster.Annotations)
+
+	// Unreserved facts and annotations and unchanged reserved ones can be set by anyone
+	result = patchCluster(t, e, unprivilegedBearerToken, map[string]any{
+		"facts":       map[string]any{"cloud": "exoscale", LieutenantInstanceFact: "lieutenant-prod"},
+		"annotations": map[string]any{"monitoring.syn.tools/sla": "best-effort"},
+	})
+	requireHTTPCode(t, http.StatusOK, result)
+
+	result = patchCluster(t, e, bearerToken, map[string]any{"facts": map[string]any{LieutenantInstanceFact: "lieutenant-dev"}})
+	requireHTTPCode(t, http.StatusOK, result)
+	require.NoError(t, c.Get(t.Context(), client.ObjectKeyFromObject(reservedCluster), cluster))
+	assert.Equal(t, "lieutenant-dev", cluster.Spec.Facts[LieutenantInstanceFact])
+}
+
+func TestUpdateClusterReservedFactsConfigured(t *testing.T) {
+	t.Setenv(ReservedFactsEnvVar, "cloud, region")
+	t.Setenv(ReservedAnnotationPrefixesEnvVar, "monitoring.syn.tools/")
+	e, _ := setupTest(t, reservedCluster)
+
+	result := patchCluster(t, e, unprivilegedBearerToken, map[string]any{"facts": map[string]any{"cloud": "exoscale"}})
+	requireReservedForbidden(t, result, "fact 'cloud'")
+
+	result = patchCluster(t, e, unprivilegedBearerToken, map[string]any{"annotations": map[string]any{"monitoring.syn.tools/sla": "none"}})
+	requireReservedForbidden(t, result, "annotation 'monitoring.syn.tools/sla'")
+
+	result = patchCluster(t, e, unprivilegedBearerToken, map[string]any{
+		"facts":       map[string]any{LieutenantInstanceFact: "lieutenant-dev"},
+		"annotations": map[string]any{"syn.tools/owner": "team-b"},
+	})
+	requireHTTPCode(t, http.StatusOK, result)
+}
+
+func TestPutClusterKeepsReserved(t *testing.T) {
+	e, c := setupTest(t, reservedCluster)
+
+	result := testutil.NewRequest().
+		Put("/clusters/"+reservedCluster.Name).
+		WithJsonBody(api.Cluster{
+			ClusterProperties: api.ClusterProperties{
+				DisplayName: pointer.ToString("Replaced"),
+				Facts:       &api.ClusterFacts{"cloud": "exoscale"},
+			},
+			ClusterTenant: api.ClusterTenant{Tenant: tenantA.Name},
+		}).
+		WithHeader(echo.HeaderAuthorization, unprivilegedBearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+
+	cluster := &synv1alpha1.Cluster{}
+	require.NoError(t, c.Get(t.Context(), client.ObjectKeyFromObject(reservedCluster), cluster))
+	assert.Equal(t, synv1alpha1.Facts{LieutenantInstanceFact: "lieutenant-prod", "cloud": "exoscale"}, cluster.Spec.Facts)
+	assert.Equal(t, map[string]string{
+		"syn.tools/owner":                "team-a",
+		api.StewardInstalledAtAnnotation: "2024-04-14T21:05:56Z",
+	}, cluster.Annotations)
+
+	result = testutil.NewRequest().
+		Put("/clusters/"+reservedCluster.Name).
+		WithJsonBody(api.Cluster{
+			ClusterProperties: api.ClusterProperties{
+				Annotations: &api.Annotations{"syn.tools/owner": "team-b"},
+			},
+			ClusterTenant: api.ClusterTenant{Tenant: tenantA.Name},
+		}).
+		WithHeader(echo.HeaderAuthorization, unprivilegedBearerToken).
+		GoWithHTTPHandler(t, e)
+	requireReservedForbidden(t, result, "annotation 'syn.tools/owner'")
+}
+
+func TestCreateClusterReserved(t *testing.T) {
+	e, _ := setupTest(t)
+
+	newCluster := api.Cluster{
+		ClusterProperties: api.ClusterProperties{
+			// The instance fact is always overwritten by the API
+			Facts: &api.ClusterFacts{LieutenantInstanceFact: "forged"},
+		},
+		ClusterTenant: api.ClusterTenant{Tenant: tenantA.Name},
+	}
+	result := testutil.NewRequest().
+		Post("/clusters").
+		WithJsonBody(newCluster).
+		WithHeader(echo.HeaderAuthorization, unprivilegedBearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusCreated, result)
+
+	newCluster.Annotations = &api.Annotations{"lieutenant.syn.tools/facts-schema-configmap": "other"}
+	result = testutil.NewRequest().
+		Post("/clusters").
+		WithJsonBody(newCluster).
+		WithHeader(echo.HeaderAuthorization, unprivilegedBearerToken).
+		GoWithHTTPHandler(t, e)
+	requireReservedForbidden(t, result, "annotation 'lieutenant.syn.tools/facts-schema-configmap'")
+}
+
+func TestTenantReservedAnnotations(t *testing.T) {
+	e, _ := setupTest(t)
+
+	result := testutil.NewRequest().
+		Patch("/tenants/"+tenantA.Name).
+		WithJsonBody(api.TenantProperties{Annotations: &api.Annotations{FactsSchemaConfigMapAnnotation: "facts-schema"}}).
+		WithContentType(api.ContentJSONPatch).
+		WithHeader(echo.HeaderAuthorization, unprivilegedBearerToken).
+		GoWithHTTPHandler(t, e)
+	requireReservedForbidden(t, result, "annotation '"+FactsSchemaConfigMapAnnotation+"'", "tenants")
+
+	result = testutil.NewRequest().
+		Post("/tenants").
+		WithJsonBody(api.Tenant{
+			TenantProperties: api.TenantProperties{
+				GitRepo:     &api.RevisionedGitRepo{GitRepo: api.GitRepo{Url: pointer.ToString("ssh://git@example.com/org/tenant.git")}},
+				Annotations: &api.Annotations{StewardRBACProfileAnnotation: RBACProfileClusterAdmin},
+			},
+		}).
+		WithHeader(echo.HeaderAuthorization, unprivilegedBearerToken).
+		GoWithHTTPHandler(t, e)
+	requireReservedForbidden(t, result, "annotation '"+StewardRBACProfileAnnotation+"'")
+
+	result = testutil.NewRequest().
+		Patch("/tenants/"+tenantA.Name).
+		WithJsonBody(api.TenantProperties{Annotations: &api.Annotations{FactsSchemaConfigMapAnnotation: "facts-schema"}}).
+		WithContentType(api.ContentJSONPatch).
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+}
@@ -1,8 +1,12 @@
 package service
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/AlekSi/pointer"
@@ -10,22 +14,42 @@ import (
 	synv1alpha1 "github.com/projectsyn/lieutenant-operator/api/v1alpha1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
-	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/projectsyn/lieutenant-api/pkg/api"
 )
 
 const (
-	namespace           = "syn"
-	appName             = "steward"
-	stewardImageDefault = "docker.io/projectsyn/steward:v0.2.2"
+	// DistributionFact defines the name of the fact which specifies the Kubernetes distribution of a cluster
+	DistributionFact = "distribution"
+
+	// StewardRestrictedDistributionsEnvVar is the env var name that's used to get the comma separated list of
+	// distributions which enforce the restricted Pod Security Standard
+	StewardRestrictedDistributionsEnvVar = "STEWARD_RESTRICTED_DISTRIBUTIONS"
+
+	namespace                      = "syn"
+	appName                        = "steward"
+	stewardImageDefault            = "docker.io/projectsyn/steward:v0.2.2"
+	restrictedDistributionsDefault = "rke2,talos"
+)
+
+// manifestVariant selects the flavor of the Steward install manifests
+type manifestVariant string
+
+const (
+	variantDefault    manifestVariant = "default"
+	variantOpenShift  manifestVariant = "openshift"
+	variantRestricted manifestVariant = "restricted"
 )
 
 var (
+	errBootstrapTokenUsed = echo.NewHTTPError(http.StatusUnauthorized, "Token already used or expired")
+
 	appLabels = map[string]string{
 		"app.kubernetes.io/name":       appName,
 		"app.kubernetes.io/managed-by": "syn",
@@ -40,77 +64,147 @@ func (s *APIImpl) InstallSteward(c echo.Context, params api.InstallStewardParams
 		return echo.NewHTTPError(http.StatusBadRequest, "Missing or malformed token")
 	}
 
-	clusterList := &synv1alpha1.ClusterList{}
-	if err := ctx.client.List(ctx.Request().Context(), clusterList, client.InNamespace(s.namespace)); err != nil {
+	cluster, err := s.clusterByBootstrapToken(ctx, *params.Token)
+	if err != nil {
 		return err
 	}
-	var token string
-	cluster := synv1alpha1.Cluster{}
-	for _, c := range clusterList.Items {
-		if bToken := c.Status.BootstrapToken; bToken != nil {
-			if len(bToken.Token) > 0 && bToken.Token == *params.Token {
-				if bToken.TokenValid && time.Now().Before(bToken.ValidUntil.Time) {
-					t, err := s.getServiceAccountToken(ctx, c.Name)
-					if err != nil {
-						return err
-					}
-					token = t
-					cluster = c
-				} else {
-					return echo.NewHTTPError(http.StatusUnauthorized, "Token already used or expired")
-				}
-			}
-		}
-	}
-	if len(token) == 0 {
+	if cluster == nil {
 		return echo.NewHTTPError(http.StatusUnauthorized, "Invalid token")
 	}
+	if !bootstrapTokenUsable(cluster, *params.Token) {
+		return errBootstrapTokenUsed
+	}
+
+	token, err := s.getServiceAccountToken(ctx, cluster.Name)
+	if err != nil {
+		return err
+	}
+	rbac, err := s.stewardRBACProfile(ctx, cluster)
+	if err != nil {
+		return err
+	}
 
+	// The token must be invalidated before any credentials are sent out.
+	// Only the request that wins the update gets to see the manifests.
+	if err := consumeBootstrapToken(ctx.Request().Context(), ctx.client, cluster, *params.Token); err != nil {
+		return err
+	}
+	if err := recordStewardInstallation(ctx, cluster); err != nil {
+		// The token is used up at this point, failing here would leave the cluster without any way to install Steward
+		ctx.Logger().Errorf("failed to record Steward installation of cluster %s: %v", cluster.Name, err)
+	}
+
+	apiHost := ctx.Scheme() + "://" + ctx.Request().Host
+	installList := createInstallList(apiHost, cluster, token, rbac)
+	return ctx.JSON(http.StatusOK, installList)
+}
+
+// recordStewardInstallation stores when, from where and with which image the install manifests were fetched on the cluster
+func recordStewardInstallation(ctx *APIContext, cluster *synv1alpha1.Cluster) error {
+	patch := map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]string{
+				api.StewardInstalledAtAnnotation:        time.Now().UTC().Format(time.RFC3339),
+				api.StewardInstalledFromAnnotation:      ctx.RealIP(),
+				api.StewardInstalledUserAgentAnnotation: ctx.Request().UserAgent(),
+				api.StewardInstalledImageAnnotation:     stewardImage(),
+			},
+		},
+	}
+	rawPatch, err := json.Marshal(patch)
+	if err != nil {
+		return fmt.Errorf("failed to marshal patch: %w", err)
+	}
+	return ctx.client.Patch(ctx.Request().Context(), cluster, client.RawPatch(types.MergePatchType, rawPatch))
+}
+
+// createInstallList returns the manifests to install Steward on the given cluster
+func createInstallList(apiHost string, cluster *synv1alpha1.Cluster, token string, rbac rbacProfile) *corev1.List {
+	variant := manifestVariantFor(cluster)
 	installList := &corev1.List{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "v1",
 			Kind:       "List",
 		},
 	}
-	apiHost := ctx.Scheme() + "://" + ctx.Request().Host
-	stewardDeployment := createStewardDeployment(apiHost, cluster.Name)
-	installList.Items = append(installList.Items, runtime.RawExtension{Object: &corev1.Namespace{
-		TypeMeta: metav1.TypeMeta{
-			APIVersion: corev1.SchemeGroupVersion.String(),
-			Kind:       "Namespace",
-		},
-		ObjectMeta: metav1.ObjectMeta{
-			Name:   namespace,
-			Labels: appLabels,
-		},
-	}})
-	installList.Items = append(installList.Items, createRBAC()...)
-	installList.Items = append(installList.Items, runtime.RawExtension{Object: stewardDeployment})
+	installList.Items = append(installList.Items, runtime.RawExtension{Object: createNamespace(variant)})
+	installList.Items = append(installList.Items, createRBAC(rbac)...)
+	installList.Items = append(installList.Items, runtime.RawExtension{Object: createStewardDeployment(apiHost, cluster.Name, variant)})
 	installList.Items = append(installList.Items, runtime.RawExtension{Object: createSecret(token)})
-	if err := ctx.JSON(http.StatusOK, installList); err != nil {
-		return err
+	return installList
+}
+
+// clusterByBootstrapToken returns the cluster with the bootstrap token or nil if there is none.
+// The read cache is searched first, a new bootstrap token might not be cached yet.
+func (s *APIImpl) clusterByBootstrapToken(ctx *APIContext, token string) (*synv1alpha1.Cluster, error) {
+	clusterList := &synv1alpha1.ClusterList{}
+	if s.readCache != nil {
+		if err := s.readCache.List(ctx.Request().Context(), clusterList, client.InNamespace(s.namespace)); err != nil {
+			return nil, err
+		}
+		if cluster := findClusterByBootstrapToken(clusterList.Items, token); cluster != nil {
+			return cluster, nil
+		}
+	}
+	if err := ctx.client.List(ctx.Request().Context(), clusterList, client.InNamespace(s.namespace)); err != nil {
+		return nil, err
 	}
-	cluster.Status.BootstrapToken.TokenValid = false
-	return ctx.client.Status().Update(ctx.Request().Context(), &cluster)
+	return findClusterByBootstrapToken(clusterList.Items, token), nil
 }
 
-func (s *APIImpl) getServiceAccountToken(ctx *APIContext, saName string) (string, error) {
+// findClusterByBootstrapToken returns the cluster with the given bootstrap token or nil if there is none
+func findClusterByBootstrapToken(clusters []synv1alpha1.Cluster, token string) *synv1alpha1.Cluster {
+	for i, c := range clusters {
+		if bToken := c.Status.BootstrapToken; bToken != nil && len(bToken.Token) > 0 && bToken.Token == token {
+			return &clusters[i]
+		}
+	}
+	return nil
+}
 
-	secrets := &corev1.SecretList{}
-	if err := ctx.client.List(
-		ctx.Request().Context(),
-		secrets,
-		client.InNamespace(s.namespace),
-		client.MatchingFields{"type": string(corev1.SecretTypeServiceAccountToken)},
-	); err != nil {
-		return "", err
+// bootstrapTokenUsable checks if the cluster's bootstrap token matches and wasn't used yet and didn't expire
+func bootstrapTokenUsable(cluster *synv1alpha1.Cluster, token string) bool {
+	bToken := cluster.Status.BootstrapToken
+	return bToken != nil &&
+		bToken.Token == token &&
+		bToken.TokenValid &&
+		time.Now().Before(bToken.ValidUntil.Time)
+}
+
+// consumeBootstrapToken marks the bootstrap token of the cluster as used.
+// The patch is guarded by the resource version of the cluster, so concurrent requests can't consume the same token twice.
+// On a conflict the cluster is fetched again and the token rechecked once.
+func consumeBootstrapToken(ctx context.Context, c client.Client, cluster *synv1alpha1.Cluster, token string) error {
+	invalidate := func() error {
+		if !bootstrapTokenUsable(cluster, token) {
+			return errBootstrapTokenUsed
+		}
+		orig := cluster.DeepCopy()
+		cluster.Status.BootstrapToken.TokenValid = false
+		return c.Status().Patch(ctx, cluster, client.MergeFromWithOptions(orig, client.MergeFromWithOptimisticLock{}))
 	}
 
-	token := findOldestSAToken(secrets.Items, saName)
-	if token == "" {
-		return "", echo.NewHTTPError(http.StatusServiceUnavailable, "Unable to find token for Cluster. This error might be transient, please try again.")
+	err := invalidate()
+	if errors.IsConflict(err) {
+		if err := c.Get(ctx, client.ObjectKeyFromObject(cluster), cluster); err != nil {
+			return err
+		}
+		return invalidate()
 	}
-	return token, nil
+	return err
+}
+
+// legacyServiceAccountToken returns the token of the oldest service account token secret.
+// Returns an empty string if there is no such secret.
+func (s *APIImpl) legacyServiceAccountToken(ctx *APIContext, saName string) (string, error) {
+	secrets, err := s.serviceAccountTokenSecrets(ctx, saName)
+	if err != nil {
+		return "", err
+	}
+	if err := revokeExpiredSATokens(ctx.Request().Context(), ctx.client, secrets); err != nil {
+		return "", err
+	}
+	return findOldestSAToken(secrets, saName), nil
 }
 
 func findOldestSAToken(secrets []corev1.Secret, saName string) string {
@@ -121,6 +215,7 @@ func findOldestSAToken(secrets []corev1.Secret, saName string) string {
 		if secret.Type == corev1.SecretTypeServiceAccountToken && // Not strictly necessary but our testing framework can't handle field selectors
 			secret.Annotations[corev1.ServiceAccountNameKey] == saName &&
 			len(secret.Data[corev1.ServiceAccountTokenKey]) > 0 &&
+			secret.Annotations[RevokeAfterAnnotation] == "" && // Rotated tokens must not be handed out again
 			!created.Before(&secret.CreationTimestamp) {
 
 			token = string(secret.Data[corev1.ServiceAccountTokenKey])
@@ -130,62 +225,6 @@ func findOldestSAToken(secrets []corev1.Secret, saName string) string {
 	return token
 }
 
-func createRBAC() []runtime.RawExtension {
-	return []runtime.RawExtension{{
-		Object: &rbacv1.ClusterRole{
-			TypeMeta: metav1.TypeMeta{
-				APIVersion: rbacv1.SchemeGroupVersion.String(),
-				Kind:       "ClusterRole",
-			},
-			ObjectMeta: metav1.ObjectMeta{
-				Name:   "syn-admin",
-				Labels: appLabels,
-			},
-			Rules: []rbacv1.PolicyRule{{
-				APIGroups: []string{"*"},
-				Resources: []string{"*"},
-				Verbs:     []string{"*"},
-			}, {
-				NonResourceURLs: []string{"*"},
-				Verbs:           []string{"*"},
-			}},
-		},
-	}, {
-		Object: &rbacv1.ClusterRoleBinding{
-			TypeMeta: metav1.TypeMeta{
-				APIVersion: rbacv1.SchemeGroupVersion.String(),
-				Kind:       "ClusterRoleBinding",
-			},
-			ObjectMeta: metav1.ObjectMeta{
-				Name:   "syn-steward",
-				Labels: appLabels,
-			},
-			RoleRef: rbacv1.RoleRef{
-				APIGroup: rbacv1.SchemeGroupVersion.Group,
-				Kind:     "ClusterRole",
-				Name:     "syn-admin",
-			},
-			Subjects: []rbacv1.Subject{{
-				Kind:      "ServiceAccount",
-				Name:      appName,
-				Namespace: namespace,
-			}},
-		},
-	}, {
-		Object: &corev1.ServiceAccount{
-			TypeMeta: metav1.TypeMeta{
-				APIVersion: corev1.SchemeGroupVersion.String(),
-				Kind:       "ServiceAccount",
-			},
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      appName,
-				Namespace: namespace,
-				Labels:    appLabels,
-			},
-		},
-	}}
-}
-
 func createSecret(token string) *corev1.Secret {
 	return &corev1.Secret{
 		TypeMeta: metav1.TypeMeta{
@@ -203,15 +242,87 @@ func createSecret(token string) *corev1.Secret {
 	}
 }
 
-func createStewardDeployment(apiHost, clusterID string) *appsv1.Deployment {
+// manifestVariantFor returns the manifest variant suitable for the distribution of the cluster
+func manifestVariantFor(cluster *synv1alpha1.Cluster) manifestVariant {
+	distribution := cluster.Spec.Facts[DistributionFact]
+	if strings.HasPrefix(distribution, "openshift") {
+		return variantOpenShift
+	}
+
+	restricted := os.Getenv(StewardRestrictedDistributionsEnvVar)
+	if restricted == "" {
+		restricted = restrictedDistributionsDefault
+	}
+	for _, d := range strings.Split(restricted, ",") {
+		if d = strings.TrimSpace(d); d != "" && d == distribution {
+			return variantRestricted
+		}
+	}
+	return variantDefault
+}
+
+func createNamespace(variant manifestVariant) *corev1.Namespace {
+	labels := map[string]string{}
+	for k, v := range appLabels {
+		labels[k] = v
+	}
+	switch variant {
+	case variantOpenShift:
+		// Let OpenShift derive the Pod Security Admission labels from the SCCs
+		labels["security.openshift.io/scc.podSecurityLabelSync"] = "true"
+	case variantRestricted:
+		for _, mode := range []string{"enforce", "audit", "warn"} {
+			labels["pod-security.kubernetes.io/"+mode] = "restricted"
+		}
+	}
+	return &corev1.Namespace{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Namespace",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   namespace,
+			Labels: labels,
+		},
+	}
+}
+
+// stewardSecurityContexts returns the pod and container security contexts for the manifest variant.
+// The hardened variants satisfy the restricted Pod Security Standard and the restricted-v2 SCC of OpenShift.
+// Neither sets a fixed user, as OpenShift assigns one from the namespace's range.
+func stewardSecurityContexts(variant manifestVariant) (*corev1.PodSecurityContext, *corev1.SecurityContext) {
+	podSecurityContext := &corev1.PodSecurityContext{
+		RunAsNonRoot: pointer.ToBool(true),
+	}
+	if variant == variantDefault {
+		return podSecurityContext, nil
+	}
+	podSecurityContext.SeccompProfile = &corev1.SeccompProfile{
+		Type: corev1.SeccompProfileTypeRuntimeDefault,
+	}
+	return podSecurityContext, &corev1.SecurityContext{
+		AllowPrivilegeEscalation: pointer.ToBool(false),
+		Capabilities: &corev1.Capabilities{
+			Drop: []corev1.Capability{"ALL"},
+		},
+	}
+}
+
+func stewardImage() string {
 	image := os.Getenv("STEWARD_IMAGE")
 	if len(image) == 0 {
 		image = stewardImageDefault
 	}
+	return image
+}
+
+func createStewardDeployment(apiHost, clusterID string, variant manifestVariant) *appsv1.Deployment {
+	image := stewardImage()
 	apiHostEnv := os.Getenv("API_HOST")
 	if len(apiHostEnv) > 0 {
 		apiHost = apiHostEnv
 	}
+	podSecurityContext, containerSecurityContext := stewardSecurityContexts(variant)
 	stewardDeployment := &appsv1.Deployment{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "apps/v1",
@@ -231,14 +342,13 @@ func createStewardDeployment(apiHost, clusterID string) *appsv1.Deployment {
 					Labels: appLabels,
 				},
 				Spec: corev1.PodSpec{
-					SecurityContext: &corev1.PodSecurityContext{
-						RunAsNonRoot: pointer.ToBool(true),
-					},
+					SecurityContext:    podSecurityContext,
 					ServiceAccountName: appName,
 					Containers: []corev1.Container{{
 						Name:            appName,
 						Image:           image,
 						ImagePullPolicy: corev1.PullAlways,
+						SecurityContext: containerSecurityContext,
 						Env: []corev1.EnvVar{
 							{
 								Name:  "STEWARD_API",
@@ -0,0 +1,269 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/labstack/echo/v4"
+	synv1alpha1 "github.com/projectsyn/lieutenant-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// StewardRBACProfileAnnotation selects the RBAC profile of Steward on a cluster or tenant.
+	// The annotation on the cluster takes precedence over the one on the tenant.
+	StewardRBACProfileAnnotation = "steward.syn.tools/rbac-profile"
+	// StewardRBACConfigMapAnnotation selects the ConfigMap used for the custom RBAC profile on a cluster or tenant.
+	StewardRBACConfigMapAnnotation = "steward.syn.tools/rbac-configmap"
+
+	// StewardRBACProfileEnvVar is the env var name that's used to get the default RBAC profile
+	StewardRBACProfileEnvVar = "STEWARD_RBAC_PROFILE"
+	// StewardRBACConfigMapEnvVar is the env var name that's used to get the default ConfigMap of the custom RBAC profile
+	StewardRBACConfigMapEnvVar = "STEWARD_RBAC_CONFIGMAP"
+
+	// RBACProfileClusterAdmin grants Steward full access to the cluster
+	RBACProfileClusterAdmin = "cluster-admin"
+	// RBACProfileScoped grants Steward only what it needs to bootstrap Argo CD
+	RBACProfileScoped = "scoped"
+	// RBACProfileCustom reads the rules for Steward from a ConfigMap
+	RBACProfileCustom = "custom"
+
+	stewardRBACConfigMapDefault = "steward-rbac"
+	clusterRulesKey             = "clusterRules"
+	namespaceRulesKey           = "namespaceRules"
+
+	clusterAdminRoleName = "syn-admin"
+	stewardRoleName      = "syn-steward"
+)
+
+// rbacProfile holds the rules granted to Steward.
+// The cluster rules are granted cluster wide, the namespace rules only in Steward's namespace.
+type rbacProfile struct {
+	clusterRoleName string
+	clusterRules    []rbacv1.PolicyRule
+	namespaceRules  []rbacv1.PolicyRule
+}
+
+var (
+	clusterAdminProfile = rbacProfile{
+		clusterRoleName: clusterAdminRoleName,
+		clusterRules: []rbacv1.PolicyRule{{
+			APIGroups: []string{"*"},
+			Resources: []string{"*"},
+			Verbs:     []string{"*"},
+		}, {
+			NonResourceURLs: []string{"*"},
+			Verbs:           []string{"*"},
+		}},
+	}
+	scopedProfile = rbacProfile{
+		clusterRoleName: stewardRoleName,
+		clusterRules: []rbacv1.PolicyRule{{
+			// Reading facts about the cluster
+			APIGroups: []string{""},
+			Resources: []string{"namespaces", "nodes"},
+			Verbs:     []string{"get", "list", "watch"},
+		}, {
+			NonResourceURLs: []string{"/version", "/version/*"},
+			Verbs:           []string{"get"},
+		}, {
+			// Installing the Argo CD CRDs
+			APIGroups: []string{"apiextensions.k8s.io"},
+			Resources: []string{"customresourcedefinitions"},
+			Verbs:     []string{"get", "list", "watch", "create", "update", "patch"},
+		}, {
+			// Watching the Argo CD applications and projects, they're managed in Steward's namespace
+			APIGroups: []string{"argoproj.io"},
+			Resources: []string{"applications", "appprojects"},
+			Verbs:     []string{"get", "list", "watch"},
+		}, {
+			// The cluster wide RBAC of Argo CD is part of the install manifests, Steward only checks it
+			APIGroups: []string{rbacv1.GroupName},
+			Resources: []string{"clusterroles", "clusterrolebindings"},
+			Verbs:     []string{"get", "list", "watch"},
+		}},
+		// There are no wildcards in Steward's namespace, Steward can't read secrets, exec into pods, request tokens
+		// or bind roles there, as these would hand out the credentials of other service accounts in the namespace.
+		namespaceRules: []rbacv1.PolicyRule{{
+			// Deploying Argo CD
+			APIGroups: []string{""},
+			Resources: []string{"configmaps", "services", "serviceaccounts"},
+			Verbs:     []string{"get", "list", "watch", "create", "update", "patch", "delete"},
+		}, {
+			APIGroups: []string{"apps"},
+			Resources: []string{"deployments", "statefulsets"},
+			Verbs:     []string{"get", "list", "watch", "create", "update", "patch", "delete"},
+		}, {
+			// Reporting the state of Argo CD
+			APIGroups: []string{""},
+			Resources: []string{"pods", "events"},
+			Verbs:     []string{"get", "list", "watch"},
+		}, {
+			// Managing the root application and project
+			APIGroups: []string{"argoproj.io"},
+			Resources: []string{"applications", "appprojects"},
+			Verbs:     []string{"get", "list", "watch", "create", "update", "patch", "delete"},
+		}},
+	}
+)
+
+// stewardRBACProfile returns the RBAC profile configured for the cluster.
+// It's taken from the cluster's annotation, the tenant's annotation or the env var, in this order.
+func (s *APIImpl) stewardRBACProfile(ctx *APIContext, cluster *synv1alpha1.Cluster) (rbacProfile, error) {
+	profile, configMap := cluster.Annotations[StewardRBACProfileAnnotation], cluster.Annotations[StewardRBACConfigMapAnnotation]
+	if profile == "" || (profile == RBACProfileCustom && configMap == "") {
+		tenant := &synv1alpha1.Tenant{}
+		err := ctx.client.Get(ctx.Request().Context(), client.ObjectKey{Name: cluster.Spec.TenantRef.Name, Namespace: s.namespace}, tenant)
+		if client.IgnoreNotFound(err) != nil {
+			return rbacProfile{}, err
+		}
+		if profile == "" {
+			profile = tenant.Annotations[StewardRBACProfileAnnotation]
+		}
+		if configMap == "" {
+			configMap = tenant.Annotations[StewardRBACConfigMapAnnotation]
+		}
+	}
+	if profile == "" {
+		profile = os.Getenv(StewardRBACProfileEnvVar)
+	}
+	if configMap == "" {
+		configMap = os.Getenv(StewardRBACConfigMapEnvVar)
+	}
+	if configMap == "" {
+		configMap = stewardRBACConfigMapDefault
+	}
+
+	switch profile {
+	case "", RBACProfileClusterAdmin:
+		return clusterAdminProfile, nil
+	case RBACProfileScoped:
+		return scopedProfile, nil
+	case RBACProfileCustom:
+		return s.customRBACProfile(ctx, configMap)
+	}
+	return rbacProfile{}, echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Unknown Steward RBAC profile '%s'", profile))
+}
+
+// customRBACProfile reads the rules from the given ConfigMap in the API's namespace
+func (s *APIImpl) customRBACProfile(ctx *APIContext, name string) (rbacProfile, error) {
+	cm := &corev1.ConfigMap{}
+	if err := ctx.client.Get(ctx.Request().Context(), client.ObjectKey{Name: name, Namespace: s.namespace}, cm); err != nil {
+		return rbacProfile{}, fmt.Errorf("failed to get custom Steward RBAC profile: %w", err)
+	}
+	profile := rbacProfile{
+		clusterRoleName: stewardRoleName,
+	}
+	if err := yaml.Unmarshal([]byte(cm.Data[clusterRulesKey]), &profile.clusterRules); err != nil {
+		return rbacProfile{}, fmt.Errorf("failed to parse '%s' of ConfigMap '%s': %w", clusterRulesKey, name, err)
+	}
+	if err := yaml.Unmarshal([]byte(cm.Data[namespaceRulesKey]), &profile.namespaceRules); err != nil {
+		return rbacProfile{}, fmt.Errorf("failed to parse '%s' of ConfigMap '%s': %w", namespaceRulesKey, name, err)
+	}
+	if len(profile.clusterRules) == 0 && len(profile.namespaceRules) == 0 {
+		return rbacProfile{}, fmt.Errorf("ConfigMap '%s' doesn't contain any rules", name)
+	}
+	return profile, nil
+}
+
+func createRBAC(profile rbacProfile) []runtime.RawExtension {
+	objs := []runtime.RawExtension{}
+	if len(profile.clusterRules) > 0 {
+		objs = append(objs, createClusterRole(profile.clusterRoleName, profile.clusterRules)...)
+	}
+	if len(profile.namespaceRules) > 0 {
+		objs = append(objs, runtime.RawExtension{
+			Object: &rbacv1.Role{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: rbacv1.SchemeGroupVersion.String(),
+					Kind:       "Role",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      stewardRoleName,
+					Namespace: namespace,
+					Labels:    appLabels,
+				},
+				Rules: profile.namespaceRules,
+			},
+		}, runtime.RawExtension{
+			Object: &rbacv1.RoleBinding{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: rbacv1.SchemeGroupVersion.String(),
+					Kind:       "RoleBinding",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      stewardRoleName,
+					Namespace: namespace,
+					Labels:    appLabels,
+				},
+				RoleRef: rbacv1.RoleRef{
+					APIGroup: rbacv1.SchemeGroupVersion.Group,
+					Kind:     "Role",
+					Name:     stewardRoleName,
+				},
+				Subjects: stewardSubjects(),
+			},
+		})
+	}
+	return append(objs, runtime.RawExtension{
+		Object: &corev1.ServiceAccount{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: corev1.SchemeGroupVersion.String(),
+				Kind:       "ServiceAccount",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      appName,
+				Namespace: namespace,
+				Labels:    appLabels,
+			},
+		},
+	})
+}
+
+// createClusterRole creates the ClusterRole and binds it to Steward
+func createClusterRole(name string, rules []rbacv1.PolicyRule) []runtime.RawExtension {
+	return []runtime.RawExtension{{
+		Object: &rbacv1.ClusterRole{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: rbacv1.SchemeGroupVersion.String(),
+				Kind:       "ClusterRole",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: appLabels,
+			},
+			Rules: rules,
+		},
+	}, {
+		Object: &rbacv1.ClusterRoleBinding{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: rbacv1.SchemeGroupVersion.String(),
+				Kind:       "ClusterRoleBinding",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   stewardRoleName,
+				Labels: appLabels,
+			},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.SchemeGroupVersion.Group,
+				Kind:     "ClusterRole",
+				Name:     name,
+			},
+			Subjects: stewardSubjects(),
+		},
+	}}
+}
+
+func stewardSubjects() []rbacv1.Subject {
+	return []rbacv1.Subject{{
+		Kind:      "ServiceAccount",
+		Name:      appName,
+		Namespace: namespace,
+	}}
+}
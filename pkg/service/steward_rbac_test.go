@@ -0,0 +1,240 @@
+package service
+
+import (
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/oapi-codegen/testutil"
+	synv1alpha1 "github.com/projectsyn/lieutenant-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer/json"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var customRBACConfigMap = &corev1.ConfigMap{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "steward-rbac-custom",
+		Namespace: "default",
+	},
+	Data: map[string]string{
+		clusterRulesKey: `
+- apiGroups: [""]
+  resources: [nodes]
+  verbs: [get, list]
+`,
+		namespaceRulesKey: `
+- apiGroups: ["*"]
+  resources: ["*"]
+  verbs: ["*"]
+`,
+	},
+}
+
+func TestInstallStewardRBACProfile(t *testing.T) {
+	tcs := map[string]struct {
+		clusterAnnotations map[string]string
+		tenantAnnotations  map[string]string
+		env                string
+
+		clusterRole    string
+		clusterRules   []rbacv1.PolicyRule
+		namespaceRules []rbacv1.PolicyRule
+	}{
+		"default": {
+			clusterRole:  clusterAdminRoleName,
+			clusterRules: clusterAdminProfile.clusterRules,
+		},
+		"env": {
+			env:            RBACProfileScoped,
+			clusterRole:    stewardRoleName,
+			clusterRules:   scopedProfile.clusterRules,
+			namespaceRules: scopedProfile.namespaceRules,
+		},
+		"tenant": {
+			tenantAnnotations: map[string]string{
+				StewardRBACProfileAnnotation: RBACProfileScoped,
+			},
+			clusterRole:    stewardRoleName,
+			clusterRules:   scopedProfile.clusterRules,
+			namespaceRules: scopedProfile.namespaceRules,
+		},
+		"cluster overrides tenant": {
+			clusterAnnotations: map[string]string{
+				StewardRBACProfileAnnotation: RBACProfileClusterAdmin,
+			},
+			tenantAnnotations: map[string]string{
+				StewardRBACProfileAnnotation: RBACProfileScoped,
+			},
+			clusterRole:  clusterAdminRoleName,
+			clusterRules: clusterAdminProfile.clusterRules,
+		},
+		"custom": {
+			clusterAnnotations: map[string]string{
+				StewardRBACProfileAnnotation: RBACProfileCustom,
+			},
+			tenantAnnotations: map[string]string{
+				StewardRBACConfigMapAnnotation: customRBACConfigMap.Name,
+			},
+			clusterRole: stewardRoleName,
+			clusterRules: []rbacv1.PolicyRule{{
+				APIGroups: []string{""},
+				Resources: []string{"nodes"},
+				Verbs:     []string{"get", "list"},
+			}},
+			namespaceRules: []rbacv1.PolicyRule{{
+				APIGroups: []string{"*"},
+				Resources: []string{"*"},
+				Verbs:     []string{"*"},
+			}},
+		},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(StewardRBACProfileEnvVar, tc.env)
+			cluster := clusterA.DeepCopy()
+			for k, v := range tc.clusterAnnotations {
+				cluster.Annotations[k] = v
+			}
+			tenant := tenantA.DeepCopy()
+			for k, v := range tc.tenantAnnotations {
+				tenant.Annotations[k] = v
+			}
+			e, _ := rawSetupTest(t, cluster, tenant, clusterASA, clusterASecret, customRBACConfigMap)
+
+			result := testutil.NewRequest().
+				Get("/install/steward.json?token="+clusterA.Status.BootstrapToken.Token).
+				GoWithHTTPHandler(t, e)
+			requireHTTPCode(t, http.StatusOK, result)
+
+			objs := decodeManifests(t, result)
+			var clusterRole *rbacv1.ClusterRole
+			var clusterRoleBinding *rbacv1.ClusterRoleBinding
+			var role *rbacv1.Role
+			var roleBinding *rbacv1.RoleBinding
+			clusterRoles := 0
+			for _, obj := range objs {
+				switch o := obj.(type) {
+				case *rbacv1.ClusterRole:
+					clusterRoles++
+					if o.Name == tc.clusterRole {
+						clusterRole = o
+					}
+				case *rbacv1.ClusterRoleBinding:
+					clusterRoleBinding = o
+				case *rbacv1.Role:
+					role = o
+				case *rbacv1.RoleBinding:
+					roleBinding = o
+				}
+			}
+
+			require.NotNil(t, clusterRole)
+			require.NotNil(t, clusterRoleBinding)
+			assert.Equal(t, tc.clusterRole, clusterRole.Name)
+			assert.Equal(t, tc.clusterRules, clusterRole.Rules)
+			assert.Equal(t, tc.clusterRole, clusterRoleBinding.RoleRef.Name)
+			assert.Equal(t, 1, clusterRoles, "only Steward's own cluster role must be installed")
+			if tc.namespaceRules == nil {
+				assert.Nil(t, role)
+				assert.Nil(t, roleBinding)
+				return
+			}
+			require.NotNil(t, role)
+			require.NotNil(t, roleBinding)
+			assert.Equal(t, namespace, role.Namespace)
+			assert.Equal(t, tc.namespaceRules, role.Rules)
+			assert.Equal(t, role.Name, roleBinding.RoleRef.Name)
+			assert.Equal(t, appName, roleBinding.Subjects[0].Name)
+		})
+	}
+}
+
+func TestScopedRBACProfileCannotEscalate(t *testing.T) {
+	// Any of these would allow Steward to gain the rights of another service account or to grant itself more rights
+	forbidden := []string{"*", "secrets", "pods/exec", "pods/attach", "serviceaccounts/token", "roles", "rolebindings", "clusterroles", "clusterrolebindings"}
+	check := func(rule rbacv1.PolicyRule) {
+		assert.NotContains(t, rule.APIGroups, "*", "rule %v", rule)
+		for _, verb := range rule.Verbs {
+			assert.NotContains(t, []string{"*", "bind", "escalate", "impersonate"}, verb, "rule %v", rule)
+		}
+		for _, resource := range rule.Resources {
+			if resource == "clusterroles" || resource == "clusterrolebindings" {
+				assert.Subset(t, []string{"get", "list", "watch"}, rule.Verbs, "rule %v", rule)
+				continue
+			}
+			assert.NotContains(t, forbidden, resource, "rule %v", rule)
+		}
+	}
+	for _, rule := range scopedProfile.clusterRules {
+		check(rule)
+	}
+	for _, rule := range scopedProfile.namespaceRules {
+		check(rule)
+		if slices.Contains(rule.Resources, "pods") {
+			assert.NotContains(t, rule.Verbs, "create", "rule %v", rule)
+		}
+	}
+
+	// No other cluster roles are installed along with Steward
+	for _, obj := range createRBAC(scopedProfile) {
+		switch o := obj.Object.(type) {
+		case *rbacv1.ClusterRole:
+			assert.Equal(t, stewardRoleName, o.Name)
+		case *rbacv1.ClusterRoleBinding:
+			assert.Equal(t, stewardSubjects(), o.Subjects)
+		}
+	}
+}
+
+func TestInstallStewardRBACProfileInvalid(t *testing.T) {
+	tcs := map[string]map[string]string{
+		"unknown profile": {
+			StewardRBACProfileAnnotation: "root",
+		},
+		"missing ConfigMap": {
+			StewardRBACProfileAnnotation:   RBACProfileCustom,
+			StewardRBACConfigMapAnnotation: "not-existing",
+		},
+	}
+	for name, annotations := range tcs {
+		t.Run(name, func(t *testing.T) {
+			cluster := clusterA.DeepCopy()
+			for k, v := range annotations {
+				cluster.Annotations[k] = v
+			}
+			e, c := rawSetupTest(t, cluster, tenantA, clusterASA, clusterASecret)
+
+			result := testutil.NewRequest().
+				Get("/install/steward.json?token="+clusterA.Status.BootstrapToken.Token).
+				GoWithHTTPHandler(t, e)
+			requireHTTPCode(t, http.StatusInternalServerError, result)
+
+			// A misconfiguration must not use up the token
+			found := &synv1alpha1.Cluster{}
+			require.NoError(t, c.Get(t.Context(), client.ObjectKeyFromObject(clusterA), found))
+			assert.True(t, found.Status.BootstrapToken.TokenValid)
+		})
+	}
+}
+
+// decodeManifests decodes the list of manifests returned by the install endpoint
+func decodeManifests(t *testing.T, result *testutil.CompletedRequest) []runtime.Object {
+	t.Helper()
+	manifests := &corev1.List{}
+	require.NoError(t, result.UnmarshalJsonToObject(&manifests))
+	decoder := json.NewSerializer(json.DefaultMetaFactory, scheme, scheme, true)
+	objs := make([]runtime.Object, 0, len(manifests.Items))
+	for _, item := range manifests.Items {
+		obj, err := runtime.Decode(decoder, item.Raw)
+		require.NoError(t, err)
+		objs = append(objs, obj)
+	}
+	return objs
+}
@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	synv1alpha1 "github.com/projectsyn/lieutenant-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crlog "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/projectsyn/lieutenant-api/pkg/api"
+)
+
+const (
+	// RevokeAfterAnnotation marks a service account token secret to be deleted after the given time
+	RevokeAfterAnnotation = "steward.syn.tools/revoke-after"
+
+	// TokenRevocationIntervalEnvVar is the env var name that's used to get how often expired service account tokens are revoked
+	TokenRevocationIntervalEnvVar = "TOKEN_REVOCATION_INTERVAL"
+
+	defaultRotationGracePeriod     = time.Hour
+	defaultBootstrapTokenTTL       = 30 * time.Minute
+	defaultTokenRevocationInterval = time.Minute
+)
+
+// RotateStewardToken mints new credentials for Steward and returns a one-time install URL to fetch them
+func (s *APIImpl) RotateStewardToken(c echo.Context, clusterID api.ClusterIdParameter, params api.RotateStewardTokenParams) error {
+	ctx := c.(*APIContext)
+
+	gracePeriod := defaultRotationGracePeriod
+	if params.GracePeriod != nil && *params.GracePeriod != "" {
+		d, err := time.ParseDuration(*params.GracePeriod)
+		if err != nil || d < 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid grace period '%s'", *params.GracePeriod))
+		}
+		gracePeriod = d
+	}
+
+	cluster := &synv1alpha1.Cluster{}
+	if err := ctx.client.Get(ctx.Request().Context(), client.ObjectKey{Name: string(clusterID), Namespace: s.namespace}, cluster); err != nil {
+		return err
+	}
+
+	secrets, err := s.serviceAccountTokenSecrets(ctx, cluster.Name)
+	if err != nil {
+		return err
+	}
+
+	// Tokens obtained with the TokenRequest API are requested on install, only legacy tokens are minted here
+	if tokenSource() != TokenSourceTokenRequest {
+		newSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: cluster.Name + "-",
+				Namespace:    s.namespace,
+				Annotations: map[string]string{
+					corev1.ServiceAccountNameKey: cluster.Name,
+				},
+			},
+			Type: corev1.SecretTypeServiceAccountToken,
+		}
+		if err := ctx.client.Create(ctx.Request().Context(), newSecret); err != nil {
+			return err
+		}
+	}
+
+	revokeAfter := time.Now().Add(gracePeriod).UTC()
+	for i := range secrets {
+		if _, marked := secrets[i].Annotations[RevokeAfterAnnotation]; marked {
+			continue
+		}
+		if gracePeriod == 0 {
+			if err := ctx.client.Delete(ctx.Request().Context(), &secrets[i]); client.IgnoreNotFound(err) != nil {
+				return err
+			}
+			continue
+		}
+		patch := client.MergeFrom(secrets[i].DeepCopy())
+		secrets[i].Annotations[RevokeAfterAnnotation] = revokeAfter.Format(time.RFC3339)
+		if err := ctx.client.Patch(ctx.Request().Context(), &secrets[i], patch); err != nil {
+			return err
+		}
+	}
+	if err := revokeExpiredSATokens(ctx.Request().Context(), ctx.client, secrets); err != nil {
+		return err
+	}
+
+	token, err := newBootstrapToken(cluster)
+	if err != nil {
+		return err
+	}
+	patch := client.MergeFrom(cluster.DeepCopy())
+	cluster.Status.BootstrapToken = token
+	if err := ctx.client.Status().Patch(ctx.Request().Context(), cluster, patch); err != nil {
+		return err
+	}
+
+	rotation := api.StewardRotation{
+		InstallURL: installURL(ctx, token.Token),
+	}
+	if gracePeriod > 0 {
+		rotation.RevokeAfter = &revokeAfter
+	}
+	return ctx.JSON(http.StatusOK, rotation)
+}
+
+// serviceAccountTokenSecrets returns all service account token secrets of the given service account,
+// including the secrets tokens obtained with the TokenRequest API are bound to
+func (s *APIImpl) serviceAccountTokenSecrets(ctx *APIContext, saName string) ([]corev1.Secret, error) {
+	secrets, err := listTokenSecrets(ctx.Request().Context(), ctx.client, s.namespace)
+	if err != nil {
+		return nil, err
+	}
+	saSecrets := make([]corev1.Secret, 0, len(secrets))
+	for _, secret := range secrets {
+		if secret.Annotations[corev1.ServiceAccountNameKey] == saName {
+			saSecrets = append(saSecrets, secret)
+		}
+	}
+	return saSecrets, nil
+}
+
+// listTokenSecrets returns the legacy service account token secrets and the secrets requested tokens are bound to
+func listTokenSecrets(ctx context.Context, c client.Reader, namespace string) ([]corev1.Secret, error) {
+	tokenSecrets := []corev1.Secret{}
+	for _, secretType := range []corev1.SecretType{corev1.SecretTypeServiceAccountToken, BoundTokenSecretType} {
+		secrets := &corev1.SecretList{}
+		if err := c.List(ctx, secrets, client.InNamespace(namespace), client.MatchingFields{"type": string(secretType)}); err != nil {
+			return nil, err
+		}
+		for _, secret := range secrets.Items {
+			// Not strictly necessary but our testing framework can't handle field selectors
+			if secret.Type == secretType {
+				tokenSecrets = append(tokenSecrets, secret)
+			}
+		}
+	}
+	return tokenSecrets, nil
+}
+
+// RunTokenRevocation revokes the service account tokens in the namespace whose grace period after a rotation has passed.
+// It checks the tokens periodically with the API's own client until the context is done.
+func RunTokenRevocation(ctx context.Context, namespace string) error {
+	if namespace == "" {
+		namespace = "default"
+	}
+	c, err := getClientFromToken("")
+	if err != nil {
+		return err
+	}
+	interval := defaultTokenRevocationInterval
+	if raw := os.Getenv(TokenRevocationIntervalEnvVar); raw != "" {
+		interval, err = time.ParseDuration(raw)
+		if err != nil || interval <= 0 {
+			return fmt.Errorf("invalid %s '%s'", TokenRevocationIntervalEnvVar, raw)
+		}
+	}
+	go revokeTokensPeriodically(ctx, c, namespace, interval)
+	return nil
+}
+
+// revokeTokensPeriodically revokes expired service account tokens every interval until the context is done
+func revokeTokensPeriodically(ctx context.Context, c client.Client, namespace string, interval time.Duration) {
+	log := crlog.FromContext(ctx).WithName("token-revocation")
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		secrets, err := listTokenSecrets(ctx, c, namespace)
+		if err != nil {
+			log.Error(err, "failed to list service account tokens")
+			continue
+		}
+		if err := revokeExpiredSATokens(ctx, c, secrets); err != nil {
+			log.Error(err, "failed to revoke service account tokens")
+		}
+	}
+}
+
+// revokeExpiredSATokens deletes the secrets whose grace period after a rotation has passed
+func revokeExpiredSATokens(ctx context.Context, c client.Client, secrets []corev1.Secret) error {
+	now := time.Now()
+	for i, secret := range secrets {
+		if !tokenRevoked(secret, now) {
+			continue
+		}
+		if err := c.Delete(ctx, &secrets[i]); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tokenRevoked checks if the secret was marked for revocation and the grace period has passed
+func tokenRevoked(secret corev1.Secret, now time.Time) bool {
+	revokeAfter, ok := secret.Annotations[RevokeAfterAnnotation]
+	if !ok {
+		return false
+	}
+	t, err := time.Parse(time.RFC3339, revokeAfter)
+	return err != nil || !now.Before(t)
+}
+
+// newBootstrapToken creates a new random bootstrap token valid for the token lifetime of the cluster
+func newBootstrapToken(cluster *synv1alpha1.Cluster) (*synv1alpha1.BootstrapToken, error) {
+	ttl := defaultBootstrapTokenTTL
+	if cluster.Spec.TokenLifeTime != "" {
+		d, err := time.ParseDuration(cluster.Spec.TokenLifeTime)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse token lifetime of cluster: %w", err)
+		}
+		ttl = d
+	}
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return nil, fmt.Errorf("failed to generate bootstrap token: %w", err)
+	}
+	return &synv1alpha1.BootstrapToken{
+		Token:      hex.EncodeToString(b),
+		TokenValid: true,
+		ValidUntil: metav1.NewTime(time.Now().Add(ttl)),
+	}, nil
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/testutil"
+	synv1alpha1 "github.com/projectsyn/lieutenant-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/projectsyn/lieutenant-api/pkg/api"
+)
+
+func TestRotateStewardToken(t *testing.T) {
+	e, c := setupTest(t)
+
+	result := testutil.NewRequest().
+		Post("/clusters/"+clusterB.Name+"/steward/rotate?gracePeriod=30m").
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+	rotation := &api.StewardRotation{}
+	require.NoError(t, result.UnmarshalJsonToObject(rotation))
+	require.NotNil(t, rotation.RevokeAfter)
+	assert.WithinDuration(t, time.Now().Add(30*time.Minute), *rotation.RevokeAfter, time.Minute)
+
+	installURL, err := url.Parse(rotation.InstallURL)
+	require.NoError(t, err)
+	token := installURL.Query().Get("token")
+	require.NotEmpty(t, token)
+	assert.NotEqual(t, clusterB.Status.BootstrapToken.Token, token)
+
+	cluster := &synv1alpha1.Cluster{}
+	require.NoError(t, c.Get(t.Context(), client.ObjectKeyFromObject(clusterB), cluster))
+	require.NotNil(t, cluster.Status.BootstrapToken)
+	assert.Equal(t, token, cluster.Status.BootstrapToken.Token)
+	assert.True(t, cluster.Status.BootstrapToken.TokenValid)
+
+	old := &corev1.Secret{}
+	require.NoError(t, c.Get(t.Context(), client.ObjectKeyFromObject(clusterBSecret), old))
+	assert.Equal(t, rotation.RevokeAfter.Format(time.RFC3339), old.Annotations[RevokeAfterAnnotation])
+
+	secrets := &corev1.SecretList{}
+	require.NoError(t, c.List(t.Context(), secrets, client.InNamespace("default")))
+	var newSecret *corev1.Secret
+	for i, s := range secrets.Items {
+		if s.Name != clusterBSecret.Name && s.Annotations[corev1.ServiceAccountNameKey] == clusterB.Name {
+			newSecret = &secrets.Items[i]
+		}
+	}
+	require.NotNil(t, newSecret, "Could not find new service account token secret")
+	assert.True(t, strings.HasPrefix(newSecret.Name, clusterB.Name+"-"))
+	assert.Equal(t, corev1.SecretTypeServiceAccountToken, newSecret.Type)
+
+	// The token controller populates the new secret
+	newSecret.Data = map[string][]byte{corev1.ServiceAccountTokenKey: []byte("rotatedtoken")}
+	require.NoError(t, c.Update(t.Context(), newSecret))
+
+	result = testutil.NewRequest().
+		Get("/install/steward.json?token="+token).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+	found := false
+	for _, obj := range decodeManifests(t, result) {
+		if secret, ok := obj.(*corev1.Secret); ok {
+			found = true
+			assert.Equal(t, "rotatedtoken", secret.StringData["token"])
+		}
+	}
+	assert.True(t, found, "Could not find secret with steward token")
+}
+
+func TestRotateStewardTokenImmediately(t *testing.T) {
+	e, c := setupTest(t)
+
+	result := testutil.NewRequest().
+		Post("/clusters/"+clusterA.Name+"/steward/rotate?gracePeriod=0s").
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+	rotation := &api.StewardRotation{}
+	require.NoError(t, result.UnmarshalJsonToObject(rotation))
+	assert.Nil(t, rotation.RevokeAfter)
+
+	for _, secret := range []*corev1.Secret{clusterASecret, newClusterASecret} {
+		err := c.Get(t.Context(), client.ObjectKeyFromObject(secret), &corev1.Secret{})
+		assert.True(t, apierrors.IsNotFound(err), "Secret %s must be revoked", secret.Name)
+	}
+	assert.NoError(t, c.Get(t.Context(), client.ObjectKeyFromObject(wrongSecret), &corev1.Secret{}))
+}
+
+func TestRotateStewardTokenInvalidGracePeriod(t *testing.T) {
+	e, _ := setupTest(t)
+
+	result := testutil.NewRequest().
+		Post("/clusters/"+clusterA.Name+"/steward/rotate?gracePeriod=tomorrow").
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusBadRequest, result)
+	reason := &api.Reason{}
+	require.NoError(t, result.UnmarshalJsonToObject(reason))
+	assert.Contains(t, reason.Reason, "Invalid grace period")
+}
+
+func TestRotateStewardTokenNotFound(t *testing.T) {
+	e, _ := setupTest(t)
+
+	result := testutil.NewRequest().
+		Post("/clusters/c-not-existing/steward/rotate").
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusNotFound, result)
+}
+
+func TestInstallStewardRevokesExpiredTokens(t *testing.T) {
+	expired := clusterASecret.DeepCopy()
+	expired.Name = "expired-secret"
+	expired.CreationTimestamp = metav1.NewTime(time.Now().Add(-48 * time.Hour))
+	expired.Annotations = map[string]string{
+		corev1.ServiceAccountNameKey: clusterA.Name,
+		RevokeAfterAnnotation:        time.Now().Add(-time.Minute).Format(time.RFC3339),
+	}
+	pending := clusterASecret.DeepCopy()
+	pending.Name = "pending-secret"
+	pending.CreationTimestamp = metav1.NewTime(time.Now().Add(-24 * time.Hour))
+	pending.Annotations = map[string]string{
+		corev1.ServiceAccountNameKey: clusterA.Name,
+		RevokeAfterAnnotation:        time.Now().Add(time.Hour).Format(time.RFC3339),
+	}
+	e, c := setupTest(t, expired, pending)
+
+	result := testutil.NewRequest().
+		Get("/install/steward.json?token="+clusterA.Status.BootstrapToken.Token).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+	for _, obj := range decodeManifests(t, result) {
+		if secret, ok := obj.(*corev1.Secret); ok {
+			assert.Equal(t, "sometoken", secret.StringData["token"], "Tokens marked for revocation must not be used")
+		}
+	}
+
+	err := c.Get(t.Context(), client.ObjectKeyFromObject(expired), &corev1.Secret{})
+	assert.True(t, apierrors.IsNotFound(err), "Expired secret must be revoked")
+	assert.NoError(t, c.Get(t.Context(), client.ObjectKeyFromObject(pending), &corev1.Secret{}))
+}
+
+func TestRevokeTokensAfterGracePeriod(t *testing.T) {
+	e, c := setupTest(t)
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	go revokeTokensPeriodically(ctx, c, "default", 10*time.Millisecond)
+
+	result := testutil.NewRequest().
+		Post("/clusters/"+clusterB.Name+"/steward/rotate?gracePeriod=2s").
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+	require.NoError(t, c.Get(t.Context(), client.ObjectKeyFromObject(clusterBSecret), &corev1.Secret{}), "Old token must be kept during the grace period")
+
+	// No further API call is made, the old token is revoked by the sweep
+	assert.Eventually(t, func() bool {
+		err := c.Get(t.Context(), client.ObjectKeyFromObject(clusterBSecret), &corev1.Secret{})
+		return apierrors.IsNotFound(err)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	secrets := &corev1.SecretList{}
+	require.NoError(t, c.List(t.Context(), secrets, client.InNamespace("default")))
+	newTokens := 0
+	for _, s := range secrets.Items {
+		if s.Annotations[corev1.ServiceAccountNameKey] == clusterB.Name {
+			newTokens++
+		}
+	}
+	assert.Equal(t, 1, newTokens, "The new token must not be revoked")
+}
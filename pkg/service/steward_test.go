@@ -1,18 +1,32 @@
 package service
 
 import (
+	"context"
+	stdjson "encoding/json"
+	"errors"
+	"flag"
 	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/AlekSi/pointer"
+	"github.com/labstack/echo/v4"
 	"github.com/oapi-codegen/testutil"
+	synv1alpha1 "github.com/projectsyn/lieutenant-operator/api/v1alpha1"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer/json"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	"github.com/projectsyn/lieutenant-api/pkg/api"
 )
@@ -185,3 +199,221 @@ func TestInstallStewardUsedToken(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Contains(t, reason.Reason, "Token already used or expired")
 }
+
+func TestInstallStewardInvalidatesToken(t *testing.T) {
+	e, c := setupTest(t)
+
+	result := testutil.NewRequest().
+		Get("/install/steward.json?token="+clusterA.Status.BootstrapToken.Token).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+
+	cluster := &synv1alpha1.Cluster{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(clusterA), cluster))
+	require.NotNil(t, cluster.Status.BootstrapToken)
+	assert.False(t, cluster.Status.BootstrapToken.TokenValid)
+
+	result = testutil.NewRequest().
+		Get("/install/steward.json?token="+clusterA.Status.BootstrapToken.Token).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusUnauthorized, result)
+}
+
+func TestInstallStewardConcurrent(t *testing.T) {
+	e, _ := setupTest(t)
+
+	const requests = 10
+	codes := make(chan int, requests)
+	bodies := make(chan string, requests)
+	var wg sync.WaitGroup
+	for range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req := httptest.NewRequest(http.MethodGet, "/install/steward.json?token="+clusterA.Status.BootstrapToken.Token, nil)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			codes <- rec.Code
+			bodies <- rec.Body.String()
+		}()
+	}
+	wg.Wait()
+	close(codes)
+	close(bodies)
+
+	succeeded := 0
+	for code := range codes {
+		if code == http.StatusOK {
+			succeeded++
+		} else {
+			assert.Equal(t, http.StatusUnauthorized, code)
+		}
+	}
+	assert.Equal(t, 1, succeeded, "Exactly one request must receive the manifests")
+
+	withToken := 0
+	for body := range bodies {
+		if strings.Contains(body, "sometoken") {
+			withToken++
+		}
+	}
+	assert.Equal(t, 1, withToken, "Exactly one response must contain the service account token")
+}
+
+func TestInstallStewardInvalidationFails(t *testing.T) {
+	e, c := setupTestWithInterceptor(t, interceptor.Funcs{
+		SubResourcePatch: func(ctx context.Context, client client.Client, subResourceName string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+			return errors.New("etcd is on fire")
+		},
+	}, testObjects...)
+
+	result := testutil.NewRequest().
+		Get("/install/steward.json?token="+clusterA.Status.BootstrapToken.Token).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusInternalServerError, result)
+	assert.NotContains(t, result.Recorder.Body.String(), "sometoken")
+
+	cluster := &synv1alpha1.Cluster{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(clusterA), cluster))
+	assert.True(t, cluster.Status.BootstrapToken.TokenValid)
+}
+
+func TestInstallStewardConflictingUpdate(t *testing.T) {
+	conflicted := false
+	e, c := setupTestWithInterceptor(t, interceptor.Funcs{
+		SubResourcePatch: func(ctx context.Context, cl client.Client, subResourceName string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+			if !conflicted {
+				// Simulate an unrelated concurrent modification of the cluster
+				conflicted = true
+				cluster := &synv1alpha1.Cluster{}
+				require.NoError(t, cl.Get(ctx, client.ObjectKeyFromObject(obj), cluster))
+				cluster.Status.Facts["concurrent"] = "change"
+				require.NoError(t, cl.Status().Update(ctx, cluster))
+			}
+			return cl.Status().Patch(ctx, obj, patch, opts...)
+		},
+	}, testObjects...)
+
+	result := testutil.NewRequest().
+		Get("/install/steward.json?token="+clusterA.Status.BootstrapToken.Token).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+
+	cluster := &synv1alpha1.Cluster{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(clusterA), cluster))
+	assert.False(t, cluster.Status.BootstrapToken.TokenValid)
+	assert.Equal(t, "change", cluster.Status.Facts["concurrent"])
+}
+
+var updateGolden = flag.Bool("update", false, "update the golden files in testdata")
+
+func TestInstallListVariants(t *testing.T) {
+	tcs := map[string]struct {
+		distribution string
+		restricted   string
+		variant      manifestVariant
+	}{
+		"default": {
+			variant: variantDefault,
+		},
+		"unknown distribution": {
+			distribution: "k3d",
+			variant:      variantDefault,
+		},
+		"openshift4": {
+			distribution: "openshift4",
+			variant:      variantOpenShift,
+		},
+		"restricted": {
+			distribution: "rke2",
+			variant:      variantRestricted,
+		},
+		"configured restricted": {
+			distribution: "eks",
+			restricted:   "gke, eks",
+			variant:      variantRestricted,
+		},
+	}
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("STEWARD_IMAGE", "")
+			t.Setenv("API_HOST", "")
+			t.Setenv(StewardRestrictedDistributionsEnvVar, tc.restricted)
+			cluster := clusterA.DeepCopy()
+			cluster.Spec.Facts = synv1alpha1.Facts{}
+			if tc.distribution != "" {
+				cluster.Spec.Facts[DistributionFact] = tc.distribution
+			}
+			assert.Equal(t, tc.variant, manifestVariantFor(cluster))
+
+			list := createInstallList("https://api.example.com", cluster, "sometoken", clusterAdminProfile)
+			actual, err := stdjson.MarshalIndent(list, "", "  ")
+			require.NoError(t, err)
+
+			golden := filepath.Join("testdata", "install_"+string(tc.variant)+".golden.json")
+			if *updateGolden {
+				require.NoError(t, os.WriteFile(golden, append(actual, '\n'), 0o644))
+			}
+			expected, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(expected), string(actual))
+		})
+	}
+}
+
+func TestInstallStewardRecordsInstallation(t *testing.T) {
+	t.Setenv("STEWARD_IMAGE", "docker.io/projectsyn/steward:v1.0.0")
+	t.Setenv(TrustedProxiesEnvVar, "192.0.2.0/24")
+	e, _ := setupTest(t)
+
+	before := time.Now().Add(-time.Second)
+	// The request passes the trusted proxy at 192.0.2.1
+	result := testutil.NewRequest().
+		WithHeader(echo.HeaderXForwardedFor, "198.51.100.7").
+		WithHeader("User-Agent", "kubectl/v1.30.0").
+		Get("/install/steward.json?token="+clusterA.Status.BootstrapToken.Token).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+
+	result = testutil.NewRequest().
+		Get("/clusters/"+clusterA.Name).
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+	cluster := &api.Cluster{}
+	require.NoError(t, result.UnmarshalJsonToObject(cluster))
+	require.NotNil(t, cluster.StewardInstallation)
+	require.NotNil(t, cluster.StewardInstallation.InstalledAt)
+	assert.True(t, cluster.StewardInstallation.InstalledAt.After(before))
+	assert.Equal(t, "198.51.100.7", pointer.GetString(cluster.StewardInstallation.ClientIP))
+	assert.Equal(t, "kubectl/v1.30.0", pointer.GetString(cluster.StewardInstallation.UserAgent))
+	assert.Equal(t, "docker.io/projectsyn/steward:v1.0.0", pointer.GetString(cluster.StewardInstallation.Image))
+	assert.Equal(t, clusterA.Annotations["some"], (*cluster.Annotations)["some"], "Existing annotations must be kept")
+}
+
+func TestInstallStewardIgnoresUntrustedForwardedFor(t *testing.T) {
+	e, _ := setupTest(t)
+
+	result := testutil.NewRequest().
+		WithHeader(echo.HeaderXForwardedFor, "198.51.100.7").
+		WithHeader(echo.HeaderXRealIP, "198.51.100.7").
+		Get("/install/steward.json?token="+clusterA.Status.BootstrapToken.Token).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+
+	result = testutil.NewRequest().
+		Get("/clusters/"+clusterA.Name).
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+	cluster := &api.Cluster{}
+	require.NoError(t, result.UnmarshalJsonToObject(cluster))
+	require.NotNil(t, cluster.StewardInstallation)
+	assert.Equal(t, "192.0.2.1", pointer.GetString(cluster.StewardInstallation.ClientIP))
+}
+
+func TestNewAPIServerInvalidTrustedProxies(t *testing.T) {
+	t.Setenv(TrustedProxiesEnvVar, "10.0.0.0/8,proxy")
+	_, err := NewAPIServer(APIConfig{APIVersion: "v1", Namespace: "default"})
+	assert.ErrorContains(t, err, "invalid TRUSTED_PROXIES")
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// StewardTokenSourceEnvVar is the env var name that's used to get the source of Steward's service account token
+	StewardTokenSourceEnvVar = "STEWARD_TOKEN_SOURCE"
+	// StewardTokenExpirationEnvVar is the env var name that's used to get the expiration of tokens obtained with the TokenRequest API
+	StewardTokenExpirationEnvVar = "STEWARD_TOKEN_EXPIRATION"
+
+	// TokenSourceAuto uses a legacy service account token secret if there is one and the TokenRequest API otherwise
+	TokenSourceAuto = "auto"
+	// TokenSourceTokenRequest uses the TokenRequest API and falls back to legacy service account token secrets on failure
+	TokenSourceTokenRequest = "tokenrequest"
+	// TokenSourceSecret only uses legacy service account token secrets
+	TokenSourceSecret = "secret"
+
+	// BoundTokenSecretType is the type of the secrets tokens obtained with the TokenRequest API are bound to.
+	// Deleting such a secret revokes the token.
+	BoundTokenSecretType corev1.SecretType = "steward.syn.tools/bound-token"
+
+	defaultTokenExpiration = 365 * 24 * time.Hour
+)
+
+var errTokenUnavailable = echo.NewHTTPError(http.StatusServiceUnavailable, "Unable to find token for Cluster. This error might be transient, please try again.")
+
+// getServiceAccountToken returns a token for the given service account from the configured source
+func (s *APIImpl) getServiceAccountToken(ctx *APIContext, saName string) (string, error) {
+	source := tokenSource()
+	switch source {
+	case TokenSourceAuto, TokenSourceSecret:
+		token, err := s.legacyServiceAccountToken(ctx, saName)
+		if err != nil {
+			return "", err
+		}
+		if token != "" {
+			return token, nil
+		}
+		if source == TokenSourceSecret {
+			return "", errTokenUnavailable
+		}
+		return s.requestServiceAccountToken(ctx, saName)
+	case TokenSourceTokenRequest:
+		token, err := s.requestServiceAccountToken(ctx, saName)
+		if err == nil {
+			return token, nil
+		}
+		ctx.Logger().Warnf("failed to request token for service account %s, falling back to legacy secrets: %v", saName, err)
+		token, err = s.legacyServiceAccountToken(ctx, saName)
+		if err != nil {
+			return "", err
+		}
+		if token == "" {
+			return "", errTokenUnavailable
+		}
+		return token, nil
+	}
+	return "", echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Unknown Steward token source '%s'", source))
+}
+
+// tokenSource returns the configured source of Steward's service account token
+func tokenSource() string {
+	if source := os.Getenv(StewardTokenSourceEnvVar); source != "" {
+		return source
+	}
+	return TokenSourceAuto
+}
+
+// requestServiceAccountToken obtains a token for the given service account with the TokenRequest API.
+// The token is bound to a new secret, so that it can be revoked like a legacy service account token secret.
+func (s *APIImpl) requestServiceAccountToken(ctx *APIContext, saName string) (string, error) {
+	expiration, err := tokenExpiration()
+	if err != nil {
+		return "", err
+	}
+	boundSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: saName + "-",
+			Namespace:    s.namespace,
+			Annotations: map[string]string{
+				corev1.ServiceAccountNameKey: saName,
+			},
+		},
+		Type: BoundTokenSecretType,
+	}
+	if err := ctx.client.Create(ctx.Request().Context(), boundSecret); err != nil {
+		return "", err
+	}
+
+	sa := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      saName,
+			Namespace: s.namespace,
+		},
+	}
+	tokenRequest := &authenticationv1.TokenRequest{
+		Spec: authenticationv1.TokenRequestSpec{
+			ExpirationSeconds: &expiration,
+			BoundObjectRef: &authenticationv1.BoundObjectReference{
+				APIVersion: corev1.SchemeGroupVersion.String(),
+				Kind:       "Secret",
+				Name:       boundSecret.Name,
+				UID:        boundSecret.UID,
+			},
+		},
+	}
+	err = ctx.client.SubResource("token").Create(ctx.Request().Context(), sa, tokenRequest)
+	if err == nil && tokenRequest.Status.Token == "" {
+		err = errTokenUnavailable
+	}
+	if err != nil {
+		if err := ctx.client.Delete(ctx.Request().Context(), boundSecret); client.IgnoreNotFound(err) != nil {
+			ctx.Logger().Errorf("failed to delete secret %s of failed token request: %v", boundSecret.Name, err)
+		}
+		return "", err
+	}
+	return tokenRequest.Status.Token, nil
+}
+
+// tokenExpiration returns the configured expiration of requested tokens in seconds
+func tokenExpiration() (int64, error) {
+	raw := os.Getenv(StewardTokenExpirationEnvVar)
+	if raw == "" {
+		return int64(defaultTokenExpiration.Seconds()), nil
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse %s: %w", StewardTokenExpirationEnvVar, err)
+	}
+	// The API server rejects expirations shorter than 10 minutes
+	if d < 10*time.Minute {
+		return 0, fmt.Errorf("%s must be at least 10m, got %s", StewardTokenExpirationEnvVar, raw)
+	}
+	return int64(d.Seconds()), nil
+}
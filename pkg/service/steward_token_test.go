@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestInstallStewardTokenSource(t *testing.T) {
+	tcs := map[string]struct {
+		source  string
+		objs    []client.Object
+		code    int
+		saToken string
+	}{
+		"auto prefers legacy secret": {
+			objs:    []client.Object{clusterA, tenantA, clusterASA, clusterASecret},
+			code:    http.StatusOK,
+			saToken: "sometoken",
+		},
+		"auto without secret": {
+			objs:    []client.Object{clusterA, tenantA, clusterASA},
+			code:    http.StatusOK,
+			saToken: "fake-token",
+		},
+		"tokenrequest": {
+			source:  TokenSourceTokenRequest,
+			objs:    []client.Object{clusterA, tenantA, clusterASA, clusterASecret},
+			code:    http.StatusOK,
+			saToken: "fake-token",
+		},
+		"tokenrequest falls back to secret": {
+			source:  TokenSourceTokenRequest,
+			objs:    []client.Object{clusterA, tenantA, clusterASecret},
+			code:    http.StatusOK,
+			saToken: "sometoken",
+		},
+		"tokenrequest without any token": {
+			source: TokenSourceTokenRequest,
+			objs:   []client.Object{clusterA, tenantA},
+			code:   http.StatusServiceUnavailable,
+		},
+		"secret": {
+			source:  TokenSourceSecret,
+			objs:    []client.Object{clusterA, tenantA, clusterASA, clusterASecret},
+			code:    http.StatusOK,
+			saToken: "sometoken",
+		},
+		"secret without secret": {
+			source: TokenSourceSecret,
+			objs:   []client.Object{clusterA, tenantA, clusterASA},
+			code:   http.StatusServiceUnavailable,
+		},
+		"unknown source": {
+			source: "vault",
+			objs:   []client.Object{clusterA, tenantA, clusterASA, clusterASecret},
+			code:   http.StatusInternalServerError,
+		},
+	}
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(StewardTokenSourceEnvVar, tc.source)
+			e, _ := rawSetupTest(t, tc.objs...)
+
+			result := testutil.NewRequest().
+				Get("/install/steward.json?token="+clusterA.Status.BootstrapToken.Token).
+				GoWithHTTPHandler(t, e)
+			requireHTTPCode(t, tc.code, result)
+			if tc.code != http.StatusOK {
+				return
+			}
+			found := false
+			for _, obj := range decodeManifests(t, result) {
+				if secret, ok := obj.(*corev1.Secret); ok {
+					found = true
+					assert.Equal(t, tc.saToken, secret.StringData["token"])
+				}
+			}
+			assert.True(t, found, "Could not find secret with steward token")
+		})
+	}
+}
+
+func TestInstallStewardTokenExpiration(t *testing.T) {
+	tcs := map[string]struct {
+		expiration string
+		seconds    int64
+	}{
+		"default": {
+			seconds: int64(defaultTokenExpiration.Seconds()),
+		},
+		"configured": {
+			expiration: "48h",
+			seconds:    48 * 60 * 60,
+		},
+	}
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(StewardTokenSourceEnvVar, TokenSourceTokenRequest)
+			t.Setenv(StewardTokenExpirationEnvVar, tc.expiration)
+			var requested *int64
+			e, _ := setupTestWithInterceptor(t, interceptor.Funcs{
+				SubResourceCreate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+					if tr, ok := subResource.(*authenticationv1.TokenRequest); ok {
+						requested = tr.Spec.ExpirationSeconds
+					}
+					return c.SubResource(subResourceName).Create(ctx, obj, subResource, opts...)
+				},
+			}, clusterA, tenantA, clusterASA)
+
+			result := testutil.NewRequest().
+				Get("/install/steward.json?token="+clusterA.Status.BootstrapToken.Token).
+				GoWithHTTPHandler(t, e)
+			requireHTTPCode(t, http.StatusOK, result)
+			require.NotNil(t, requested)
+			assert.Equal(t, tc.seconds, *requested)
+		})
+	}
+}
+
+func TestInstallStewardTokenRequestIsRevocable(t *testing.T) {
+	t.Setenv(StewardTokenSourceEnvVar, TokenSourceTokenRequest)
+	var boundRef *authenticationv1.BoundObjectReference
+	e, c := setupTestWithInterceptor(t, interceptor.Funcs{
+		SubResourceCreate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+			if tr, ok := subResource.(*authenticationv1.TokenRequest); ok {
+				boundRef = tr.Spec.BoundObjectRef
+			}
+			return c.SubResource(subResourceName).Create(ctx, obj, subResource, opts...)
+		},
+	}, clusterA, tenantA, clusterASA)
+
+	result := testutil.NewRequest().
+		Get("/install/steward.json?token="+clusterA.Status.BootstrapToken.Token).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+	require.NotNil(t, boundRef, "Requested token must be bound to a secret")
+	assert.Equal(t, "Secret", boundRef.Kind)
+
+	bound := &corev1.Secret{}
+	require.NoError(t, c.Get(t.Context(), client.ObjectKey{Name: boundRef.Name, Namespace: "default"}, bound))
+	assert.Equal(t, BoundTokenSecretType, bound.Type)
+	assert.Equal(t, bound.UID, boundRef.UID)
+	assert.Equal(t, clusterA.Name, bound.Annotations[corev1.ServiceAccountNameKey])
+
+	// Rotating the credentials deletes the secret, which revokes the requested token
+	result = testutil.NewRequest().
+		Post("/clusters/"+clusterA.Name+"/steward/rotate?gracePeriod=0s").
+		WithHeader(echo.HeaderAuthorization, bearerToken).
+		GoWithHTTPHandler(t, e)
+	requireHTTPCode(t, http.StatusOK, result)
+	err := c.Get(t.Context(), client.ObjectKeyFromObject(bound), &corev1.Secret{})
+	assert.True(t, apierrors.IsNotFound(err), "Bound secret must be deleted")
+
+	secrets := &corev1.SecretList{}
+	require.NoError(t, c.List(t.Context(), secrets, client.InNamespace("default")))
+	for _, secret := range secrets.Items {
+		assert.NotEqual(t, clusterA.Name, secret.Annotations[corev1.ServiceAccountNameKey], "No legacy token must be minted with the TokenRequest source")
+	}
+}
+
+func TestNewAPIServerInvalidTokenExpiration(t *testing.T) {
+	tcs := map[string]struct {
+		expiration string
+		err        string
+	}{
+		"too short": {
+			expiration: "1m",
+			err:        "STEWARD_TOKEN_EXPIRATION must be at least 10m, got 1m",
+		},
+		"invalid": {
+			expiration: "forever",
+			err:        "failed to parse STEWARD_TOKEN_EXPIRATION",
+		},
+	}
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(StewardTokenExpirationEnvVar, tc.expiration)
+			_, err := NewAPIServer(APIConfig{APIVersion: "v1", Namespace: "default"})
+			assert.ErrorContains(t, err, tc.err)
+		})
+	}
+}
@@ -22,7 +22,7 @@ const DefaultAPISecretRefNameEnvVar = "DEFAULT_API_SECRET_REF_NAME"
 func (s *APIImpl) ListTenants(c echo.Context) error {
 	ctx := c.(*APIContext)
 	tenantList := &synv1alpha1.TenantList{}
-	if err := ctx.client.List(ctx.Request().Context(), tenantList, client.InNamespace(s.namespace)); err != nil {
+	if err := s.listTenants(ctx, tenantList, client.InNamespace(s.namespace)); err != nil {
 		return err
 	}
 	tenants := []api.Tenant{}
@@ -42,16 +42,27 @@ func (s *APIImpl) CreateTenant(c echo.Context) error {
 	}
 	apiTenant := api.Tenant(*newTenant)
 
-	tenant, err := api.NewCRDFromAPITenant(apiTenant)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	create := func() error {
+		tenant, err := api.NewCRDFromAPITenant(apiTenant)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		return s.createTenant(ctx, tenant)
 	}
-
-	return s.createTenant(ctx, tenant)
+	if apiTenant.Id.String() != "" {
+		return create()
+	}
+	return createWithGeneratedID(api.TenantIDPrefix, pointer.GetString(apiTenant.DisplayName), func(id api.Id) error {
+		apiTenant.Id = &id
+		return create()
+	})
 }
 
 func (s *APIImpl) createTenant(ctx *APIContext, tenant *synv1alpha1.Tenant) error {
 	tenant.Namespace = s.namespace
+	if err := s.authorizeReserved(ctx, "tenants", tenant.Name, changedReservedOfTenant(&synv1alpha1.Tenant{}, tenant)); err != nil {
+		return err
+	}
 	if name, ok := os.LookupEnv(DefaultAPISecretRefNameEnvVar); ok &&
 		tenant.Spec.GitRepoTemplate != nil &&
 		tenant.Spec.GitRepoTemplate.RepoType == synv1alpha1.AutoRepoType {
@@ -107,7 +118,13 @@ func (s *APIImpl) UpdateTenant(c echo.Context, tenantID api.TenantIdParameter) e
 		return err
 	}
 
-	api.SyncCRDFromAPITenant(patchTenant, existingTenant)
+	old := existingTenant.DeepCopy()
+	if err := api.SyncCRDFromAPITenant(patchTenant, existingTenant); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if err := s.authorizeReserved(ctx, "tenants", existingTenant.Name, changedReservedOfTenant(old, existingTenant)); err != nil {
+		return err
+	}
 
 	return s.updateTenant(ctx, existingTenant)
 }
@@ -145,6 +162,11 @@ func (s *APIImpl) PutTenant(c echo.Context, tenantID api.TenantIdParameter) erro
 		return s.createTenant(ctx, tenant)
 	}
 
+	tenant.Annotations = keepReserved(found.Annotations, tenant.Annotations, configuredReservedKeys().isAnnotation)
+	if err := s.authorizeReserved(ctx, "tenants", found.Name, changedReservedOfTenant(found, tenant)); err != nil {
+		return err
+	}
+
 	found.Spec = tenant.Spec
 	found.Annotations = tenant.Annotations
 	return s.updateTenant(ctx, found)